@@ -0,0 +1,16 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// An account holds a single feed per band plus its own feed, which has no
+// band, so regenerating a token can never leave an older one working
+var calendarFeedsScopeIndex = Migration{
+	Name: "0006_calendar_feeds_scope_index",
+	Run: func(tx *gorm.DB) error {
+		return execAll(tx,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feeds_scope
+				ON calendar_feeds (account_id, COALESCE(band_id, 0))
+				WHERE deleted_at IS NULL`,
+		)
+	},
+}
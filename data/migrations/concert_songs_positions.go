@@ -0,0 +1,20 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// Numbers existing setlist entries of every concert in the order they were
+// added, which is the order they were listed in before positions existed
+var concertSongsPositions = Migration{
+	Name: "0005_concert_songs_positions",
+	Run: func(tx *gorm.DB) error {
+		return execAll(tx, `
+			UPDATE concert_songs SET position = numbered.position
+			FROM (
+				SELECT id, ROW_NUMBER() OVER (PARTITION BY concert_id ORDER BY id) - 1 AS position
+				FROM concert_songs
+				WHERE deleted_at IS NULL
+			) AS numbered
+			WHERE concert_songs.id = numbered.id
+		`)
+	},
+}
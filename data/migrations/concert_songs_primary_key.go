@@ -0,0 +1,36 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// Setlist entries used to be a join table keyed by (concert_id, song_id),
+// which rejects the same song twice on a concert. The entry id AutoMigrate
+// added is made the key instead, on databases still keyed the old way.
+var concertSongsPrimaryKey = Migration{
+	Name: "0007_concert_songs_primary_key",
+	Run: func(tx *gorm.DB) error {
+		return execAll(tx,
+			`ALTER TABLE concert_songs ADD COLUMN IF NOT EXISTS id BIGSERIAL`,
+			`DO $$
+			DECLARE
+				key_name TEXT;
+			BEGIN
+				SELECT c.conname INTO key_name
+				FROM pg_constraint c
+				JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = ANY (c.conkey)
+				WHERE c.conrelid = 'concert_songs'::regclass AND c.contype = 'p' AND a.attname <> 'id'
+				LIMIT 1;
+
+				IF key_name IS NOT NULL THEN
+					EXECUTE format('ALTER TABLE concert_songs DROP CONSTRAINT %I', key_name);
+				END IF;
+
+				IF NOT EXISTS (
+					SELECT 1 FROM pg_constraint
+					WHERE conrelid = 'concert_songs'::regclass AND contype = 'p'
+				) THEN
+					ALTER TABLE concert_songs ADD PRIMARY KEY (id);
+				END IF;
+			END $$`,
+		)
+	},
+}
@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SchemaMigration records a migration that was already applied
+type SchemaMigration struct {
+	ID        uint      `gorm:"primarykey"`
+	Name      string    `gorm:"uniqueIndex"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// Migration is a one time database change that AutoMigrate can't express,
+// like extensions, triggers, indexes and data backfills
+type Migration struct {
+	Name string
+	Run  func(*gorm.DB) error
+}
+
+// Ordered list of migrations, new entries must always be appended
+var migrations = []Migration{
+	songsFullTextSearch,
+	songsInitialRevisions,
+	songsCategoriesToTags,
+	songsKeyIndex,
+	concertSongsPositions,
+	calendarFeedsScopeIndex,
+	concertSongsPrimaryKey,
+}
+
+// Run applies every pending migration, each one inside its own transaction
+func Run(db *gorm.DB) error {
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return err
+	}
+
+	for _, migration := range migrations {
+		var count int64
+		if err := db.Model(&SchemaMigration{}).Where("name = ?", migration.Name).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Run(tx); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{Name: migration.Name, AppliedAt: time.Now()}).Error
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Runs each statement in order, stopping at the first failure
+func execAll(tx *gorm.DB, statements ...string) error {
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
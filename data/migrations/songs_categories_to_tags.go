@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"gorm.io/gorm"
+)
+
+// Free form song categories become band tags linked to their songs. The
+// search vector function is replaced first since it used to read the
+// category column, which is dropped at the end.
+var songsCategoriesToTags = Migration{
+	Name: "0003_songs_categories_to_tags",
+	Run: func(tx *gorm.DB) error {
+		if err := execAll(tx,
+			`CREATE OR REPLACE FUNCTION songs_search_vector_update() RETURNS trigger AS $$
+			BEGIN
+				NEW.search_vector :=
+					setweight(to_tsvector('pt_unaccent', coalesce(NEW.title, '')), 'A') ||
+					setweight(to_tsvector('pt_unaccent', coalesce(NEW.writter, '')), 'B') ||
+					setweight(to_tsvector('pt_unaccent', coalesce(NEW.lyrics, '')), 'C');
+				RETURN NEW;
+			END $$ LANGUAGE plpgsql`,
+		); err != nil {
+			return err
+		}
+
+		if !tx.Migrator().HasColumn(&song.Song{}, "category") {
+			return nil
+		}
+
+		return execAll(tx,
+			// One tag per distinct category of each band, ignoring case and
+			// surrounding spaces so typos like "Worship " collapse together
+			`INSERT INTO tags (created_at, updated_at, title, band_id)
+			SELECT NOW(), NOW(), MIN(TRIM(songs.category)), songs.band_id
+			FROM songs
+			WHERE songs.category IS NOT NULL AND TRIM(songs.category) <> ''
+				AND NOT EXISTS (
+					SELECT 1 FROM tags
+					WHERE tags.band_id = songs.band_id
+						AND tags.deleted_at IS NULL
+						AND LOWER(tags.title) = LOWER(TRIM(songs.category))
+				)
+			GROUP BY songs.band_id, LOWER(TRIM(songs.category))`,
+			`INSERT INTO song_tags (song_id, tag_id)
+			SELECT songs.id, tags.id
+			FROM songs
+			JOIN tags ON tags.band_id = songs.band_id
+				AND tags.deleted_at IS NULL
+				AND LOWER(tags.title) = LOWER(TRIM(songs.category))
+			WHERE songs.category IS NOT NULL
+			ON CONFLICT DO NOTHING`,
+			`ALTER TABLE songs DROP COLUMN category`,
+			// Refresh search vectors without the category weight
+			`UPDATE songs SET lyrics = lyrics`,
+		)
+	},
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"github.com/mazurco066/playliter-api-go/infra/chords"
+	"gorm.io/gorm"
+)
+
+// Accent insensitive portuguese full text search over songs. The search
+// vector is kept by a trigger so every insert or update refreshes it, the
+// lyrics column (body without chords) is filled by the application.
+var songsFullTextSearch = Migration{
+	Name: "0001_songs_full_text_search",
+	Run: func(tx *gorm.DB) error {
+		if err := execAll(tx,
+			`CREATE EXTENSION IF NOT EXISTS unaccent`,
+			`DO $$
+			BEGIN
+				IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'pt_unaccent') THEN
+					CREATE TEXT SEARCH CONFIGURATION pt_unaccent (COPY = portuguese);
+					ALTER TEXT SEARCH CONFIGURATION pt_unaccent
+						ALTER MAPPING FOR hword, hword_part, word WITH unaccent, portuguese_stem;
+				END IF;
+			END $$`,
+			`ALTER TABLE songs ADD COLUMN IF NOT EXISTS search_vector tsvector`,
+			`CREATE OR REPLACE FUNCTION songs_search_vector_update() RETURNS trigger AS $$
+			BEGIN
+				NEW.search_vector :=
+					setweight(to_tsvector('pt_unaccent', coalesce(NEW.title, '')), 'A') ||
+					setweight(to_tsvector('pt_unaccent', coalesce(NEW.writter, '')), 'B') ||
+					setweight(to_tsvector('pt_unaccent', coalesce(NEW.category, '')), 'B') ||
+					setweight(to_tsvector('pt_unaccent', coalesce(NEW.lyrics, '')), 'C');
+				RETURN NEW;
+			END $$ LANGUAGE plpgsql`,
+			`DROP TRIGGER IF EXISTS songs_search_vector_trigger ON songs`,
+			`CREATE TRIGGER songs_search_vector_trigger
+				BEFORE INSERT OR UPDATE ON songs
+				FOR EACH ROW EXECUTE FUNCTION songs_search_vector_update()`,
+			`CREATE INDEX IF NOT EXISTS idx_songs_search_vector ON songs USING GIN (search_vector)`,
+		); err != nil {
+			return err
+		}
+
+		// Backfill lyrics of existing songs, which also fires the trigger
+		var songs []*song.Song
+		if err := tx.Unscoped().Select("id", "body").Find(&songs).Error; err != nil {
+			return err
+		}
+		for _, s := range songs {
+			if err := tx.Unscoped().Model(&song.Song{}).
+				Where("id = ?", s.ID).
+				UpdateColumn("lyrics", chords.Lyrics(s.Body)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"gorm.io/gorm"
+)
+
+// Songs created before revision history existed get their current content
+// recorded as the first revision, authored by the band owner
+var songsInitialRevisions = Migration{
+	Name: "0002_songs_initial_revisions",
+	Run: func(tx *gorm.DB) error {
+		var songs []*song.Song
+		if err := tx.
+			Where("NOT EXISTS (SELECT 1 FROM revisions WHERE revisions.song_id = songs.id)").
+			Preload("Band").
+			Find(&songs).Error; err != nil {
+			return err
+		}
+
+		for _, s := range songs {
+			revision := song.Revision{
+				SongID:      s.ID,
+				Number:      1,
+				AuthorID:    s.Band.OwnerID,
+				Title:       s.Title,
+				Writter:     s.Writter,
+				Tone:        s.Tone,
+				Body:        s.Body,
+				EmbeddedUrl: s.EmbeddedUrl,
+			}
+			if err := tx.Omit("Song", "Author").Create(&revision).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"github.com/mazurco066/playliter-api-go/infra/chords"
+	"gorm.io/gorm"
+)
+
+// Rewrites existing tones into their canonical spelling and fills the key
+// index used to sort and filter songs by key. Tones that are not a valid
+// key are left untouched, without an index.
+var songsKeyIndex = Migration{
+	Name: "0004_songs_key_index",
+	Run: func(tx *gorm.DB) error {
+		var songs []*song.Song
+		if err := tx.Unscoped().Select("id", "tone").Find(&songs).Error; err != nil {
+			return err
+		}
+		for _, s := range songs {
+			key, err := chords.ParseKey(s.Tone)
+			if err != nil {
+				continue
+			}
+			if err := tx.Unscoped().Model(&song.Song{}).
+				Where("id = ?", s.ID).
+				UpdateColumns(map[string]interface{}{
+					"tone":      key.String(),
+					"key_index": key.Index(),
+				}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
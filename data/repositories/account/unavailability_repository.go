@@ -0,0 +1,67 @@
+package accountrepo
+
+import (
+	"time"
+
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"gorm.io/gorm"
+)
+
+type UnavailabilityRepo interface {
+	Create(*account.Unavailability) error
+	FindByAccount(*account.Account, time.Time) ([]*account.Unavailability, error)
+	FindById(uint) (*account.Unavailability, error)
+	FindConflicts([]uint, time.Time) ([]*account.Unavailability, error)
+	Remove(*account.Unavailability) error
+}
+
+type unavailabilityRepo struct {
+	db *gorm.DB
+}
+
+func NewUnavailabilityRepo(db *gorm.DB) UnavailabilityRepo {
+	return &unavailabilityRepo{
+		db: db,
+	}
+}
+
+func (repo *unavailabilityRepo) Create(u *account.Unavailability) error {
+	return repo.db.Omit("Account").Create(u).Error
+}
+
+// Periods of the account that did not end before the given day
+func (repo *unavailabilityRepo) FindByAccount(a *account.Account, since time.Time) ([]*account.Unavailability, error) {
+	var results []*account.Unavailability
+	if err := repo.db.
+		Where("account_id = ? AND ends_on >= ?", a.ID, since.Format(time.DateOnly)).
+		Order("starts_on ASC, id ASC").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (repo *unavailabilityRepo) FindById(id uint) (*account.Unavailability, error) {
+	var result account.Unavailability
+	if err := repo.db.Where("id = ?", id).First(&result).Error; err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Periods of the accounts that include the given day
+func (repo *unavailabilityRepo) FindConflicts(accountIDs []uint, day time.Time) ([]*account.Unavailability, error) {
+	var results []*account.Unavailability
+	if err := repo.db.
+		Where("account_id IN ? AND starts_on <= ? AND ends_on >= ?", accountIDs, day.Format(time.DateOnly), day.Format(time.DateOnly)).
+		Preload("Account").
+		Order("account_id ASC, starts_on ASC").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (repo *unavailabilityRepo) Remove(u *account.Unavailability) error {
+	return repo.db.Delete(u).Error
+}
@@ -0,0 +1,82 @@
+package concertrepo
+
+import (
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/concert"
+	"gorm.io/gorm"
+)
+
+type CalendarFeedRepo interface {
+	FindBandIDs(*account.Account) ([]uint, error)
+	FindByToken(string) (*concert.CalendarFeed, error)
+	Remove(*account.Account, *band.Band) error
+	Replace(*concert.CalendarFeed) error
+}
+
+type calendarFeedRepo struct {
+	db *gorm.DB
+}
+
+func NewCalendarFeedRepo(db *gorm.DB) CalendarFeedRepo {
+	return &calendarFeedRepo{
+		db: db,
+	}
+}
+
+// Bands the account owns or currently plays on
+func (repo *calendarFeedRepo) FindBandIDs(a *account.Account) ([]uint, error) {
+	var ids []uint
+	if err := repo.db.
+		Model(&band.Band{}).
+		Where("owner_id = ? OR EXISTS (SELECT 1 FROM members WHERE bands.id = members.band_id AND members.account_id = ? AND members.deleted_at IS NULL)", a.ID, a.ID).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (repo *calendarFeedRepo) FindByToken(tokenHash string) (*concert.CalendarFeed, error) {
+	var feed concert.CalendarFeed
+	if err := repo.db.
+		Where("token_hash = ?", tokenHash).
+		Preload("Account").
+		Preload("Band").
+		First(&feed).Error; err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// Revokes the feed of the account for the band, or its own feed when no
+// band is given
+func (repo *calendarFeedRepo) Remove(a *account.Account, b *band.Band) error {
+	result := repo.db.Unscoped().Scopes(feedScope(a.ID, b)).Delete(&concert.CalendarFeed{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Stores the feed in place of the previous one of the same scope, whose
+// token stops working
+func (repo *calendarFeedRepo) Replace(feed *concert.CalendarFeed) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Scopes(feedScope(feed.AccountID, feed.Band)).Delete(&concert.CalendarFeed{}).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Account", "Band").Create(feed).Error
+	})
+}
+
+func feedScope(accountID uint, b *band.Band) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if b == nil {
+			return db.Where("account_id = ? AND band_id IS NULL", accountID)
+		}
+		return db.Where("account_id = ? AND band_id = ?", accountID, b.ID)
+	}
+}
@@ -1,8 +1,22 @@
 package concertrepo
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	commoninputs "github.com/mazurco066/playliter-api-go/domain/inputs/common"
+	concertinputs "github.com/mazurco066/playliter-api-go/domain/inputs/concert"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/concert"
+	"gorm.io/gorm"
+)
 
 type Repo interface {
+	Create(*concert.Concert) error
+	FindByBand(*band.Band, *concertinputs.ListParams, *commoninputs.PagingParams) ([]*concert.Concert, error)
+	FindById(id uint) (*concert.Concert, error)
+	FindForCalendar([]uint, time.Time) ([]*concert.Concert, error)
+	Remove(*concert.Concert) error
+	Update(*concert.Concert) error
 }
 
 type ConcertRepo struct {
@@ -14,3 +28,115 @@ func NewConcertRepo(db *gorm.DB) Repo {
 		db: db,
 	}
 }
+
+func (repo *ConcertRepo) Create(c *concert.Concert) error {
+	return repo.db.Omit("Band", "Songs").Create(c).Error
+}
+
+// Upcoming concerts are listed soonest first, any other listing starts
+// from the most recent concert
+func (repo *ConcertRepo) FindByBand(b *band.Band, f *concertinputs.ListParams, p *commoninputs.PagingParams) ([]*concert.Concert, error) {
+	query := repo.db.Where("band_id = ?", b.ID)
+
+	order := "date DESC"
+	switch f.When {
+	case concertinputs.WhenUpcoming:
+		query = query.Where("date >= ?", time.Now())
+		order = "date ASC"
+	case concertinputs.WhenPast:
+		query = query.Where("date < ?", time.Now())
+	}
+	if f.From != nil {
+		query = query.Where("date >= ?", *f.From)
+	}
+	if f.To != nil {
+		query = query.Where("date < ?", *f.To)
+	}
+
+	var results []*concert.Concert
+	if err := query.
+		Preload("Band").
+		Preload("Songs", songsInPlay).
+		Preload("Songs.Song").
+		Preload("Songs.Arrangement", sectionsInOrder).
+		Preload("Songs.Arrangement.Section").
+		Preload("Songs.LeadVocalist").
+		Order(order).
+		Limit(p.Limit).
+		Offset(p.Offset).
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (repo *ConcertRepo) FindById(id uint) (*concert.Concert, error) {
+	var concert concert.Concert
+	if err := repo.db.
+		Where("id = ?", id).
+		Preload("Band").
+		Preload("Band.Owner").
+		Preload("Band.Members").
+		Preload("Band.Members.Account").
+		Preload("Songs", songsInPlay).
+		Preload("Songs.Song").
+		Preload("Songs.Arrangement", sectionsInOrder).
+		Preload("Songs.Arrangement.Section").
+		Preload("Songs.LeadVocalist").
+		First(&concert).Error; err != nil {
+		return nil, err
+	}
+	return &concert, nil
+}
+
+// Concerts of the bands dated from the given time on, deleted ones included
+// so calendar feeds can publish them as cancelled
+func (repo *ConcertRepo) FindForCalendar(bandIDs []uint, from time.Time) ([]*concert.Concert, error) {
+	var results []*concert.Concert
+	if len(bandIDs) == 0 {
+		return results, nil
+	}
+	// Preloads inherit Unscoped, so removed setlist entries are left out here
+	if err := repo.db.
+		Unscoped().
+		Where("band_id IN ? AND date >= ?", bandIDs, from).
+		Preload("Band").
+		Preload("Songs", func(db *gorm.DB) *gorm.DB {
+			return songsInPlay(db).Where("concert_songs.deleted_at IS NULL")
+		}).
+		Preload("Songs.Song").
+		Order("date ASC, id ASC").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Removes the concert along with its setlist. The removal is a new revision
+// of the concert, published as a cancellation on calendar feeds.
+func (repo *ConcertRepo) Remove(c *concert.Concert) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(c).UpdateColumn("sequence", gorm.Expr("sequence + 1")).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("concert_id = ?", c.ID).Delete(&concert.ConcertSong{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(c).Error
+	})
+}
+
+func (repo *ConcertRepo) Update(c *concert.Concert) error {
+	return repo.db.Omit("Band", "Songs").Save(c).Error
+}
+
+// Setlist entries of songs that were not deleted, in setlist order
+func songsInPlay(db *gorm.DB) *gorm.DB {
+	return db.
+		Where("EXISTS (SELECT 1 FROM songs WHERE songs.id = concert_songs.song_id AND songs.deleted_at IS NULL)").
+		Order("concert_songs.position ASC, concert_songs.id ASC")
+}
+
+func sectionsInOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("setlist_sections.position ASC")
+}
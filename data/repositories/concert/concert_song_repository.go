@@ -0,0 +1,218 @@
+package concertrepo
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mazurco066/playliter-api-go/domain/models/concert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Every setlist change locks the concert and renumbers its entries inside a
+// single transaction, so concurrent edits can not leave gaps or duplicated
+// positions behind. Changes are also a new revision of the concert, which
+// calendar feeds publish.
+type ConcertSongRepo interface {
+	Insert(*concert.ConcertSong, int) error
+	Move(*concert.ConcertSong, int) error
+	Remove(*concert.ConcertSong) error
+	Reorder(*concert.Concert, []uint) error
+	SaveOverrides(*concert.ConcertSong, []*concert.SetlistSection) error
+}
+
+type concertSongRepo struct {
+	db *gorm.DB
+}
+
+func NewConcertSongRepo(db *gorm.DB) ConcertSongRepo {
+	return &concertSongRepo{
+		db: db,
+	}
+}
+
+// Inserts the entry at the given index, a negative or out of range index
+// appends it to the setlist
+func (repo *concertSongRepo) Insert(entry *concert.ConcertSong, index int) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		entries, err := lockSetlist(tx, entry.ConcertID)
+		if err != nil {
+			return err
+		}
+		if index < 0 || index > len(entries) {
+			index = len(entries)
+		}
+
+		entry.Position = index
+		if err := tx.Omit("Concert", "Song").Create(entry).Error; err != nil {
+			return err
+		}
+		entries = append(entries[:index], append([]*concert.ConcertSong{entry}, entries[index:]...)...)
+		return renumber(tx, entries)
+	})
+}
+
+// Moves the entry to the given index, an out of range index moves it to
+// the end of the setlist
+func (repo *concertSongRepo) Move(entry *concert.ConcertSong, index int) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		entries, err := lockSetlist(tx, entry.ConcertID)
+		if err != nil {
+			return err
+		}
+
+		var rest []*concert.ConcertSong
+		var moved *concert.ConcertSong
+		for _, e := range entries {
+			if e.ID == entry.ID {
+				moved = e
+				continue
+			}
+			rest = append(rest, e)
+		}
+		if moved == nil {
+			return gorm.ErrRecordNotFound
+		}
+		if index < 0 || index > len(rest) {
+			index = len(rest)
+		}
+
+		rest = append(rest[:index], append([]*concert.ConcertSong{moved}, rest[index:]...)...)
+		if err := renumber(tx, rest); err != nil {
+			return err
+		}
+		entry.Position = moved.Position
+		return nil
+	})
+}
+
+func (repo *concertSongRepo) Remove(entry *concert.ConcertSong) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		entries, err := lockSetlist(tx, entry.ConcertID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(entry).Error; err != nil {
+			return err
+		}
+
+		var rest []*concert.ConcertSong
+		for _, e := range entries {
+			if e.ID != entry.ID {
+				rest = append(rest, e)
+			}
+		}
+		return renumber(tx, rest)
+	})
+}
+
+// Puts the setlist in the order of the given entry ids, which must be
+// every entry of the setlist
+func (repo *concertSongRepo) Reorder(c *concert.Concert, ids []uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		entries, err := lockSetlist(tx, c.ID)
+		if err != nil {
+			return err
+		}
+
+		byID := map[uint]*concert.ConcertSong{}
+		for _, e := range entries {
+			byID[e.ID] = e
+		}
+		// The setlist may have changed since the order was sent
+		changed := errors.New("setlist order must list every entry of the setlist once")
+		if len(ids) != len(entries) {
+			return changed
+		}
+		ordered := make([]*concert.ConcertSong, 0, len(ids))
+		for _, id := range ids {
+			e, ok := byID[id]
+			if !ok {
+				return changed
+			}
+			delete(byID, id)
+			ordered = append(ordered, e)
+		}
+		return renumber(tx, ordered)
+	})
+}
+
+// Writes the entry overrides and replaces its arrangement, the setlist order
+// is not touched so the concert is only revised
+func (repo *concertSongRepo) SaveOverrides(entry *concert.ConcertSong, items []*concert.SetlistSection) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := revise(tx, entry.ConcertID); err != nil {
+			return err
+		}
+		if err := tx.Model(entry).
+			Updates(map[string]interface{}{
+				"key":              entry.Key,
+				"capo":             entry.Capo,
+				"lead_vocalist_id": entry.LeadVocalistID,
+				"segue":            entry.Segue,
+				"transition":       entry.Transition,
+			}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("concert_song_id = ?", entry.ID).Delete(&concert.SetlistSection{}).Error; err != nil {
+			return err
+		}
+		for _, item := range items {
+			item.ConcertSongID = entry.ID
+			if err := tx.Omit("Section").Create(item).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Locks the concert row, revising it, and loads its setlist in order.
+// Entries of deleted songs are not listed and keep their position.
+func lockSetlist(tx *gorm.DB, concertID uint) ([]*concert.ConcertSong, error) {
+	if err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&concert.Concert{}, concertID).Error; err != nil {
+		return nil, err
+	}
+	if err := revise(tx, concertID); err != nil {
+		return nil, err
+	}
+
+	var entries []*concert.ConcertSong
+	if err := tx.
+		Where("concert_id = ?", concertID).
+		Scopes(songsInPlay).
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Bumps the sequence and modification time of the concert, so calendar
+// clients pick up the setlist change
+func revise(tx *gorm.DB, concertID uint) error {
+	return tx.Model(&concert.Concert{}).
+		Where("id = ?", concertID).
+		UpdateColumns(map[string]interface{}{
+			"sequence":   gorm.Expr("sequence + 1"),
+			"updated_at": time.Now(),
+		}).Error
+}
+
+// Writes zero based positions in slice order, skipping unchanged entries
+func renumber(tx *gorm.DB, entries []*concert.ConcertSong) error {
+	for i, e := range entries {
+		if e.Position == i {
+			continue
+		}
+		if err := tx.Model(&concert.ConcertSong{}).
+			Where("id = ?", e.ID).
+			Update("position", i).Error; err != nil {
+			return err
+		}
+		e.Position = i
+	}
+	return nil
+}
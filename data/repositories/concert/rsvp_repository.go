@@ -0,0 +1,47 @@
+package concertrepo
+
+import (
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/concert"
+	"gorm.io/gorm"
+)
+
+type RSVPRepo interface {
+	Find(*concert.Concert, *account.Account) (*concert.RSVP, error)
+	FindByConcert(*concert.Concert) ([]*concert.RSVP, error)
+	Save(*concert.RSVP) error
+}
+
+type rsvpRepo struct {
+	db *gorm.DB
+}
+
+func NewRSVPRepo(db *gorm.DB) RSVPRepo {
+	return &rsvpRepo{
+		db: db,
+	}
+}
+
+func (repo *rsvpRepo) Find(c *concert.Concert, a *account.Account) (*concert.RSVP, error) {
+	var rsvp concert.RSVP
+	if err := repo.db.
+		Where("concert_id = ? AND account_id = ?", c.ID, a.ID).
+		First(&rsvp).Error; err != nil {
+		return nil, err
+	}
+	return &rsvp, nil
+}
+
+func (repo *rsvpRepo) FindByConcert(c *concert.Concert) ([]*concert.RSVP, error) {
+	var results []*concert.RSVP
+	if err := repo.db.
+		Where("concert_id = ?", c.ID).
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (repo *rsvpRepo) Save(rsvp *concert.RSVP) error {
+	return repo.db.Omit("Concert", "Account").Save(rsvp).Error
+}
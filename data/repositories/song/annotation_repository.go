@@ -0,0 +1,103 @@
+package songrepo
+
+import (
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"gorm.io/gorm"
+)
+
+type AnnotationRepo interface {
+	Create(*song.Annotation) error
+	FindAnchored(*song.Song) ([]*song.Annotation, error)
+	FindById(uint) (*song.Annotation, error)
+	FindBySong(*song.Song, *account.Account, bool) ([]*song.Annotation, error)
+	Remove(*song.Annotation) error
+	Update(*song.Annotation) error
+}
+
+type annotationRepo struct {
+	db *gorm.DB
+}
+
+func NewAnnotationRepo(db *gorm.DB) AnnotationRepo {
+	return &annotationRepo{
+		db: db,
+	}
+}
+
+func (repo *annotationRepo) Create(annotation *song.Annotation) error {
+	return repo.db.Omit("Song", "Author", "Replies").Create(annotation).Error
+}
+
+// Thread roots still anchored to a line of the song body
+func (repo *annotationRepo) FindAnchored(s *song.Song) ([]*song.Annotation, error) {
+	var results []*song.Annotation
+	if err := repo.db.
+		Where("song_id = ? AND parent_id IS NULL AND line IS NOT NULL AND orphaned = ?", s.ID, false).
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (repo *annotationRepo) FindById(id uint) (*song.Annotation, error) {
+	var annotation song.Annotation
+	if err := repo.db.
+		Where("id = ?", id).
+		Preload("Author").
+		First(&annotation).Error; err != nil {
+		return nil, err
+	}
+	return &annotation, nil
+}
+
+// Lists every annotation of the song the viewer can see, oldest first.
+// Private annotations are only listed to their authors and hidden ones to
+// their authors and to moderators.
+func (repo *annotationRepo) FindBySong(s *song.Song, viewer *account.Account, moderator bool) ([]*song.Annotation, error) {
+	db := repo.db.
+		Where("song_id = ?", s.ID).
+		Where("private = ? OR author_id = ?", false, viewer.ID)
+	if !moderator {
+		db = db.Where("hidden = ? OR author_id = ?", false, viewer.ID)
+	}
+
+	var results []*song.Annotation
+	if err := db.
+		Preload("Author").
+		Order("created_at ASC").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Removes the annotation along with its replies
+func (repo *annotationRepo) Remove(annotation *song.Annotation) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("parent_id = ?", annotation.ID).Delete(&song.Annotation{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(annotation).Error
+	})
+}
+
+func (repo *annotationRepo) Update(annotation *song.Annotation) error {
+	return repo.db.Omit("Song", "Author", "Replies").Save(annotation).Error
+}
+
+// Moves line annotations along with a body change, must run inside the song transaction
+func reanchorAnnotations(tx *gorm.DB, annotations []*song.Annotation) error {
+	for _, annotation := range annotations {
+		if err := tx.Model(annotation).
+			Select("line", "line_text", "orphaned").
+			Updates(map[string]interface{}{
+				"line":      annotation.Line,
+				"line_text": annotation.LineText,
+				"orphaned":  annotation.Orphaned,
+			}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
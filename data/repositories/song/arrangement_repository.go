@@ -0,0 +1,48 @@
+package songrepo
+
+import (
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"gorm.io/gorm"
+)
+
+type ArrangementRepo interface {
+	FindBySong(*song.Song) ([]*song.ArrangementItem, error)
+	Replace(*song.Song, []*song.ArrangementItem) error
+}
+
+type arrangementRepo struct {
+	db *gorm.DB
+}
+
+func NewArrangementRepo(db *gorm.DB) ArrangementRepo {
+	return &arrangementRepo{
+		db: db,
+	}
+}
+
+func (repo *arrangementRepo) FindBySong(s *song.Song) ([]*song.ArrangementItem, error) {
+	var results []*song.ArrangementItem
+	if err := repo.db.
+		Where("song_id = ?", s.ID).
+		Preload("Section").
+		Order("position ASC").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (repo *arrangementRepo) Replace(s *song.Song, items []*song.ArrangementItem) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("song_id = ?", s.ID).Delete(&song.ArrangementItem{}).Error; err != nil {
+			return err
+		}
+		for _, item := range items {
+			item.SongID = s.ID
+			if err := tx.Omit("Song", "Section").Create(item).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,52 @@
+package songrepo
+
+import (
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"gorm.io/gorm"
+)
+
+type MediaLinkRepo interface {
+	Create(*song.MediaLink) error
+	FindById(uint) (*song.MediaLink, error)
+	FindBySong(*song.Song) ([]*song.MediaLink, error)
+	Remove(*song.MediaLink) error
+}
+
+type mediaLinkRepo struct {
+	db *gorm.DB
+}
+
+func NewMediaLinkRepo(db *gorm.DB) MediaLinkRepo {
+	return &mediaLinkRepo{
+		db: db,
+	}
+}
+
+func (repo *mediaLinkRepo) Create(link *song.MediaLink) error {
+	return repo.db.Omit("Song").Create(link).Error
+}
+
+func (repo *mediaLinkRepo) FindById(id uint) (*song.MediaLink, error) {
+	var link song.MediaLink
+	if err := repo.db.
+		Where("id = ?", id).
+		First(&link).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (repo *mediaLinkRepo) FindBySong(s *song.Song) ([]*song.MediaLink, error) {
+	var results []*song.MediaLink
+	if err := repo.db.
+		Where("song_id = ?", s.ID).
+		Order("position ASC").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (repo *mediaLinkRepo) Remove(link *song.MediaLink) error {
+	return repo.db.Delete(link).Error
+}
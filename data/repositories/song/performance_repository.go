@@ -0,0 +1,91 @@
+package songrepo
+
+import (
+	"time"
+
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"gorm.io/gorm"
+)
+
+// Performance history is read from concert setlists, a concert counts once
+// its date is past and a song played twice in it counts once
+type PerformanceRepo interface {
+	CountByBand(*band.Band, time.Time, time.Time, time.Time) ([]*song.PerformanceCount, error)
+	FindBySong(*song.Song, time.Time) ([]*song.Performance, error)
+}
+
+type performanceRepo struct {
+	db *gorm.DB
+}
+
+func NewPerformanceRepo(db *gorm.DB) PerformanceRepo {
+	return &performanceRepo{
+		db: db,
+	}
+}
+
+// Counts the performances of every band song between from and to, along
+// with its history up to the until date
+func (repo *performanceRepo) CountByBand(b *band.Band, from time.Time, to time.Time, until time.Time) ([]*song.PerformanceCount, error) {
+	var rows []struct {
+		SongID        uint
+		Performances  int
+		Total         int
+		LastPerformed *time.Time
+	}
+	if err := repo.db.Raw(`
+		SELECT
+			songs.id AS song_id,
+			COUNT(DISTINCT concerts.id) FILTER (WHERE concerts.date BETWEEN ? AND ?) AS performances,
+			COUNT(DISTINCT concerts.id) AS total,
+			MAX(concerts.date) AS last_performed
+		FROM songs
+		LEFT JOIN concert_songs ON concert_songs.song_id = songs.id AND concert_songs.deleted_at IS NULL
+		LEFT JOIN concerts ON concerts.id = concert_songs.concert_id
+			AND concerts.deleted_at IS NULL
+			AND concerts.date <= ?
+		WHERE songs.band_id = ? AND songs.deleted_at IS NULL
+		GROUP BY songs.id
+	`, from, to, until, b.ID).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	var songs []*song.Song
+	if err := repo.db.Where("band_id = ?", b.ID).Find(&songs).Error; err != nil {
+		return nil, err
+	}
+	byID := map[uint]*song.Song{}
+	for _, s := range songs {
+		byID[s.ID] = s
+	}
+
+	var results []*song.PerformanceCount
+	for _, row := range rows {
+		s, ok := byID[row.SongID]
+		if !ok {
+			continue
+		}
+		results = append(results, &song.PerformanceCount{
+			Song:          s,
+			Performances:  row.Performances,
+			Total:         row.Total,
+			LastPerformed: row.LastPerformed,
+		})
+	}
+	return results, nil
+}
+
+func (repo *performanceRepo) FindBySong(s *song.Song, until time.Time) ([]*song.Performance, error) {
+	var results []*song.Performance
+	if err := repo.db.Raw(`
+		SELECT DISTINCT concerts.id AS concert_id, concerts.date, concerts.venue
+		FROM concert_songs
+		JOIN concerts ON concerts.id = concert_songs.concert_id AND concerts.deleted_at IS NULL
+		WHERE concert_songs.song_id = ? AND concert_songs.deleted_at IS NULL AND concerts.date <= ?
+		ORDER BY concerts.date ASC
+	`, s.ID, until).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
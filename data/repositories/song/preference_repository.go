@@ -0,0 +1,42 @@
+package songrepo
+
+import (
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"gorm.io/gorm"
+)
+
+type PreferenceRepo interface {
+	Find(*song.Song, *account.Account) (*song.Preference, error)
+	Remove(*song.Preference) error
+	Save(*song.Preference) error
+}
+
+type preferenceRepo struct {
+	db *gorm.DB
+}
+
+func NewPreferenceRepo(db *gorm.DB) PreferenceRepo {
+	return &preferenceRepo{
+		db: db,
+	}
+}
+
+func (repo *preferenceRepo) Find(s *song.Song, a *account.Account) (*song.Preference, error) {
+	var preference song.Preference
+	if err := repo.db.
+		Where("song_id = ? AND account_id = ?", s.ID, a.ID).
+		First(&preference).Error; err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+// Preferences are hard deleted so the member can save a new one later
+func (repo *preferenceRepo) Remove(preference *song.Preference) error {
+	return repo.db.Unscoped().Delete(preference).Error
+}
+
+func (repo *preferenceRepo) Save(preference *song.Preference) error {
+	return repo.db.Omit("Song", "Account").Save(preference).Error
+}
@@ -0,0 +1,62 @@
+package songrepo
+
+import (
+	commoninputs "github.com/mazurco066/playliter-api-go/domain/inputs/common"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"gorm.io/gorm"
+)
+
+// Revisions are immutable, they are only created along with song changes
+type RevisionRepo interface {
+	FindByNumber(*song.Song, int) (*song.Revision, error)
+	FindBySong(*song.Song, *commoninputs.PagingParams) ([]*song.Revision, error)
+}
+
+type revisionRepo struct {
+	db *gorm.DB
+}
+
+func NewRevisionRepo(db *gorm.DB) RevisionRepo {
+	return &revisionRepo{
+		db: db,
+	}
+}
+
+func (repo *revisionRepo) FindByNumber(s *song.Song, number int) (*song.Revision, error) {
+	var revision song.Revision
+	if err := repo.db.
+		Where("song_id = ? AND number = ?", s.ID, number).
+		Preload("Author").
+		First(&revision).Error; err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (repo *revisionRepo) FindBySong(s *song.Song, p *commoninputs.PagingParams) ([]*song.Revision, error) {
+	var results []*song.Revision
+	if err := repo.db.
+		Where("song_id = ?", s.ID).
+		Preload("Author").
+		Order("number DESC").
+		Limit(p.Limit).
+		Offset(p.Offset).
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Stores the revision as the next one of the song, must run inside the song transaction
+func createRevision(tx *gorm.DB, s *song.Song, revision *song.Revision) error {
+	var last int
+	if err := tx.Model(&song.Revision{}).
+		Where("song_id = ?", s.ID).
+		Select("COALESCE(MAX(number), 0)").
+		Scan(&last).Error; err != nil {
+		return err
+	}
+	revision.SongID = s.ID
+	revision.Number = last + 1
+	return tx.Omit("Song", "Author").Create(revision).Error
+}
@@ -0,0 +1,99 @@
+package songrepo
+
+import (
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"gorm.io/gorm"
+)
+
+type SectionRepo interface {
+	Create(*song.Section) error
+	FindById(uint) (*song.Section, error)
+	FindBySong(*song.Song) ([]*song.Section, error)
+	Remove(*song.Section) error
+	Replace(*song.Song, []*song.Section, []*song.ArrangementItem) error
+	Update(*song.Section) error
+}
+
+type sectionRepo struct {
+	db *gorm.DB
+}
+
+func NewSectionRepo(db *gorm.DB) SectionRepo {
+	return &sectionRepo{
+		db: db,
+	}
+}
+
+func (repo *sectionRepo) Create(section *song.Section) error {
+	return repo.db.Omit("Song").Create(section).Error
+}
+
+func (repo *sectionRepo) FindById(id uint) (*song.Section, error) {
+	var section song.Section
+	if err := repo.db.
+		Where("id = ?", id).
+		First(&section).Error; err != nil {
+		return nil, err
+	}
+	return &section, nil
+}
+
+func (repo *sectionRepo) FindBySong(s *song.Song) ([]*song.Section, error) {
+	var results []*song.Section
+	if err := repo.db.
+		Where("song_id = ?", s.ID).
+		Order("position ASC").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Removes the section along with every arrangement reference to it, its
+// annotations are kept as orphans
+func (repo *sectionRepo) Remove(section *song.Section) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("section_id = ?", section.ID).Delete(&song.ArrangementItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&song.Annotation{}).Where("section_id = ?", section.ID).Update("orphaned", true).Error; err != nil {
+			return err
+		}
+		return tx.Delete(section).Error
+	})
+}
+
+// Replaces every section and the arrangement of a song at once. Items
+// reference sections by their index in the given slice through SectionID.
+// Annotations of the replaced sections are kept as orphans.
+func (repo *sectionRepo) Replace(s *song.Song, sections []*song.Section, items []*song.ArrangementItem) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("song_id = ?", s.ID).Delete(&song.ArrangementItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&song.Annotation{}).Where("song_id = ? AND section_id IS NOT NULL", s.ID).Update("orphaned", true).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("song_id = ?", s.ID).Delete(&song.Section{}).Error; err != nil {
+			return err
+		}
+		for _, section := range sections {
+			section.SongID = s.ID
+			if err := tx.Omit("Song").Create(section).Error; err != nil {
+				return err
+			}
+		}
+		for _, item := range items {
+			item.SongID = s.ID
+			item.SectionID = sections[item.SectionID].ID
+			if err := tx.Omit("Song", "Section").Create(item).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (repo *sectionRepo) Update(section *song.Section) error {
+	return repo.db.Omit("Song").Save(section).Error
+}
@@ -1,8 +1,32 @@
 package songrepo
 
-import "gorm.io/gorm"
+import (
+	"html"
+	"strings"
+
+	commoninputs "github.com/mazurco066/playliter-api-go/domain/inputs/common"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
 
 type Repo interface {
+	Create(*song.Song, *song.Revision) error
+	FindByBand(*band.Band, *songinputs.ListParams, *commoninputs.PagingParams) ([]*song.Song, error)
+	FindAllByBand(*band.Band) ([]*song.Song, error)
+	FindById(uint) (*song.Song, error)
+	FindPublic(string, *commoninputs.PagingParams) ([]*song.Song, error)
+	FindPublicById(uint) (*song.Song, error)
+	FindTitles(*band.Band) ([]string, error)
+	Import([]*song.Song, []*song.Revision) error
+	Merge(*song.Song, *song.Song) error
+	Remove(*song.Song) error
+	Search(*account.Account, string, uint, *commoninputs.PagingParams) ([]*song.SearchResult, error)
+	Update(*song.Song, *song.Revision, []*song.Annotation) error
+	UpdateVisibility(*song.Song) error
 }
 
 type SongRepo struct {
@@ -14,3 +38,375 @@ func NewSongRepo(db *gorm.DB) Repo {
 		db: db,
 	}
 }
+
+// Creates the song along with its tags and first revision
+func (repo *SongRepo) Create(s *song.Song, revision *song.Revision) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		// Translations, set by the OpenLyrics import, are created along with the song
+		if err := tx.Omit("Band", "Tags", "MediaLinks").Create(s).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(s).Association("Tags").Replace(s.Tags); err != nil {
+			return err
+		}
+		return createRevision(tx, s, revision)
+	})
+}
+
+func (repo *SongRepo) FindByBand(b *band.Band, f *songinputs.ListParams, p *commoninputs.PagingParams) ([]*song.Song, error) {
+	query := repo.db.Where("band_id = ?", b.ID)
+
+	// Tag filters, matching songs with any or with all of the given tags
+	if len(f.Tags) > 0 {
+		if f.TagMatch == "all" {
+			query = query.Where(
+				"(SELECT COUNT(DISTINCT song_tags.tag_id) FROM song_tags WHERE song_tags.song_id = songs.id AND song_tags.tag_id IN ?) = ?",
+				f.Tags, countUnique(f.Tags),
+			)
+		} else {
+			query = query.Where(
+				"EXISTS (SELECT 1 FROM song_tags WHERE song_tags.song_id = songs.id AND song_tags.tag_id IN ?)",
+				f.Tags,
+			)
+		}
+	}
+
+	// Musical metadata filters
+	if f.Key != "" {
+		query = query.Where("tone = ?", f.Key)
+	}
+	if f.MinBpm > 0 {
+		query = query.Where("bpm >= ?", f.MinBpm)
+	}
+	if f.MaxBpm > 0 {
+		query = query.Where("bpm <= ?", f.MaxBpm)
+	}
+
+	var results []*song.Song
+	if err := query.
+		Preload("Band").
+		Preload("Tags").
+		Order(listOrder(f)).
+		Limit(p.Limit).
+		Offset(p.Offset).
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Every song of the band without paging, meant for whole repertoire checks
+func (repo *SongRepo) FindAllByBand(b *band.Band) ([]*song.Song, error) {
+	var results []*song.Song
+	if err := repo.db.
+		Where("band_id = ?", b.ID).
+		Preload("Band").
+		Preload("Tags").
+		Order("id ASC").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (repo *SongRepo) FindById(id uint) (*song.Song, error) {
+	var song song.Song
+	if err := repo.db.
+		Where("id = ?", id).
+		Preload("Band").
+		Preload("Band.Members").
+		Preload("Tags").
+		Preload("MediaLinks", orderedByPosition).
+		First(&song).Error; err != nil {
+		return nil, err
+	}
+	return &song, nil
+}
+
+// Saves the song and its tags, recording the given revision of its new content
+func (repo *SongRepo) Update(s *song.Song, revision *song.Revision, annotations []*song.Annotation) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Band", "Tags", "MediaLinks", "Translations").Save(s).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(s).Association("Tags").Replace(s.Tags); err != nil {
+			return err
+		}
+		if err := reanchorAnnotations(tx, annotations); err != nil {
+			return err
+		}
+		return createRevision(tx, s, revision)
+	})
+}
+
+// Lists catalog songs, ranked by relevance when a search query is given
+func (repo *SongRepo) FindPublic(query string, p *commoninputs.PagingParams) ([]*song.Song, error) {
+	db := repo.db.Where("is_public = ?", true)
+	if query != "" {
+		db = db.
+			Where("search_vector @@ websearch_to_tsquery('pt_unaccent', ?)", query).
+			Order(clause.OrderBy{Expression: clause.Expr{
+				SQL:                "ts_rank(search_vector, websearch_to_tsquery('pt_unaccent', ?)) DESC",
+				Vars:               []interface{}{query},
+				WithoutParentheses: true,
+			}})
+	}
+
+	var results []*song.Song
+	if err := db.
+		Preload("Band").
+		Order("title ASC").
+		Limit(p.Limit).
+		Offset(p.Offset).
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (repo *SongRepo) FindPublicById(id uint) (*song.Song, error) {
+	var song song.Song
+	if err := repo.db.
+		Where("id = ? AND is_public = ?", id, true).
+		Preload("Band").
+		First(&song).Error; err != nil {
+		return nil, err
+	}
+	return &song, nil
+}
+
+func (repo *SongRepo) FindTitles(b *band.Band) ([]string, error) {
+	var titles []string
+	if err := repo.db.Model(&song.Song{}).Where("band_id = ?", b.ID).Pluck("title", &titles).Error; err != nil {
+		return nil, err
+	}
+	return titles, nil
+}
+
+// Creates every imported song with its first revision, or none of them.
+// Tags without an ID are created once per title and shared between songs.
+func (repo *SongRepo) Import(songs []*song.Song, revisions []*song.Revision) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		created := map[string]song.Tag{}
+		for i, s := range songs {
+			for j, tag := range s.Tags {
+				if tag.ID != 0 {
+					continue
+				}
+				if existing, ok := created[tag.Title]; ok {
+					s.Tags[j] = existing
+					continue
+				}
+				if err := tx.Omit("Band").Create(&tag).Error; err != nil {
+					return err
+				}
+				created[tag.Title] = tag
+				s.Tags[j] = tag
+			}
+
+			if err := tx.Omit("Band", "Tags", "MediaLinks").Create(s).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(s).Association("Tags").Replace(s.Tags); err != nil {
+				return err
+			}
+			if err := createRevision(tx, s, revisions[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Moves the concert references, annotations, tags, member preferences,
+// translations and media links of the duplicate to the kept song and soft
+// deletes the duplicate. Setlist entries are only pointed at the kept song, so
+// concerts listing both play it twice as they did before. Where both songs
+// have the same tag, a preference of the same member or a translation to the
+// same language, the kept song's one stays and the duplicate's is left behind.
+func (repo *SongRepo) Merge(keep *song.Song, duplicate *song.Song) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(
+			"UPDATE concert_songs SET song_id = ? WHERE song_id = ? AND deleted_at IS NULL",
+			keep.ID, duplicate.ID,
+		).Error; err != nil {
+			return err
+		}
+		// Annotations are kept, but their anchors belong to the removed body
+		if err := tx.Model(&song.Annotation{}).
+			Where("song_id = ?", duplicate.ID).
+			Updates(map[string]interface{}{"song_id": keep.ID, "orphaned": true}).Error; err != nil {
+			return err
+		}
+		for _, statement := range mergeStatements {
+			if err := tx.Exec(statement, map[string]interface{}{"keep": keep.ID, "duplicate": duplicate.ID}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(duplicate).Error
+	})
+}
+
+// Statements run by Merge with the @keep and @duplicate song ids
+var mergeStatements = []string{
+	`INSERT INTO song_tags (song_id, tag_id)
+	SELECT @keep, tag_id FROM song_tags WHERE song_id = @duplicate
+	ON CONFLICT DO NOTHING`,
+	`DELETE FROM song_tags WHERE song_id = @duplicate`,
+	`UPDATE preferences SET song_id = @keep
+	WHERE song_id = @duplicate
+		AND account_id NOT IN (SELECT account_id FROM preferences WHERE song_id = @keep)`,
+	`UPDATE translations SET song_id = @keep
+	WHERE song_id = @duplicate
+		AND language NOT IN (SELECT language FROM translations WHERE song_id = @keep)`,
+	// Links of the duplicate are listed after the ones of the kept song
+	`UPDATE media_links SET song_id = @keep, position = position + (
+		SELECT COUNT(*) FROM media_links WHERE song_id = @keep AND deleted_at IS NULL
+	)
+	WHERE song_id = @duplicate AND deleted_at IS NULL`,
+}
+
+func (repo *SongRepo) Remove(song *song.Song) error {
+	return repo.db.Delete(song).Error
+}
+
+// Ranked full text search restricted to bands the account owns or belongs to.
+// A zero bandId searches across every band of the account.
+func (repo *SongRepo) Search(a *account.Account, query string, bandId uint, p *commoninputs.PagingParams) ([]*song.SearchResult, error) {
+	var hits []struct {
+		ID      uint
+		Rank    float64
+		Snippet string
+	}
+	if err := repo.db.Raw(`
+		SELECT
+			songs.id,
+			ts_rank(songs.search_vector, query) AS rank,
+			ts_headline(
+				'pt_unaccent',
+				replace(replace(songs.title || E'\n' || coalesce(songs.lyrics, ''), ?, ''), ?, ''),
+				query,
+				'StartSel=' || ? || ', StopSel=' || ? || ', MaxFragments=2, MaxWords=20, MinWords=5'
+			) AS snippet
+		FROM songs, websearch_to_tsquery('pt_unaccent', ?) query
+		WHERE songs.deleted_at IS NULL
+			AND (
+				songs.search_vector @@ query OR EXISTS (
+					SELECT 1 FROM song_tags
+					JOIN tags ON tags.id = song_tags.tag_id AND tags.deleted_at IS NULL
+					WHERE song_tags.song_id = songs.id
+						AND to_tsvector('pt_unaccent', tags.title) @@ query
+				)
+			)
+			AND (? = 0 OR songs.band_id = ?)
+			AND songs.band_id IN (
+				SELECT bands.id FROM bands
+				WHERE bands.deleted_at IS NULL AND (
+					bands.owner_id = ? OR EXISTS (
+						SELECT 1 FROM members
+						WHERE members.band_id = bands.id
+							AND members.account_id = ?
+							AND members.deleted_at IS NULL
+					)
+				)
+			)
+		ORDER BY rank DESC, songs.title ASC
+		LIMIT ? OFFSET ?`,
+		startMark, stopMark, startMark, stopMark, query, bandId, bandId, a.ID, a.ID, p.Limit, p.Offset,
+	).Scan(&hits).Error; err != nil {
+		return nil, err
+	}
+
+	if len(hits) == 0 {
+		return []*song.SearchResult{}, nil
+	}
+
+	var ids []uint
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+
+	var songs []*song.Song
+	if err := repo.db.
+		Where("id IN ?", ids).
+		Preload("Band").
+		Preload("Tags").
+		Find(&songs).Error; err != nil {
+		return nil, err
+	}
+
+	songsById := map[uint]*song.Song{}
+	for _, s := range songs {
+		songsById[s.ID] = s
+	}
+
+	var results []*song.SearchResult
+	for _, hit := range hits {
+		if s, ok := songsById[hit.ID]; ok {
+			results = append(results, &song.SearchResult{Song: s, Rank: hit.Rank, Snippet: highlight(hit.Snippet)})
+		}
+	}
+	return results, nil
+}
+
+// Private use characters mark the matches of a search snippet, so they can
+// not be confused with the song text, which is escaped as HTML before the
+// marks become <mark> tags
+const (
+	startMark = "\uE000"
+	stopMark  = "\uE001"
+)
+
+func highlight(snippet string) string {
+	return strings.NewReplacer(startMark, "<mark>", stopMark, "</mark>").Replace(html.EscapeString(snippet))
+}
+
+// Saves catalog visibility only, publishing is not a content change so no
+// revision is recorded
+func (repo *SongRepo) UpdateVisibility(s *song.Song) error {
+	return repo.db.Model(s).
+		Select("is_public", "published_at").
+		Updates(map[string]interface{}{
+			"is_public":    s.IsPublic,
+			"published_at": s.PublishedAt,
+		}).Error
+}
+
+func orderedByPosition(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC")
+}
+
+// Concerts already played with the listed song in their setlist
+const performedConcerts = `FROM concert_songs
+	JOIN concerts ON concerts.id = concert_songs.concert_id AND concerts.deleted_at IS NULL
+	WHERE concert_songs.song_id = songs.id AND concert_songs.deleted_at IS NULL AND concerts.date <= CURRENT_TIMESTAMP`
+
+// Sorting clause from the whitelisted list params, songs without the sorted
+// value always come last and ties are broken by title
+func listOrder(f *songinputs.ListParams) string {
+	direction := "ASC"
+	if f.Order == "desc" {
+		direction = "DESC"
+	}
+
+	columns := map[string]string{
+		"key":            "key_index",
+		"bpm":            "bpm",
+		"duration":       "duration_seconds",
+		"performances":   "(SELECT COUNT(DISTINCT concerts.id) " + performedConcerts + ")",
+		"last_performed": "(SELECT MAX(concerts.date) " + performedConcerts + ")",
+	}
+	column, ok := columns[f.Sort]
+	if !ok {
+		return "title " + direction
+	}
+	return column + " " + direction + " NULLS LAST, title ASC"
+}
+
+func countUnique(ids []uint) int {
+	unique := map[uint]bool{}
+	for _, id := range ids {
+		unique[id] = true
+	}
+	return len(unique)
+}
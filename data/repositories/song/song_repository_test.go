@@ -0,0 +1,145 @@
+package songrepo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Records the statements sent to it instead of talking to a database,
+// failing the one whose text contains failOn
+type recorder struct {
+	statements []string
+	args       [][]interface{}
+	failOn     string
+	committed  bool
+	rolledBack bool
+}
+
+func (r *recorder) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, errors.New("prepare is not supported")
+}
+
+func (r *recorder) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	r.statements = append(r.statements, strings.Join(strings.Fields(query), " "))
+	r.args = append(r.args, args)
+	if r.failOn != "" && strings.Contains(query, r.failOn) {
+		return nil, errors.New("statement failed")
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (r *recorder) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("query is not supported")
+}
+
+func (r *recorder) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	panic("query row is not supported")
+}
+
+// Connection pool handing out transactions that record to the same recorder
+type recordingPool struct {
+	*recorder
+}
+
+func (p *recordingPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	return &recordingTx{p.recorder}, nil
+}
+
+type recordingTx struct {
+	*recorder
+}
+
+func (tx *recordingTx) Commit() error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *recordingTx) Rollback() error {
+	tx.rolledBack = true
+	return nil
+}
+
+func newRecordingRepo(t *testing.T, r *recorder) Repo {
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: &recordingPool{r}}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewSongRepo(db)
+}
+
+func TestMerge(t *testing.T) {
+	keep := &song.Song{Model: gorm.Model{ID: 1}}
+	duplicate := &song.Song{Model: gorm.Model{ID: 2}}
+
+	r := &recorder{}
+	if err := newRecordingRepo(t, r).Merge(keep, duplicate); err != nil {
+		t.Fatalf("Merge error: %v", err)
+	}
+
+	// Every table pointing at songs is moved before the duplicate is deleted
+	prefixes := []string{
+		"UPDATE concert_songs SET song_id",
+		`UPDATE "annotations" SET`,
+		"INSERT INTO song_tags (song_id, tag_id) SELECT $1, tag_id FROM song_tags WHERE song_id = $2 ON CONFLICT DO NOTHING",
+		"DELETE FROM song_tags WHERE song_id = $1",
+		"UPDATE preferences SET song_id = $1 WHERE song_id = $2 AND account_id NOT IN (SELECT account_id FROM preferences WHERE song_id = $3)",
+		"UPDATE translations SET song_id = $1 WHERE song_id = $2 AND language NOT IN (SELECT language FROM translations WHERE song_id = $3)",
+		"UPDATE media_links SET song_id = $1, position = position + ( SELECT COUNT(*) FROM media_links WHERE song_id = $2",
+		`UPDATE "songs" SET "deleted_at"`,
+	}
+	if len(r.statements) != len(prefixes) {
+		t.Fatalf("Merge ran %d statements, want %d: %q", len(r.statements), len(prefixes), r.statements)
+	}
+	for i, prefix := range prefixes {
+		if !strings.HasPrefix(r.statements[i], prefix) {
+			t.Errorf("statement %d = %q, want it to start with %q", i, r.statements[i], prefix)
+		}
+	}
+
+	wantArgs := map[int][]interface{}{
+		2: {uint(1), uint(2)},
+		3: {uint(2)},
+		4: {uint(1), uint(2), uint(1)},
+		5: {uint(1), uint(2), uint(1)},
+		6: {uint(1), uint(1), uint(2)},
+	}
+	for i, want := range wantArgs {
+		if !reflect.DeepEqual(r.args[i], want) {
+			t.Errorf("statement %d args = %v, want %v", i, r.args[i], want)
+		}
+	}
+
+	if !r.committed || r.rolledBack {
+		t.Errorf("committed = %v, rolled back = %v, want a commit", r.committed, r.rolledBack)
+	}
+}
+
+func TestMergeRollsBack(t *testing.T) {
+	keep := &song.Song{Model: gorm.Model{ID: 1}}
+	duplicate := &song.Song{Model: gorm.Model{ID: 2}}
+
+	r := &recorder{failOn: "UPDATE translations"}
+	if err := newRecordingRepo(t, r).Merge(keep, duplicate); err == nil {
+		t.Fatal("Merge error = nil, want the failed statement error")
+	}
+	for _, statement := range r.statements {
+		if strings.Contains(statement, "media_links") || strings.HasPrefix(statement, `UPDATE "songs"`) {
+			t.Errorf("ran %q after a failed statement", statement)
+		}
+	}
+	if r.committed || !r.rolledBack {
+		t.Errorf("committed = %v, rolled back = %v, want a rollback", r.committed, r.rolledBack)
+	}
+}
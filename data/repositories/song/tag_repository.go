@@ -0,0 +1,87 @@
+package songrepo
+
+import (
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"gorm.io/gorm"
+)
+
+type TagRepo interface {
+	Create(*song.Tag) error
+	FindByBand(*band.Band) ([]*song.Tag, error)
+	FindById(uint) (*song.Tag, error)
+	FindByIds(*band.Band, []uint) ([]*song.Tag, error)
+	FindByTitle(*band.Band, string) (*song.Tag, error)
+	Remove(*song.Tag) error
+	Update(*song.Tag) error
+}
+
+type tagRepo struct {
+	db *gorm.DB
+}
+
+func NewTagRepo(db *gorm.DB) TagRepo {
+	return &tagRepo{
+		db: db,
+	}
+}
+
+func (repo *tagRepo) Create(tag *song.Tag) error {
+	return repo.db.Omit("Band").Create(tag).Error
+}
+
+func (repo *tagRepo) FindByBand(b *band.Band) ([]*song.Tag, error) {
+	var results []*song.Tag
+	if err := repo.db.
+		Where("band_id = ?", b.ID).
+		Order("title ASC").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (repo *tagRepo) FindById(id uint) (*song.Tag, error) {
+	var tag song.Tag
+	if err := repo.db.
+		Where("id = ?", id).
+		Preload("Band").
+		First(&tag).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (repo *tagRepo) FindByIds(b *band.Band, ids []uint) ([]*song.Tag, error) {
+	var results []*song.Tag
+	if err := repo.db.
+		Where("band_id = ? AND id IN ?", b.ID, ids).
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (repo *tagRepo) FindByTitle(b *band.Band, title string) (*song.Tag, error) {
+	var tag song.Tag
+	if err := repo.db.
+		Where("band_id = ? AND LOWER(title) = LOWER(?)", b.ID, title).
+		First(&tag).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// Removes the tag and unlinks it from every song
+func (repo *tagRepo) Remove(tag *song.Tag) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM song_tags WHERE tag_id = ?", tag.ID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(tag).Error
+	})
+}
+
+func (repo *tagRepo) Update(tag *song.Tag) error {
+	return repo.db.Omit("Band").Save(tag).Error
+}
@@ -0,0 +1,53 @@
+package songrepo
+
+import (
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"gorm.io/gorm"
+)
+
+type TranslationRepo interface {
+	Find(*song.Song, string) (*song.Translation, error)
+	FindBySong(*song.Song) ([]*song.Translation, error)
+	Remove(*song.Translation) error
+	Save(*song.Translation) error
+}
+
+type translationRepo struct {
+	db *gorm.DB
+}
+
+func NewTranslationRepo(db *gorm.DB) TranslationRepo {
+	return &translationRepo{
+		db: db,
+	}
+}
+
+func (repo *translationRepo) Find(s *song.Song, language string) (*song.Translation, error) {
+	var translation song.Translation
+	if err := repo.db.
+		Where("song_id = ? AND language = ?", s.ID, language).
+		First(&translation).Error; err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+func (repo *translationRepo) FindBySong(s *song.Song) ([]*song.Translation, error) {
+	var results []*song.Translation
+	if err := repo.db.
+		Where("song_id = ?", s.ID).
+		Order("language ASC").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Translations are hard deleted so the language can be added again later
+func (repo *translationRepo) Remove(translation *song.Translation) error {
+	return repo.db.Unscoped().Delete(translation).Error
+}
+
+func (repo *translationRepo) Save(translation *song.Translation) error {
+	return repo.db.Omit("Song").Save(translation).Error
+}
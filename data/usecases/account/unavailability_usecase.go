@@ -0,0 +1,60 @@
+package accountusecase
+
+import (
+	"errors"
+	"time"
+
+	accountrepo "github.com/mazurco066/playliter-api-go/data/repositories/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+)
+
+type UnavailabilityUseCase interface {
+	Create(*account.Unavailability) error
+	FindByAccount(*account.Account) ([]*account.Unavailability, error)
+	FindById(uint) (*account.Unavailability, error)
+	FindConflicts([]uint, time.Time) ([]*account.Unavailability, error)
+	Remove(*account.Unavailability) error
+}
+
+type unavailabilityUseCase struct {
+	Repo accountrepo.UnavailabilityRepo
+}
+
+func NewUnavailabilityUseCase(repo accountrepo.UnavailabilityRepo) UnavailabilityUseCase {
+	return &unavailabilityUseCase{
+		Repo: repo,
+	}
+}
+
+func (uc *unavailabilityUseCase) Create(u *account.Unavailability) error {
+	if u.StartsOn.After(u.EndsOn) {
+		return errors.New("invalid period, from must not be after to")
+	}
+	return uc.Repo.Create(u)
+}
+
+// Current and upcoming periods, the ones already over are left out
+func (uc *unavailabilityUseCase) FindByAccount(a *account.Account) ([]*account.Unavailability, error) {
+	return uc.Repo.FindByAccount(a, time.Now())
+}
+
+func (uc *unavailabilityUseCase) FindById(id uint) (*account.Unavailability, error) {
+	result, err := uc.Repo.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Periods of the given accounts that include the day, a calendar date
+// without time zone
+func (uc *unavailabilityUseCase) FindConflicts(accountIDs []uint, day time.Time) ([]*account.Unavailability, error) {
+	if len(accountIDs) == 0 {
+		return []*account.Unavailability{}, nil
+	}
+	return uc.Repo.FindConflicts(accountIDs, day)
+}
+
+func (uc *unavailabilityUseCase) Remove(u *account.Unavailability) error {
+	return uc.Repo.Remove(u)
+}
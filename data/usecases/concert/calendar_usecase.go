@@ -0,0 +1,167 @@
+package concertusecase
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	concertrepo "github.com/mazurco066/playliter-api-go/data/repositories/concert"
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/concert"
+	"github.com/mazurco066/playliter-api-go/infra/hmachash"
+	"github.com/mazurco066/playliter-api-go/infra/ical"
+)
+
+const (
+	// Concerts have no end time, their events last this long
+	concertDuration = 3 * time.Hour
+	// How far back past concerts are kept on feeds
+	calendarHistory = 365 * 24 * time.Hour
+)
+
+type CalendarUseCase interface {
+	Create(*account.Account, *band.Band) (string, error)
+	Find(string) (*concert.CalendarFeed, error)
+	Remove(*account.Account, *band.Band) error
+	Render(*concert.CalendarFeed) ([]byte, error)
+}
+
+type calendarUseCase struct {
+	Repo        concertrepo.CalendarFeedRepo
+	ConcertRepo concertrepo.Repo
+	hmac        hmachash.HMAC
+}
+
+func NewCalendarUseCase(
+	repo concertrepo.CalendarFeedRepo,
+	concertRepo concertrepo.Repo,
+	hmac hmachash.HMAC,
+) CalendarUseCase {
+	return &calendarUseCase{
+		Repo:        repo,
+		ConcertRepo: concertRepo,
+		hmac:        hmac,
+	}
+}
+
+// Creates the feed of the account for the band, or its own feed when no band
+// is given, revoking the previous one. Returns the secret token, which is
+// not stored and can not be read again.
+func (uc *calendarUseCase) Create(a *account.Account, b *band.Band) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	feed := &concert.CalendarFeed{
+		TokenHash: uc.hmac.Hash(token),
+		AccountID: a.ID,
+		Band:      b,
+	}
+	if b != nil {
+		feed.BandID = &b.ID
+	}
+	if err := uc.Repo.Replace(feed); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (uc *calendarUseCase) Find(token string) (*concert.CalendarFeed, error) {
+	result, err := uc.Repo.FindByToken(uc.hmac.Hash(token))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (uc *calendarUseCase) Remove(a *account.Account, b *band.Band) error {
+	return uc.Repo.Remove(a, b)
+}
+
+// Writes the concerts of the feed as an iCalendar document. Band feeds stop
+// working once the account leaves the band.
+func (uc *calendarUseCase) Render(feed *concert.CalendarFeed) ([]byte, error) {
+	bandIDs, err := uc.Repo.FindBandIDs(&feed.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	name := "Playliter"
+	if feed.BandID != nil {
+		if !slices.Contains(bandIDs, *feed.BandID) {
+			return nil, errors.New("calendar feed not found")
+		}
+		bandIDs = []uint{*feed.BandID}
+		name = feed.Band.Title
+	}
+
+	concerts, err := uc.ConcertRepo.FindForCalendar(bandIDs, time.Now().Add(-calendarHistory))
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &ical.Calendar{Name: name, Generated: time.Now()}
+	for _, c := range concerts {
+		loc, err := time.LoadLocation(c.TimeZone)
+		if err != nil {
+			loc = time.UTC
+		}
+		event := ical.Event{
+			UID:         fmt.Sprintf("concert-%d@playliter", c.ID),
+			Sequence:    c.Sequence,
+			Status:      ical.StatusConfirmed,
+			Summary:     c.Title,
+			Description: calendarDescription(c),
+			Location:    c.Venue,
+			Start:       c.Date,
+			Duration:    concertDuration,
+			TimeZone:    loc,
+			Modified:    c.UpdatedAt,
+		}
+		// Feeds of the account mix every band it plays on
+		if feed.BandID == nil {
+			event.Summary = c.Band.Title + ": " + c.Title
+		}
+		if c.DeletedAt.Valid {
+			event.Status = ical.StatusCancelled
+			event.Modified = c.DeletedAt.Time
+		}
+		calendar.Events = append(calendar.Events, event)
+	}
+	return ical.Marshal(calendar), nil
+}
+
+// Concert description followed by its setlist, e.g. "1. Wonderwall (F#m) >"
+// where ">" marks a segue into the next song
+func calendarDescription(c *concert.Concert) string {
+	var parts []string
+	if description := strings.TrimSpace(c.Description); description != "" {
+		parts = append(parts, description)
+	}
+	if len(c.Songs) > 0 {
+		lines := []string{"Setlist:"}
+		for i, entry := range c.Songs {
+			line := fmt.Sprintf("%d. %s", i+1, entry.Song.Title)
+			key := entry.Song.Tone
+			if entry.Key != nil {
+				key = *entry.Key
+			}
+			if key != "" {
+				line += " (" + key + ")"
+			}
+			if entry.Segue {
+				line += " >"
+			}
+			lines = append(lines, line)
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,146 @@
+package concertusecase
+
+import (
+	"errors"
+	"fmt"
+
+	concertrepo "github.com/mazurco066/playliter-api-go/data/repositories/concert"
+	songrepo "github.com/mazurco066/playliter-api-go/data/repositories/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/concert"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"github.com/mazurco066/playliter-api-go/infra/chart"
+	"github.com/mazurco066/playliter-api-go/infra/chords"
+)
+
+type ConcertSongUseCase interface {
+	Chart(*concert.ConcertSong) (*song.Song, error)
+	Insert(*concert.ConcertSong, *int) error
+	Move(*concert.ConcertSong, int) error
+	Remove(*concert.ConcertSong) error
+	Reorder(*concert.Concert, []uint) error
+	SaveOverrides(*concert.ConcertSong, []*concert.SetlistSection) error
+}
+
+type concertSongUseCase struct {
+	Repo            concertrepo.ConcertSongRepo
+	ArrangementRepo songrepo.ArrangementRepo
+	SectionRepo     songrepo.SectionRepo
+}
+
+func NewConcertSongUseCase(
+	repo concertrepo.ConcertSongRepo,
+	arrangementRepo songrepo.ArrangementRepo,
+	sectionRepo songrepo.SectionRepo,
+) ConcertSongUseCase {
+	return &concertSongUseCase{
+		Repo:            repo,
+		ArrangementRepo: arrangementRepo,
+		SectionRepo:     sectionRepo,
+	}
+}
+
+// Charts the song with the arrangement played on this entry, or else with
+// the song arrangement. The chart is a copy, the song itself is left as is.
+func (uc *concertSongUseCase) Chart(entry *concert.ConcertSong) (*song.Song, error) {
+	s := entry.Song
+
+	var entries []chart.RenderEntry
+	if len(entry.Arrangement) > 0 {
+		for _, item := range entry.Arrangement {
+			// Sections deleted from the song since are skipped
+			if item.Section.ID == 0 {
+				continue
+			}
+			entries = append(entries, chart.RenderEntry{
+				Label:  item.Section.Label,
+				Body:   item.Section.Body,
+				Repeat: item.Repeat,
+			})
+		}
+	} else {
+		items, err := uc.ArrangementRepo.FindBySong(&s)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			entries = append(entries, chart.RenderEntry{
+				Label:  item.Section.Label,
+				Body:   item.Section.Body,
+				Repeat: item.Repeat,
+			})
+		}
+	}
+	if len(entries) > 0 {
+		s.Body = chart.Render(entries)
+	}
+	return &s, nil
+}
+
+// Inserts the entry at the given position, or at the end of the setlist
+// when no position is given
+func (uc *concertSongUseCase) Insert(entry *concert.ConcertSong, position *int) error {
+	index := -1
+	if position != nil {
+		index = *position
+	}
+	return uc.Repo.Insert(entry, index)
+}
+
+func (uc *concertSongUseCase) Move(entry *concert.ConcertSong, position int) error {
+	return uc.Repo.Move(entry, position)
+}
+
+func (uc *concertSongUseCase) Remove(entry *concert.ConcertSong) error {
+	return uc.Repo.Remove(entry)
+}
+
+// Checks the new order against the loaded setlist before replacing it, the
+// repository checks it again once the setlist is locked
+func (uc *concertSongUseCase) Reorder(c *concert.Concert, ids []uint) error {
+	expected := map[uint]bool{}
+	for _, entry := range c.Songs {
+		expected[entry.ID] = true
+	}
+	if len(ids) != len(expected) {
+		return errors.New("setlist order must list every entry of the setlist once")
+	}
+	for _, id := range ids {
+		if !expected[id] {
+			return errors.New("setlist order must list every entry of the setlist once")
+		}
+		delete(expected, id)
+	}
+	return uc.Repo.Reorder(c, ids)
+}
+
+// Stores the entry overrides with the key in its canonical spelling, the
+// arrangement may only use sections of the entry song
+func (uc *concertSongUseCase) SaveOverrides(entry *concert.ConcertSong, items []*concert.SetlistSection) error {
+	if entry.Key != nil {
+		key, err := chords.NormalizeKey(*entry.Key)
+		if err != nil {
+			return err
+		}
+		entry.Key = &key
+	}
+
+	sections, err := uc.SectionRepo.FindBySong(&entry.Song)
+	if err != nil {
+		return err
+	}
+	owned := map[uint]bool{}
+	for _, section := range sections {
+		owned[section.ID] = true
+	}
+	for i, item := range items {
+		if !owned[item.SectionID] {
+			return fmt.Errorf("section %d does not belong to this song", item.SectionID)
+		}
+		item.Position = i
+		if item.Repeat < 1 {
+			item.Repeat = 1
+		}
+	}
+
+	return uc.Repo.SaveOverrides(entry, items)
+}
@@ -0,0 +1,61 @@
+package concertusecase
+
+import (
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm"
+
+	concertrepo "github.com/mazurco066/playliter-api-go/data/repositories/concert"
+	"github.com/mazurco066/playliter-api-go/domain/models/concert"
+)
+
+// Records the order it was asked to store, other setlist changes are unused
+type concertSongRepoStub struct {
+	concertrepo.ConcertSongRepo
+	reordered []uint
+}
+
+func (r *concertSongRepoStub) Reorder(c *concert.Concert, ids []uint) error {
+	r.reordered = ids
+	return nil
+}
+
+func TestReorder(t *testing.T) {
+	setlist := &concert.Concert{Songs: []concert.ConcertSong{
+		{Model: gorm.Model{ID: 1}},
+		{Model: gorm.Model{ID: 2}},
+		{Model: gorm.Model{ID: 3}},
+	}}
+
+	tests := []struct {
+		name    string
+		ids     []uint
+		invalid bool
+	}{
+		{name: "every entry once", ids: []uint{3, 1, 2}},
+		{name: "missing entry", ids: []uint{3, 1}, invalid: true},
+		{name: "repeated entry", ids: []uint{3, 1, 1}, invalid: true},
+		{name: "entry of another setlist", ids: []uint{3, 1, 4}, invalid: true},
+		{name: "extra entry", ids: []uint{3, 1, 2, 4}, invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &concertSongRepoStub{}
+			err := (&concertSongUseCase{Repo: repo}).Reorder(setlist, tt.ids)
+			if tt.invalid {
+				if err == nil || repo.reordered != nil {
+					t.Errorf("Reorder(%v) = %v, stored %v, want an error and nothing stored", tt.ids, err, repo.reordered)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Reorder(%v) error: %v", tt.ids, err)
+			}
+			if !reflect.DeepEqual(repo.reordered, tt.ids) {
+				t.Errorf("Reorder stored %v, want %v", repo.reordered, tt.ids)
+			}
+		})
+	}
+}
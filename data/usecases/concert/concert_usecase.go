@@ -1,8 +1,19 @@
 package concertusecase
 
-import concertrepo "github.com/mazurco066/playliter-api-go/data/repositories/concert"
+import (
+	concertrepo "github.com/mazurco066/playliter-api-go/data/repositories/concert"
+	commoninputs "github.com/mazurco066/playliter-api-go/domain/inputs/common"
+	concertinputs "github.com/mazurco066/playliter-api-go/domain/inputs/concert"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/concert"
+)
 
 type ConcertUseCase interface {
+	Create(*concert.Concert) error
+	FindByBand(*band.Band, *concertinputs.ListParams, *commoninputs.PagingParams) ([]*concert.Concert, error)
+	FindById(uint) (*concert.Concert, error)
+	Remove(*concert.Concert) error
+	Update(*concert.Concert) error
 }
 
 type concertUseCase struct {
@@ -16,3 +27,35 @@ func NewConcertUseCase(
 		Repo: repo,
 	}
 }
+
+func (uc *concertUseCase) Create(c *concert.Concert) error {
+	if c.TimeZone == "" {
+		c.TimeZone = "UTC"
+	}
+	return uc.Repo.Create(c)
+}
+
+func (uc *concertUseCase) FindByBand(b *band.Band, f *concertinputs.ListParams, p *commoninputs.PagingParams) ([]*concert.Concert, error) {
+	if p.Limit == 0 {
+		p.Limit = 100
+	}
+	return uc.Repo.FindByBand(b, f, p)
+}
+
+func (uc *concertUseCase) FindById(id uint) (*concert.Concert, error) {
+	result, err := uc.Repo.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (uc *concertUseCase) Remove(c *concert.Concert) error {
+	return uc.Repo.Remove(c)
+}
+
+// Every update is a new revision of the concert for calendar feeds
+func (uc *concertUseCase) Update(c *concert.Concert) error {
+	c.Sequence++
+	return uc.Repo.Update(c)
+}
@@ -0,0 +1,94 @@
+package concertusecase
+
+import (
+	concertrepo "github.com/mazurco066/playliter-api-go/data/repositories/concert"
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/concert"
+)
+
+type RSVPUseCase interface {
+	Find(*concert.Concert, *account.Account) (*concert.RSVP, error)
+	Save(*concert.RSVP) error
+	Summary(*concert.Concert, []*account.Unavailability) (*concert.RSVPSummary, error)
+}
+
+type rsvpUseCase struct {
+	Repo concertrepo.RSVPRepo
+}
+
+func NewRSVPUseCase(repo concertrepo.RSVPRepo) RSVPUseCase {
+	return &rsvpUseCase{
+		Repo: repo,
+	}
+}
+
+func (uc *rsvpUseCase) Find(c *concert.Concert, a *account.Account) (*concert.RSVP, error) {
+	result, err := uc.Repo.Find(c, a)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (uc *rsvpUseCase) Save(rsvp *concert.RSVP) error {
+	return uc.Repo.Save(rsvp)
+}
+
+// Lists the owner and then every member of the concert band with their
+// answer, along with the given unavailability periods. Answers of accounts
+// that left the band are not counted.
+func (uc *rsvpUseCase) Summary(c *concert.Concert, unavailable []*account.Unavailability) (*concert.RSVPSummary, error) {
+	rsvps, err := uc.Repo.FindByConcert(c)
+	if err != nil {
+		return nil, err
+	}
+	byAccount := map[uint]*concert.RSVP{}
+	for _, rsvp := range rsvps {
+		byAccount[rsvp.AccountID] = rsvp
+	}
+	periods := map[uint]*account.Unavailability{}
+	for _, period := range unavailable {
+		if _, ok := periods[period.AccountID]; !ok {
+			periods[period.AccountID] = period
+		}
+	}
+
+	summary := &concert.RSVPSummary{Members: []*concert.RSVPStatus{}}
+	add := func(a account.Account, required bool) {
+		status := &concert.RSVPStatus{
+			Account:     a,
+			Required:    required,
+			Unavailable: periods[a.ID],
+		}
+		if rsvp, ok := byAccount[a.ID]; ok {
+			status.Response = rsvp.Response
+			status.Note = rsvp.Note
+		}
+		switch status.Response {
+		case concert.RSVPYes:
+			summary.Yes++
+		case concert.RSVPNo:
+			summary.No++
+		case concert.RSVPMaybe:
+			summary.Maybe++
+		default:
+			summary.Pending++
+		}
+		summary.Members = append(summary.Members, status)
+	}
+
+	// Owners that took over a band may still hold their member entry
+	ownerRequired := false
+	for _, member := range c.Band.Members {
+		if member.AccountID == c.Band.OwnerID {
+			ownerRequired = member.Required
+		}
+	}
+	add(c.Band.Owner, ownerRequired)
+	for _, member := range c.Band.Members {
+		if member.AccountID != c.Band.OwnerID {
+			add(member.Account, member.Required)
+		}
+	}
+	return summary, nil
+}
@@ -0,0 +1,102 @@
+package concertusecase
+
+import (
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm"
+
+	concertrepo "github.com/mazurco066/playliter-api-go/data/repositories/concert"
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/concert"
+)
+
+// Returns fixed answers for any concert
+type rsvpRepoStub struct {
+	concertrepo.RSVPRepo
+	rsvps []*concert.RSVP
+}
+
+func (r *rsvpRepoStub) FindByConcert(c *concert.Concert) ([]*concert.RSVP, error) {
+	return r.rsvps, nil
+}
+
+func TestRSVPSummary(t *testing.T) {
+	member := func(id uint, required bool) band.Member {
+		return band.Member{AccountID: id, Account: account.Account{Model: gorm.Model{ID: id}}, Required: required}
+	}
+	owner := account.Account{Model: gorm.Model{ID: 1}}
+	away := &account.Unavailability{AccountID: 3, Note: "trip"}
+
+	tests := []struct {
+		name        string
+		members     []band.Member
+		rsvps       []*concert.RSVP
+		unavailable []*account.Unavailability
+		accounts    []uint
+		responses   []string
+		required    []bool
+		counts      [4]int // Yes, no, maybe and pending
+	}{
+		{
+			name:      "owner first and everyone pending",
+			members:   []band.Member{member(2, false), member(3, true)},
+			accounts:  []uint{1, 2, 3},
+			responses: []string{"", "", ""},
+			required:  []bool{false, false, true},
+			counts:    [4]int{0, 0, 0, 3},
+		},
+		{
+			name:    "answers are counted and former members ignored",
+			members: []band.Member{member(2, false), member(3, true)},
+			rsvps: []*concert.RSVP{
+				{AccountID: 1, Response: concert.RSVPYes},
+				{AccountID: 3, Response: concert.RSVPNo, Note: "away"},
+				{AccountID: 9, Response: concert.RSVPYes},
+			},
+			unavailable: []*account.Unavailability{away, {AccountID: 3}},
+			accounts:    []uint{1, 2, 3},
+			responses:   []string{concert.RSVPYes, "", concert.RSVPNo},
+			required:    []bool{false, false, true},
+			counts:      [4]int{1, 1, 0, 1},
+		},
+		{
+			name:      "owner listed once with their member entry",
+			members:   []band.Member{member(1, true), member(2, false)},
+			rsvps:     []*concert.RSVP{{AccountID: 2, Response: concert.RSVPMaybe}},
+			accounts:  []uint{1, 2},
+			responses: []string{"", concert.RSVPMaybe},
+			required:  []bool{true, false},
+			counts:    [4]int{0, 0, 1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &concert.Concert{Band: band.Band{OwnerID: owner.ID, Owner: owner, Members: tt.members}}
+			summary, err := NewRSVPUseCase(&rsvpRepoStub{rsvps: tt.rsvps}).Summary(c, tt.unavailable)
+			if err != nil {
+				t.Fatalf("Summary error: %v", err)
+			}
+
+			var accounts []uint
+			var responses []string
+			var required []bool
+			for _, status := range summary.Members {
+				accounts = append(accounts, status.Account.ID)
+				responses = append(responses, status.Response)
+				required = append(required, status.Required)
+				if status.Account.ID == away.AccountID && tt.unavailable != nil && status.Unavailable != away {
+					t.Errorf("member %d unavailable = %+v, want the first period", status.Account.ID, status.Unavailable)
+				}
+			}
+			if !reflect.DeepEqual(accounts, tt.accounts) || !reflect.DeepEqual(responses, tt.responses) || !reflect.DeepEqual(required, tt.required) {
+				t.Errorf("Summary members = %v %q %v, want %v %q %v", accounts, responses, required, tt.accounts, tt.responses, tt.required)
+			}
+			if counts := [4]int{summary.Yes, summary.No, summary.Maybe, summary.Pending}; counts != tt.counts {
+				t.Errorf("Summary counts = %v, want %v", counts, tt.counts)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package songusecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	songrepo "github.com/mazurco066/playliter-api-go/data/repositories/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"github.com/mazurco066/playliter-api-go/infra/chords"
+	"github.com/mazurco066/playliter-api-go/infra/textdiff"
+)
+
+type AnnotationUseCase interface {
+	Create(*song.Annotation, *song.Song) error
+	FindById(uint) (*song.Annotation, error)
+	FindThreads(*song.Song, *account.Account, bool) ([]*song.Annotation, error)
+	Hide(*song.Annotation, bool) error
+	Remove(*song.Annotation) error
+	Resolve(*song.Annotation, *account.Account, bool) error
+	Update(*song.Annotation) error
+}
+
+type annotationUseCase struct {
+	Repo        songrepo.AnnotationRepo
+	SectionRepo songrepo.SectionRepo
+}
+
+func NewAnnotationUseCase(repo songrepo.AnnotationRepo, sectionRepo songrepo.SectionRepo) AnnotationUseCase {
+	return &annotationUseCase{
+		Repo:        repo,
+		SectionRepo: sectionRepo,
+	}
+}
+
+// Validates the anchor of a new thread, replies join the thread of their
+// parent and take its kind and visibility
+func (uc *annotationUseCase) Create(a *song.Annotation, s *song.Song) error {
+	a.SongID = s.ID
+	if a.Kind == "" {
+		a.Kind = song.AnnotationComment
+	}
+
+	if a.ParentID != nil {
+		parent, err := uc.Repo.FindById(*a.ParentID)
+		if err != nil || parent.SongID != s.ID {
+			return errors.New("parent annotation does not belong to the song")
+		}
+		if parent.Private && parent.AuthorID != a.AuthorID {
+			return errors.New("parent annotation does not belong to the song")
+		}
+		if parent.ParentID != nil {
+			a.ParentID = parent.ParentID
+		}
+		a.Kind = parent.Kind
+		a.Private = parent.Private
+		a.Line = nil
+		a.SectionID = nil
+		return uc.Repo.Create(a)
+	}
+
+	if a.Private && a.Kind != song.AnnotationNote {
+		return errors.New("only annotations can be private")
+	}
+	if a.Line != nil && a.SectionID != nil {
+		return errors.New("anchor to either a line or a section")
+	}
+	if a.Kind == song.AnnotationNote && a.Line == nil && a.SectionID == nil {
+		return errors.New("annotations must be anchored to a line or a section")
+	}
+
+	if a.Line != nil {
+		lines := strings.Split(strings.ReplaceAll(s.Body, "\r\n", "\n"), "\n")
+		if *a.Line < 1 || *a.Line > len(lines) {
+			return fmt.Errorf("line %d is out of the song body", *a.Line)
+		}
+		a.LineText = lines[*a.Line-1]
+	}
+	if a.SectionID != nil {
+		section, err := uc.SectionRepo.FindById(*a.SectionID)
+		if err != nil || section.SongID != s.ID {
+			return errors.New("section does not belong to the song")
+		}
+	}
+	return uc.Repo.Create(a)
+}
+
+func (uc *annotationUseCase) FindById(id uint) (*song.Annotation, error) {
+	result, err := uc.Repo.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Groups the annotations the viewer can see into threads, replies of
+// threads the viewer can not see are left out
+func (uc *annotationUseCase) FindThreads(s *song.Song, viewer *account.Account, moderator bool) ([]*song.Annotation, error) {
+	annotations, err := uc.Repo.FindBySong(s, viewer, moderator)
+	if err != nil {
+		return nil, err
+	}
+
+	threads := []*song.Annotation{}
+	roots := map[uint]*song.Annotation{}
+	for _, a := range annotations {
+		if a.ParentID == nil {
+			threads = append(threads, a)
+			roots[a.ID] = a
+		}
+	}
+	for _, a := range annotations {
+		if a.ParentID == nil {
+			continue
+		}
+		if root, ok := roots[*a.ParentID]; ok {
+			root.Replies = append(root.Replies, *a)
+		}
+	}
+	return threads, nil
+}
+
+func (uc *annotationUseCase) Hide(a *song.Annotation, hidden bool) error {
+	a.Hidden = hidden
+	return uc.Repo.Update(a)
+}
+
+func (uc *annotationUseCase) Remove(a *song.Annotation) error {
+	return uc.Repo.Remove(a)
+}
+
+// Threads are resolved as a whole, only their root keeps the resolution
+func (uc *annotationUseCase) Resolve(a *song.Annotation, by *account.Account, resolved bool) error {
+	if a.ParentID != nil {
+		return errors.New("only a thread can be resolved, not its replies")
+	}
+	a.Resolved = resolved
+	a.ResolvedAt = nil
+	a.ResolvedByID = nil
+	if resolved {
+		now := time.Now()
+		a.ResolvedAt = &now
+		a.ResolvedByID = &by.ID
+	}
+	return uc.Repo.Update(a)
+}
+
+func (uc *annotationUseCase) Update(a *song.Annotation) error {
+	return uc.Repo.Update(a)
+}
+
+// Moves line annotations to the lines they ended up at after a body change,
+// following edited lines to their new text and flagging the ones whose line
+// was deleted as orphans. Returns only the annotations that changed.
+func reanchor(annotations []*song.Annotation, oldBody string, newBody string) []*song.Annotation {
+	if len(annotations) == 0 || oldBody == newBody {
+		return nil
+	}
+
+	mapping := textdiff.MatchLines(anchorText(oldBody), anchorText(newBody))
+	lines := strings.Split(strings.ReplaceAll(newBody, "\r\n", "\n"), "\n")
+
+	var changed []*song.Annotation
+	for _, a := range annotations {
+		line, ok := mapping[*a.Line]
+		if !ok {
+			a.Orphaned = true
+			changed = append(changed, a)
+			continue
+		}
+		if line != *a.Line || lines[line-1] != a.LineText {
+			a.Line = &line
+			a.LineText = lines[line-1]
+			changed = append(changed, a)
+		}
+	}
+	return changed
+}
+
+// Lines are compared without their chords, so transposing or rewriting the
+// chords of a song leaves every annotation in place
+func anchorText(body string) string {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		if !chords.IsDirective(line) {
+			lines[i] = chords.Lyrics(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
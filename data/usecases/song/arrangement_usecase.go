@@ -0,0 +1,78 @@
+package songusecase
+
+import (
+	"fmt"
+
+	songrepo "github.com/mazurco066/playliter-api-go/data/repositories/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"github.com/mazurco066/playliter-api-go/infra/chart"
+)
+
+type ArrangementUseCase interface {
+	FindBySong(*song.Song) ([]*song.ArrangementItem, error)
+	Render(*song.Song) (string, error)
+	Replace(*song.Song, []*song.ArrangementItem) error
+}
+
+type arrangementUseCase struct {
+	Repo        songrepo.ArrangementRepo
+	SectionRepo songrepo.SectionRepo
+}
+
+func NewArrangementUseCase(
+	repo songrepo.ArrangementRepo,
+	sectionRepo songrepo.SectionRepo,
+) ArrangementUseCase {
+	return &arrangementUseCase{
+		Repo:        repo,
+		SectionRepo: sectionRepo,
+	}
+}
+
+func (uc *arrangementUseCase) FindBySong(s *song.Song) ([]*song.ArrangementItem, error) {
+	return uc.Repo.FindBySong(s)
+}
+
+// Expands the arrangement into the full chart, songs without an arrangement render their body
+func (uc *arrangementUseCase) Render(s *song.Song) (string, error) {
+	items, err := uc.Repo.FindBySong(s)
+	if err != nil {
+		return "", err
+	}
+	if len(items) == 0 {
+		return s.Body, nil
+	}
+
+	var entries []chart.RenderEntry
+	for _, item := range items {
+		entries = append(entries, chart.RenderEntry{
+			Label:  item.Section.Label,
+			Body:   item.Section.Body,
+			Repeat: item.Repeat,
+		})
+	}
+	return chart.Render(entries), nil
+}
+
+func (uc *arrangementUseCase) Replace(s *song.Song, items []*song.ArrangementItem) error {
+	sections, err := uc.SectionRepo.FindBySong(s)
+	if err != nil {
+		return err
+	}
+
+	owned := map[uint]bool{}
+	for _, section := range sections {
+		owned[section.ID] = true
+	}
+	for i, item := range items {
+		if !owned[item.SectionID] {
+			return fmt.Errorf("section %d does not belong to this song", item.SectionID)
+		}
+		item.Position = i
+		if item.Repeat < 1 {
+			item.Repeat = 1
+		}
+	}
+
+	return uc.Repo.Replace(s, items)
+}
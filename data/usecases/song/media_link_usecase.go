@@ -0,0 +1,61 @@
+package songusecase
+
+import (
+	songrepo "github.com/mazurco066/playliter-api-go/data/repositories/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"github.com/mazurco066/playliter-api-go/infra/medialinks"
+)
+
+type MediaLinkUseCase interface {
+	Create(*song.MediaLink) error
+	FindById(uint) (*song.MediaLink, error)
+	FindBySong(*song.Song) ([]*song.MediaLink, error)
+	Remove(*song.MediaLink) error
+}
+
+type mediaLinkUseCase struct {
+	Repo songrepo.MediaLinkRepo
+}
+
+func NewMediaLinkUseCase(repo songrepo.MediaLinkRepo) MediaLinkUseCase {
+	return &mediaLinkUseCase{
+		Repo: repo,
+	}
+}
+
+// Recognizes the link provider and stores its normalized form, new links
+// are appended after the existing ones
+func (uc *mediaLinkUseCase) Create(link *song.MediaLink) error {
+	parsed, err := medialinks.Parse(link.Url)
+	if err != nil {
+		return err
+	}
+	link.Url = parsed.Url
+	link.Provider = parsed.Provider
+	link.MediaID = parsed.ID
+	link.Kind = parsed.Kind
+	link.StartSeconds = parsed.Start
+
+	existing, err := uc.Repo.FindBySong(&link.Song)
+	if err != nil {
+		return err
+	}
+	link.Position = len(existing)
+	return uc.Repo.Create(link)
+}
+
+func (uc *mediaLinkUseCase) FindById(id uint) (*song.MediaLink, error) {
+	result, err := uc.Repo.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (uc *mediaLinkUseCase) FindBySong(s *song.Song) ([]*song.MediaLink, error) {
+	return uc.Repo.FindBySong(s)
+}
+
+func (uc *mediaLinkUseCase) Remove(link *song.MediaLink) error {
+	return uc.Repo.Remove(link)
+}
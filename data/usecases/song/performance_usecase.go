@@ -0,0 +1,147 @@
+package songusecase
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	songrepo "github.com/mazurco066/playliter-api-go/data/repositories/song"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+)
+
+const defaultReportLimit = 10
+
+type PerformanceUseCase interface {
+	Report(*band.Band, *songinputs.PerformanceReportParams) (*song.PerformanceReport, error)
+	Stats(*song.Song) (*song.PerformanceStats, error)
+}
+
+type performanceUseCase struct {
+	Repo songrepo.PerformanceRepo
+}
+
+func NewPerformanceUseCase(repo songrepo.PerformanceRepo) PerformanceUseCase {
+	return &performanceUseCase{
+		Repo: repo,
+	}
+}
+
+// Ranks the band songs by their performances within the period, the last
+// twelve months by default. Least played songs are the ones played before
+// that were played the fewest times within the period.
+func (uc *performanceUseCase) Report(b *band.Band, p *songinputs.PerformanceReportParams) (*song.PerformanceReport, error) {
+	now := time.Now()
+	to := now
+	if p.To != "" {
+		day, err := time.Parse(time.DateOnly, p.To)
+		if err != nil {
+			return nil, err
+		}
+		// The whole last day is included
+		to = day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	from := to.AddDate(-1, 0, 0)
+	if p.From != "" {
+		day, err := time.Parse(time.DateOnly, p.From)
+		if err != nil {
+			return nil, err
+		}
+		from = day
+	}
+	if from.After(to) {
+		return nil, errors.New("invalid period, from must not be after to")
+	}
+	limit := p.Limit
+	if limit == 0 {
+		limit = defaultReportLimit
+	}
+
+	counts, err := uc.Repo.CountByBand(b, from, to, now)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &song.PerformanceReport{
+		From:        from,
+		To:          to,
+		MostPlayed:  []*song.PerformanceCount{},
+		LeastPlayed: []*song.PerformanceCount{},
+		NeverPlayed: []*song.Song{},
+	}
+	var played []*song.PerformanceCount
+	for _, count := range counts {
+		if count.Total == 0 {
+			report.NeverPlayed = append(report.NeverPlayed, count.Song)
+			continue
+		}
+		played = append(played, count)
+	}
+	sort.Slice(report.NeverPlayed, func(i, j int) bool {
+		return report.NeverPlayed[i].Title < report.NeverPlayed[j].Title
+	})
+
+	// Ties go to the song played most recently, then to the title
+	sort.SliceStable(played, func(i, j int) bool {
+		if played[i].Performances != played[j].Performances {
+			return played[i].Performances > played[j].Performances
+		}
+		if !played[i].LastPerformed.Equal(*played[j].LastPerformed) {
+			return played[i].LastPerformed.After(*played[j].LastPerformed)
+		}
+		return played[i].Song.Title < played[j].Song.Title
+	})
+	for _, count := range played {
+		if count.Performances == 0 || len(report.MostPlayed) == limit {
+			break
+		}
+		report.MostPlayed = append(report.MostPlayed, count)
+	}
+	for i := len(played) - 1; i >= 0 && len(report.LeastPlayed) < limit; i-- {
+		report.LeastPlayed = append(report.LeastPlayed, played[i])
+	}
+	return report, nil
+}
+
+func (uc *performanceUseCase) Stats(s *song.Song) (*song.PerformanceStats, error) {
+	performances, err := uc.Repo.FindBySong(s, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &song.PerformanceStats{
+		SongID:       s.ID,
+		Performances: len(performances),
+		Venues:       []*song.VenueCount{},
+	}
+	if len(performances) == 0 {
+		return stats, nil
+	}
+
+	first := performances[0].Date
+	last := performances[len(performances)-1].Date
+	stats.FirstPerformed = &first
+	stats.LastPerformed = &last
+	if len(performances) > 1 {
+		gap := last.Sub(first).Hours() / 24 / float64(len(performances)-1)
+		gap = math.Round(gap*10) / 10
+		stats.AverageGapDays = &gap
+	}
+
+	venues := map[string]*song.VenueCount{}
+	for _, performance := range performances {
+		venue, exists := venues[performance.Venue]
+		if !exists {
+			venue = &song.VenueCount{Venue: performance.Venue}
+			venues[performance.Venue] = venue
+			stats.Venues = append(stats.Venues, venue)
+		}
+		venue.Performances++
+	}
+	sort.SliceStable(stats.Venues, func(i, j int) bool {
+		return stats.Venues[i].Performances > stats.Venues[j].Performances
+	})
+	return stats, nil
+}
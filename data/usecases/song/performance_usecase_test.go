@@ -0,0 +1,184 @@
+package songusecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+)
+
+// Returns fixed counts and performances, recording the period it was asked for
+type performanceRepoStub struct {
+	counts       []*song.PerformanceCount
+	performances []*song.Performance
+	from         time.Time
+	to           time.Time
+}
+
+func (r *performanceRepoStub) CountByBand(b *band.Band, from time.Time, to time.Time, now time.Time) ([]*song.PerformanceCount, error) {
+	r.from, r.to = from, to
+	return r.counts, nil
+}
+
+func (r *performanceRepoStub) FindBySong(s *song.Song, now time.Time) ([]*song.Performance, error) {
+	return r.performances, nil
+}
+
+func TestPerformanceReport(t *testing.T) {
+	day := func(d int) *time.Time {
+		date := time.Date(2024, 1, d, 20, 0, 0, 0, time.UTC)
+		return &date
+	}
+	count := func(title string, performances int, total int, last *time.Time) *song.PerformanceCount {
+		return &song.PerformanceCount{Song: &song.Song{Title: title}, Performances: performances, Total: total, LastPerformed: last}
+	}
+
+	tests := []struct {
+		name   string
+		counts []*song.PerformanceCount
+		limit  int
+		most   []string
+		least  []string
+		never  []string
+	}{
+		{
+			name: "ties go to the most recent, then to the title",
+			counts: []*song.PerformanceCount{
+				count("Charlie", 2, 5, day(3)),
+				count("Alpha", 2, 2, day(5)),
+				count("Bravo", 2, 3, day(5)),
+				count("Delta", 4, 4, day(1)),
+			},
+			most:  []string{"Delta", "Alpha", "Bravo", "Charlie"},
+			least: []string{"Charlie", "Bravo", "Alpha", "Delta"},
+			never: []string{},
+		},
+		{
+			name: "songs only played before the period are least played",
+			counts: []*song.PerformanceCount{
+				count("Old", 0, 3, day(1)),
+				count("New", 1, 1, day(2)),
+				count("Zulu", 0, 0, nil),
+				count("Echo", 0, 0, nil),
+			},
+			most:  []string{"New"},
+			least: []string{"Old", "New"},
+			never: []string{"Echo", "Zulu"},
+		},
+		{
+			name: "limit applies to both rankings",
+			counts: []*song.PerformanceCount{
+				count("A", 3, 3, day(1)),
+				count("B", 2, 2, day(1)),
+				count("C", 1, 1, day(1)),
+			},
+			limit: 2,
+			most:  []string{"A", "B"},
+			least: []string{"C", "B"},
+			never: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewPerformanceUseCase(&performanceRepoStub{counts: tt.counts})
+			report, err := uc.Report(&band.Band{}, &songinputs.PerformanceReportParams{Limit: tt.limit})
+			if err != nil {
+				t.Fatalf("Report error: %v", err)
+			}
+			if got := countTitles(report.MostPlayed); !reflect.DeepEqual(got, tt.most) {
+				t.Errorf("MostPlayed = %v, want %v", got, tt.most)
+			}
+			if got := countTitles(report.LeastPlayed); !reflect.DeepEqual(got, tt.least) {
+				t.Errorf("LeastPlayed = %v, want %v", got, tt.least)
+			}
+			never := []string{}
+			for _, s := range report.NeverPlayed {
+				never = append(never, s.Title)
+			}
+			if !reflect.DeepEqual(never, tt.never) {
+				t.Errorf("NeverPlayed = %v, want %v", never, tt.never)
+			}
+		})
+	}
+}
+
+func TestPerformanceReportPeriod(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  songinputs.PerformanceReportParams
+		from    time.Time
+		to      time.Time
+		invalid bool
+	}{
+		{
+			name:   "whole days",
+			params: songinputs.PerformanceReportParams{From: "2024-01-01", To: "2024-01-31"},
+			from:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond),
+		},
+		{
+			name:   "a year up to the last day by default",
+			params: songinputs.PerformanceReportParams{To: "2024-06-30"},
+			from:   time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond),
+			to:     time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond),
+		},
+		{
+			name:    "from after to",
+			params:  songinputs.PerformanceReportParams{From: "2024-02-01", To: "2024-01-01"},
+			invalid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &performanceRepoStub{}
+			_, err := NewPerformanceUseCase(repo).Report(&band.Band{}, &tt.params)
+			if tt.invalid {
+				if err == nil {
+					t.Error("Report succeeded, want an invalid period error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Report error: %v", err)
+			}
+			if !repo.from.Equal(tt.from) || !repo.to.Equal(tt.to) {
+				t.Errorf("Report period = %v - %v, want %v - %v", repo.from, repo.to, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func TestPerformanceStats(t *testing.T) {
+	performances := []*song.Performance{
+		{ConcertID: 1, Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Venue: "Hall"},
+		{ConcertID: 2, Date: time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), Venue: "Club"},
+		{ConcertID: 3, Date: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), Venue: "Club"},
+	}
+	stats, err := NewPerformanceUseCase(&performanceRepoStub{performances: performances}).Stats(&song.Song{})
+	if err != nil {
+		t.Fatalf("Stats error: %v", err)
+	}
+	if stats.Performances != 3 || stats.AverageGapDays == nil || *stats.AverageGapDays != 15 {
+		t.Errorf("Stats = %+v, want 3 performances 15 days apart", stats)
+	}
+	venues := []song.VenueCount{}
+	for _, venue := range stats.Venues {
+		venues = append(venues, *venue)
+	}
+	if want := []song.VenueCount{{Venue: "Club", Performances: 2}, {Venue: "Hall", Performances: 1}}; !reflect.DeepEqual(venues, want) {
+		t.Errorf("Stats.Venues = %+v, want %+v", venues, want)
+	}
+}
+
+func countTitles(counts []*song.PerformanceCount) []string {
+	titles := []string{}
+	for _, count := range counts {
+		titles = append(titles, count.Song.Title)
+	}
+	return titles
+}
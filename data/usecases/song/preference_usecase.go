@@ -0,0 +1,96 @@
+package songusecase
+
+import (
+	"fmt"
+
+	songrepo "github.com/mazurco066/playliter-api-go/data/repositories/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"github.com/mazurco066/playliter-api-go/infra/chords"
+)
+
+type PreferenceUseCase interface {
+	Apply(*song.Song, *song.Preference) error
+	Find(*song.Song, *account.Account) (*song.Preference, error)
+	Remove(*song.Preference) error
+	Save(*song.Preference) error
+}
+
+type preferenceUseCase struct {
+	Repo songrepo.PreferenceRepo
+}
+
+func NewPreferenceUseCase(repo songrepo.PreferenceRepo) PreferenceUseCase {
+	return &preferenceUseCase{
+		Repo: repo,
+	}
+}
+
+// Charts the song in the member key and capo. The notation is left to the
+// caller since it must be the last change of the chart.
+func (uc *preferenceUseCase) Apply(s *song.Song, p *song.Preference) error {
+	return retune(s, p.Key, p.Capo)
+}
+
+func (uc *preferenceUseCase) Find(s *song.Song, a *account.Account) (*song.Preference, error) {
+	result, err := uc.Repo.Find(s, a)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (uc *preferenceUseCase) Remove(p *song.Preference) error {
+	return uc.Repo.Remove(p)
+}
+
+// Stores the preference with its key in the canonical spelling
+func (uc *preferenceUseCase) Save(p *song.Preference) error {
+	if p.Key != nil {
+		key, err := chords.NormalizeKey(*p.Key)
+		if err != nil {
+			return err
+		}
+		p.Key = &key
+	}
+	if p.Notation == "" {
+		p.Notation = song.NotationLetters
+	}
+	return uc.Repo.Save(p)
+}
+
+// Moves the chart into the given sounding key and capo, either may be nil to
+// keep the song one. Chords are the shapes played over the capo, so the
+// chart is moved into the key and then reshaped for the capo, keeping the
+// song pitch.
+func retune(s *song.Song, key *string, capo *int) error {
+	fret := s.Capo
+	if capo != nil {
+		fret = *capo
+	}
+	semitones := s.Capo - fret
+
+	if key != nil {
+		tone, err := chords.ParseKey(s.Tone)
+		if err != nil {
+			return fmt.Errorf("song tone %q is not a valid key", s.Tone)
+		}
+		target, err := chords.ParseKey(*key)
+		if err != nil {
+			return err
+		}
+		target.Minor = tone.Minor
+		semitones += chords.SemitonesBetween(tone.Transpose(s.Capo), *target)
+	}
+
+	if semitones != 0 {
+		body, tone, err := chords.TransposeSong(s.Body, s.Tone, semitones, "")
+		if err != nil {
+			return err
+		}
+		s.Body = body
+		s.Tone = tone
+	}
+	s.Capo = fret
+	return nil
+}
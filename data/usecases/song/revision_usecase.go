@@ -0,0 +1,92 @@
+package songusecase
+
+import (
+	"strconv"
+
+	songrepo "github.com/mazurco066/playliter-api-go/data/repositories/song"
+	commoninputs "github.com/mazurco066/playliter-api-go/domain/inputs/common"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"github.com/mazurco066/playliter-api-go/infra/textdiff"
+)
+
+type RevisionUseCase interface {
+	Diff(*song.Revision, *song.Revision) *song.RevisionDiff
+	FindByNumber(*song.Song, int) (*song.Revision, error)
+	FindBySong(*song.Song, *commoninputs.PagingParams) ([]*song.Revision, error)
+}
+
+type revisionUseCase struct {
+	Repo songrepo.RevisionRepo
+}
+
+func NewRevisionUseCase(repo songrepo.RevisionRepo) RevisionUseCase {
+	return &revisionUseCase{
+		Repo: repo,
+	}
+}
+
+// Compares the metadata fields and the body lines of two revisions
+func (uc *revisionUseCase) Diff(from *song.Revision, to *song.Revision) *song.RevisionDiff {
+	diff := &song.RevisionDiff{
+		From:   from,
+		To:     to,
+		Fields: []song.FieldChange{},
+		Lines:  []song.DiffLine{},
+	}
+
+	fields := []struct {
+		name string
+		from string
+		to   string
+	}{
+		{"title", from.Title, to.Title},
+		{"writter", from.Writter, to.Writter},
+		{"tone", from.Tone, to.Tone},
+		{"original_key", valueOf(from.OriginalKey), valueOf(to.OriginalKey)},
+		{"bpm", intValueOf(from.Bpm), intValueOf(to.Bpm)},
+		{"time_signature", valueOf(from.TimeSignature), valueOf(to.TimeSignature)},
+		{"duration_seconds", intValueOf(from.DurationSeconds), intValueOf(to.DurationSeconds)},
+		{"capo", strconv.Itoa(from.Capo), strconv.Itoa(to.Capo)},
+		{"embedded_url", valueOf(from.EmbeddedUrl), valueOf(to.EmbeddedUrl)},
+	}
+	for _, field := range fields {
+		if field.from != field.to {
+			diff.Fields = append(diff.Fields, song.FieldChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+
+	for _, line := range textdiff.Lines(from.Body, to.Body) {
+		diff.Lines = append(diff.Lines, song.DiffLine{
+			Op:      line.Op,
+			OldLine: line.OldLine,
+			NewLine: line.NewLine,
+			Text:    line.Text,
+		})
+	}
+	return diff
+}
+
+func (uc *revisionUseCase) FindByNumber(s *song.Song, number int) (*song.Revision, error) {
+	return uc.Repo.FindByNumber(s, number)
+}
+
+func (uc *revisionUseCase) FindBySong(s *song.Song, p *commoninputs.PagingParams) ([]*song.Revision, error) {
+	if p.Limit == 0 {
+		p.Limit = 100
+	}
+	return uc.Repo.FindBySong(s, p)
+}
+
+func valueOf(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func intValueOf(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
@@ -0,0 +1,84 @@
+package songusecase
+
+import (
+	songrepo "github.com/mazurco066/playliter-api-go/data/repositories/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"github.com/mazurco066/playliter-api-go/infra/chart"
+)
+
+type SectionUseCase interface {
+	Create(*song.Section) error
+	FindById(uint) (*song.Section, error)
+	FindBySong(*song.Song) ([]*song.Section, error)
+	Parse(*song.Song) ([]*song.Section, error)
+	Remove(*song.Section) error
+	Update(*song.Section) error
+}
+
+type sectionUseCase struct {
+	Repo songrepo.SectionRepo
+}
+
+func NewSectionUseCase(repo songrepo.SectionRepo) SectionUseCase {
+	return &sectionUseCase{
+		Repo: repo,
+	}
+}
+
+func (uc *sectionUseCase) Create(section *song.Section) error {
+	sections, err := uc.Repo.FindBySong(&section.Song)
+	if err != nil {
+		return err
+	}
+	section.Position = len(sections)
+	return uc.Repo.Create(section)
+}
+
+func (uc *sectionUseCase) FindById(id uint) (*song.Section, error) {
+	result, err := uc.Repo.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (uc *sectionUseCase) FindBySong(s *song.Song) ([]*song.Section, error) {
+	return uc.Repo.FindBySong(s)
+}
+
+// Parses the song body into sections, replacing the current sections and arrangement
+func (uc *sectionUseCase) Parse(s *song.Song) ([]*song.Section, error) {
+	parsedSections, entries := chart.ParseSections(s.Body)
+
+	var sections []*song.Section
+	for i, parsed := range parsedSections {
+		sections = append(sections, &song.Section{
+			Kind:     parsed.Kind,
+			Label:    parsed.Label,
+			Position: i,
+			Body:     parsed.Body,
+		})
+	}
+
+	var items []*song.ArrangementItem
+	for i, entry := range entries {
+		items = append(items, &song.ArrangementItem{
+			SectionID: uint(entry.Section),
+			Position:  i,
+			Repeat:    entry.Repeat,
+		})
+	}
+
+	if err := uc.Repo.Replace(s, sections, items); err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+func (uc *sectionUseCase) Remove(section *song.Section) error {
+	return uc.Repo.Remove(section)
+}
+
+func (uc *sectionUseCase) Update(section *song.Section) error {
+	return uc.Repo.Update(section)
+}
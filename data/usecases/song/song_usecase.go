@@ -1,18 +1,1085 @@
 package songusecase
 
-import songrepo "github.com/mazurco066/playliter-api-go/data/repositories/song"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	songrepo "github.com/mazurco066/playliter-api-go/data/repositories/song"
+	commoninputs "github.com/mazurco066/playliter-api-go/domain/inputs/common"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"github.com/mazurco066/playliter-api-go/infra/bulkimport"
+	"github.com/mazurco066/playliter-api-go/infra/chart"
+	"github.com/mazurco066/playliter-api-go/infra/chorddiagrams"
+	"github.com/mazurco066/playliter-api-go/infra/chordpro"
+	"github.com/mazurco066/playliter-api-go/infra/chords"
+	"github.com/mazurco066/playliter-api-go/infra/medialinks"
+	"github.com/mazurco066/playliter-api-go/infra/openlyrics"
+	"github.com/mazurco066/playliter-api-go/infra/pdf"
+	"github.com/mazurco066/playliter-api-go/infra/render"
+	"github.com/mazurco066/playliter-api-go/infra/textnorm"
+)
+
+const (
+	defaultDuplicateScore = 0.6
+	maxDuplicatePairs     = 100
+)
 
 type SongUseCase interface {
+	ChordDiagrams([]*song.Song, string, string) (*song.ChordDiagramSet, error)
+	Create(*song.Song, *account.Account) error
+	CreateBatch([]*song.Song, *account.Account) error
+	ExportChordPro(*song.Song) string
+	ExportOpenLyrics(*song.Song, []*song.Translation) ([]byte, []string, error)
+	ExportPdf(*song.Song, *songinputs.PdfParams) []byte
+	ExportSongbook(string, []*song.Song, *songinputs.PdfParams) []byte
+	FindByBand(*band.Band, *songinputs.ListParams, *commoninputs.PagingParams) ([]*song.Song, error)
+	FindById(uint) (*song.Song, error)
+	FindDuplicates(*band.Band, float64) ([]*song.DuplicatePair, error)
+	FindPublic(string, *commoninputs.PagingParams) ([]*song.Song, error)
+	FindPublicById(uint) (*song.Song, error)
+	Fork(*song.Song, *band.Band, *account.Account) (*song.Song, error)
+	FromNashville(string, string) (string, string, error)
+	Import([]*bulkimport.Record, *band.Band, []*song.Tag, *account.Account, bool) (*song.ImportReport, error)
+	ImportChordPro(string, *band.Band) ([]*song.Song, error)
+	ImportOpenLyrics([]byte, *band.Band) (*song.OpenLyricsImport, error)
+	Merge(*song.Song, *song.Song) error
+	Normalize(*song.Song) error
+	Publish(*song.Song) error
+	Remove(*song.Song) error
+	RenderChart(*song.Song, string, string) *song.ChartDocument
+	Restore(*song.Song, *song.Revision, *account.Account) error
+	Retune(*song.Song, *string, *int) error
+	Search(*account.Account, string, uint, *commoninputs.PagingParams) ([]*song.SearchResult, error)
+	SyncUpstream(*song.Song, *account.Account) error
+	ToNashville(*song.Song) error
+	Transpose(*song.Song, int, string) error
+	Unpublish(*song.Song) error
+	Update(*song.Song, *account.Account) error
 }
 
 type songUseCase struct {
-	Repo songrepo.Repo
+	Repo           songrepo.Repo
+	AnnotationRepo songrepo.AnnotationRepo
 }
 
 func NewSongUseCase(
 	repo songrepo.Repo,
+	annotationRepo songrepo.AnnotationRepo,
 ) SongUseCase {
 	return &songUseCase{
-		Repo: repo,
+		Repo:           repo,
+		AnnotationRepo: annotationRepo,
+	}
+}
+
+// Voices every unique chord of the songs, in the order they are first played,
+// reporting the ones the voicing library has no shape for
+func (uc *songUseCase) ChordDiagrams(songs []*song.Song, instrument string, tuning string) (*song.ChordDiagramSet, error) {
+	if instrument == "" {
+		instrument = chorddiagrams.Guitar
+	}
+	if instrument == chorddiagrams.Keyboard {
+		tuning = ""
+	} else if _, err := chorddiagrams.FindInstrument(instrument, tuning); err != nil {
+		return nil, err
+	} else if tuning == "" {
+		tuning = chorddiagrams.Standard
+	}
+
+	set := &song.ChordDiagramSet{
+		Instrument: instrument,
+		Tuning:     tuning,
+		Diagrams:   []*song.ChordDiagram{},
+		Unvoiced:   []string{},
+	}
+	seen := map[string]bool{}
+	for _, s := range songs {
+		for _, symbol := range chords.Chords(s.Body) {
+			if seen[symbol] {
+				continue
+			}
+			seen[symbol] = true
+
+			diagram, err := chorddiagrams.Voice(symbol, instrument, tuning)
+			if err != nil {
+				set.Unvoiced = append(set.Unvoiced, symbol)
+				continue
+			}
+			set.Diagrams = append(set.Diagrams, &song.ChordDiagram{
+				Chord:    diagram.Chord,
+				Frets:    diagram.Frets,
+				BaseFret: diagram.BaseFret,
+				Keys:     diagram.Keys,
+				Notes:    diagram.Notes,
+				Svg:      chorddiagrams.SVG(diagram),
+			})
+		}
+	}
+	return set, nil
+}
+
+func (uc *songUseCase) Create(s *song.Song, author *account.Account) error {
+	s.Lyrics = chords.Lyrics(s.Body)
+	uc.indexKey(s)
+	return uc.Repo.Create(s, uc.newRevision(s, author))
+}
+
+// Creates every song with its first revision, or none of them
+func (uc *songUseCase) CreateBatch(songs []*song.Song, author *account.Account) error {
+	revisions := make([]*song.Revision, len(songs))
+	for i, s := range songs {
+		s.Lyrics = chords.Lyrics(s.Body)
+		uc.indexKey(s)
+		revisions[i] = uc.newRevision(s, author)
+	}
+	return uc.Repo.Import(songs, revisions)
+}
+
+func (uc *songUseCase) ExportChordPro(s *song.Song) string {
+	document := &chordpro.Song{
+		Title:  s.Title,
+		Artist: s.Writter,
+		Key:    s.Tone,
+		Body:   s.Body,
+	}
+	if s.Bpm != nil {
+		document.Tempo = strconv.Itoa(*s.Bpm)
+	}
+	if s.TimeSignature != nil {
+		document.Time = *s.TimeSignature
+	}
+	if s.DurationSeconds != nil {
+		document.Duration = chords.FormatDuration(*s.DurationSeconds)
+	}
+	if s.Capo > 0 {
+		document.Capo = strconv.Itoa(s.Capo)
+	}
+	return chordpro.Serialize(document)
+}
+
+// Writes the song as an OpenLyrics document, every section becomes a verse
+// played in the arrangement order and translations become verses of their
+// language. Returns the song fields the format has no place for.
+func (uc *songUseCase) ExportOpenLyrics(s *song.Song, translations []*song.Translation) ([]byte, []string, error) {
+	var dropped []string
+	document := &openlyrics.Song{
+		Titles:  []openlyrics.Title{{Text: s.Title}},
+		Authors: splitAuthors(s.Writter),
+	}
+	if key, err := chords.NormalizeKey(s.Tone); err == nil {
+		document.Key = key
+	} else if s.Tone != "" {
+		dropped = append(dropped, "tone")
+	}
+	if s.Bpm != nil {
+		if *s.Bpm >= openlyrics.MinTempo && *s.Bpm <= openlyrics.MaxTempo {
+			document.Tempo = *s.Bpm
+		} else {
+			dropped = append(dropped, "bpm")
+		}
+	}
+
+	// Directives other than section headings, e.g. {tempo: rubato}, have
+	// no place in a verse
+	for _, line := range strings.Split(s.Body, "\n") {
+		line = strings.TrimSpace(line)
+		if chords.IsDirective(line) && chordpro.SectionHeading(line) == "" {
+			dropped = append(dropped, "directives")
+			break
+		}
+	}
+
+	sections, entries := chart.ParseSections(s.Body)
+	names := map[string]string{} // Section label, in lower case, to verse name
+	used := map[string]bool{}
+	for _, section := range sections {
+		text := openLyricsText(section.Body)
+		// Sections without lyrics can not be written as a verse
+		if text == "" {
+			continue
+		}
+		name := verseName(section, used)
+		used[name] = true
+		names[strings.ToLower(section.Label)] = name
+		document.Verses = append(document.Verses, openlyrics.Verse{Name: name, Text: text})
+	}
+	for _, entry := range entries {
+		name, ok := names[strings.ToLower(sections[entry.Section].Label)]
+		if !ok {
+			continue
+		}
+		for i := 0; i < max(entry.Repeat, 1); i++ {
+			document.VerseOrder = append(document.VerseOrder, name)
+		}
+	}
+
+	for _, t := range translations {
+		if t.Title != "" {
+			document.Titles = append(document.Titles, openlyrics.Title{Lang: t.Language, Text: t.Title})
+		}
+		translated, _ := chart.ParseSections(t.Body)
+		for _, section := range translated {
+			text := openLyricsText(section.Body)
+			if text == "" {
+				continue
+			}
+			name, ok := names[strings.ToLower(section.Label)]
+			if !ok {
+				dropped = append(dropped, fmt.Sprintf("translation %s (section %s)", t.Language, section.Label))
+				continue
+			}
+			document.Verses = append(document.Verses, openlyrics.Verse{Name: name, Lang: t.Language, Text: text})
+		}
+	}
+
+	if s.OriginalKey != nil {
+		dropped = append(dropped, "original_key")
+	}
+	if s.TimeSignature != nil {
+		dropped = append(dropped, "time_signature")
+	}
+	if s.DurationSeconds != nil {
+		dropped = append(dropped, "duration_seconds")
+	}
+	if s.Capo > 0 {
+		dropped = append(dropped, "capo")
+	}
+	if s.EmbeddedUrl != nil {
+		dropped = append(dropped, "embedded_url")
+	}
+	if len(s.Tags) > 0 {
+		dropped = append(dropped, "tags")
+	}
+	if len(s.MediaLinks) > 0 {
+		dropped = append(dropped, "media_links")
+	}
+	content, err := openlyrics.Marshal(document)
+	if err != nil {
+		return nil, nil, err
+	}
+	return content, dropped, nil
+}
+
+func (uc *songUseCase) ExportPdf(s *song.Song, p *songinputs.PdfParams) []byte {
+	return pdf.SongSheet(uc.pdfChart(s), uc.pdfOptions(p))
+}
+
+func (uc *songUseCase) ExportSongbook(title string, songs []*song.Song, p *songinputs.PdfParams) []byte {
+	var charts []*pdf.Chart
+	for _, s := range songs {
+		charts = append(charts, uc.pdfChart(s))
+	}
+	return pdf.Songbook(title, charts, uc.pdfOptions(p))
+}
+
+func (uc *songUseCase) FindByBand(b *band.Band, f *songinputs.ListParams, p *commoninputs.PagingParams) ([]*song.Song, error) {
+	if p.Limit == 0 {
+		p.Limit = 100
+	}
+	// Keys are stored in their canonical spelling, so "Ré menor" finds "Dm"
+	if f.Key != "" {
+		key, err := chords.NormalizeKey(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		f.Key = key
+	}
+	return uc.Repo.FindByBand(b, f, p)
+}
+
+func (uc *songUseCase) FindById(id uint) (*song.Song, error) {
+	result, err := uc.Repo.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Compares every pair of band songs by title, writer and lyrics, returning
+// the pairs scoring at least minScore, best candidates first
+func (uc *songUseCase) FindDuplicates(b *band.Band, minScore float64) ([]*song.DuplicatePair, error) {
+	if minScore == 0 {
+		minScore = defaultDuplicateScore
+	}
+
+	songs, err := uc.Repo.FindAllByBand(b)
+	if err != nil {
+		return nil, err
+	}
+
+	// Texts are simplified once per song rather than once per pair
+	texts := make([]*duplicateTexts, len(songs))
+	for i, s := range songs {
+		texts[i] = newDuplicateTexts(s)
+	}
+
+	pairs := []*song.DuplicatePair{}
+	for i := 0; i < len(songs); i++ {
+		for j := i + 1; j < len(songs); j++ {
+			x, y := texts[i], texts[j]
+			pair := &song.DuplicatePair{
+				Song:      songs[i],
+				Duplicate: songs[j],
+				Title:     max(x.title.Ratio(y.title), x.title.Overlap(y.title)),
+				Lyrics:    x.lyrics.Overlap(y.lyrics),
+			}
+
+			// Writer only counts when both songs have one
+			if !x.writer.Empty() && !y.writer.Empty() {
+				pair.Writer = x.writer.Ratio(y.writer)
+				pair.Score = 0.35*pair.Title + 0.15*pair.Writer + 0.5*pair.Lyrics
+			} else {
+				pair.Score = 0.4*pair.Title + 0.6*pair.Lyrics
+			}
+
+			if pair.Score >= minScore {
+				pair.Score = roundScore(pair.Score)
+				pair.Title, pair.Writer, pair.Lyrics = roundScore(pair.Title), roundScore(pair.Writer), roundScore(pair.Lyrics)
+				pairs = append(pairs, pair)
+			}
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Score > pairs[j].Score
+	})
+	if len(pairs) > maxDuplicatePairs {
+		pairs = pairs[:maxDuplicatePairs]
+	}
+	return pairs, nil
+}
+
+// Converts a chart written in Nashville numbers into letter chords of the
+// key, returning the body and the canonical key name
+func (uc *songUseCase) FromNashville(body string, tone string) (string, string, error) {
+	key, err := chords.ParseKey(tone)
+	if err != nil {
+		return "", "", err
+	}
+	return chords.FromNashville(body, *key), key.String(), nil
+}
+
+func (uc *songUseCase) FindPublic(query string, p *commoninputs.PagingParams) ([]*song.Song, error) {
+	if p.Limit == 0 {
+		p.Limit = 100
+	}
+	return uc.Repo.FindPublic(query, p)
+}
+
+func (uc *songUseCase) FindPublicById(id uint) (*song.Song, error) {
+	result, err := uc.Repo.FindPublicById(id)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Copies a catalog song into a band, keeping a link to the original so the
+// fork can pull upstream changes later
+func (uc *songUseCase) Fork(upstream *song.Song, b *band.Band, author *account.Account) (*song.Song, error) {
+	now := time.Now()
+	fork := &song.Song{
+		BandID:           b.ID,
+		Band:             *b,
+		ForkedFromID:     &upstream.ID,
+		UpstreamSyncedAt: &now,
+	}
+	copyContent(fork, upstream)
+
+	if err := uc.Create(fork, author); err != nil {
+		return nil, err
+	}
+	return fork, nil
+}
+
+func (uc *songUseCase) ImportChordPro(content string, b *band.Band) ([]*song.Song, error) {
+	documents, err := chordpro.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var songs []*song.Song
+	for _, document := range documents {
+		songs = append(songs, uc.fromDocument(document, b))
+	}
+	return songs, nil
+}
+
+// Maps an OpenLyrics document into a song. Verses of the first language
+// found are the song body, written in the verse order under "[Label]"
+// headings, and verses of every other language become translations.
+func (uc *songUseCase) ImportOpenLyrics(content []byte, b *band.Band) (*song.OpenLyricsImport, error) {
+	parsed, err := openlyrics.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	dropped := parsed.Dropped
+
+	// Verses grouped by language, in the order the languages appear
+	var languages []string
+	verses := map[string][]openlyrics.Verse{}
+	for _, verse := range parsed.Verses {
+		if _, exists := verses[verse.Lang]; !exists {
+			languages = append(languages, verse.Lang)
+		}
+		verses[verse.Lang] = append(verses[verse.Lang], verse)
+	}
+	primary := languages[0]
+
+	body, unknown := openLyricsBody(verses[primary], parsed.VerseOrder)
+	for _, name := range unknown {
+		dropped = append(dropped, fmt.Sprintf("verseOrder (unknown verse %s)", name))
+	}
+
+	document := &chordpro.Song{
+		Artist: strings.Join(parsed.Authors, ", "),
+		Key:    parsed.Key,
+		Body:   body,
+	}
+	if parsed.Tempo > 0 {
+		document.Tempo = strconv.Itoa(parsed.Tempo)
+	}
+	if parsed.Key != "" {
+		if _, keyErr := chords.NormalizeKey(parsed.Key); keyErr != nil {
+			dropped = append(dropped, "key")
+		}
+	}
+
+	titles := map[string]string{}
+	for _, title := range parsed.Titles {
+		lang := title.Lang
+		if lang == "" || lang == primary {
+			lang = primary
+		}
+		if _, exists := titles[lang]; exists {
+			dropped = appendMissing(dropped, "title (alternative)")
+			continue
+		}
+		if _, hasVerses := verses[lang]; !hasVerses {
+			dropped = appendMissing(dropped, fmt.Sprintf("title (%s)", lang))
+			continue
+		}
+		titles[lang] = title.Text
+	}
+	document.Title = titles[primary]
+
+	s := uc.fromDocument(document, b)
+	for _, lang := range languages[1:] {
+		translated, _ := openLyricsBody(verses[lang], parsed.VerseOrder)
+		s.Translations = append(s.Translations, song.Translation{
+			Language: lang,
+			Title:    titles[lang],
+			Body:     translated,
+		})
+	}
+	// The song body has no language of its own
+	if primary != "" {
+		dropped = append(dropped, fmt.Sprintf("lang (%s)", primary))
+	}
+
+	sort.Strings(dropped)
+	return &song.OpenLyricsImport{Song: s, Dropped: dropped}, nil
+}
+
+// Validates every record of a bulk import file, skipping titles the band
+// already has. Unless it is a dry run the songs are created in a single
+// transaction, and only when no record failed.
+func (uc *songUseCase) Import(records []*bulkimport.Record, b *band.Band, tags []*song.Tag, author *account.Account, dryRun bool) (*song.ImportReport, error) {
+	existingTitles, err := uc.Repo.FindTitles(b)
+	if err != nil {
+		return nil, err
+	}
+	titles := map[string]string{}
+	for _, title := range existingTitles {
+		titles[textnorm.Simplify(title)] = ""
+	}
+
+	// Categories become band tags, the ones not found are created on commit
+	tagsByTitle := map[string]song.Tag{}
+	for _, t := range tags {
+		tagsByTitle[textnorm.Fold(t.Title)] = *t
+	}
+
+	report := &song.ImportReport{DryRun: dryRun}
+	var songs []*song.Song
+	var revisions []*song.Revision
+	for _, record := range records {
+		row := &song.ImportRow{Row: record.Row, Source: record.Source}
+		report.Rows = append(report.Rows, row)
+
+		s, reason := uc.fromRecord(record, b)
+		if s != nil {
+			row.Title = s.Title
+		} else if record.Song != nil {
+			row.Title = record.Song.Title
+		}
+		if reason != "" {
+			row.Status, row.Reason = song.ImportFailed, reason
+			report.Failed++
+			continue
+		}
+
+		titleKey := textnorm.Simplify(s.Title)
+		if source, ok := titles[titleKey]; ok {
+			row.Status, row.Reason = song.ImportSkipped, "band already has a song with this title"
+			if source != "" {
+				row.Reason = "duplicate of " + source
+			}
+			report.Skipped++
+			continue
+		}
+		titles[titleKey] = record.Source
+
+		for _, category := range record.Categories {
+			key := textnorm.Fold(category)
+			tag, ok := tagsByTitle[key]
+			if !ok {
+				tag = song.Tag{Title: category, BandID: b.ID}
+				tagsByTitle[key] = tag
+			}
+			s.Tags = append(s.Tags, tag)
+		}
+
+		s.Lyrics = chords.Lyrics(s.Body)
+		uc.indexKey(s)
+		row.Status, row.Song = song.ImportCreated, s
+		report.Created++
+		songs = append(songs, s)
+		revisions = append(revisions, uc.newRevision(s, author))
+	}
+
+	if dryRun || report.Failed > 0 || len(songs) == 0 {
+		return report, nil
+	}
+	if err := uc.Repo.Import(songs, revisions); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// Keeps the first song, moving the concert references, annotations, tags,
+// preferences, translations and media links of the duplicate to it before
+// the duplicate is removed
+func (uc *songUseCase) Merge(keep *song.Song, duplicate *song.Song) error {
+	if keep.ID == duplicate.ID {
+		return errors.New("a song can not be merged into itself")
+	}
+	if keep.BandID != duplicate.BandID {
+		return errors.New("songs must belong to the same band to be merged")
+	}
+	return uc.Repo.Merge(keep, duplicate)
+}
+
+// Validates the musical metadata and the media link of the song, rewriting
+// them into their canonical form. Fields left empty are not validated.
+func (uc *songUseCase) Normalize(s *song.Song) error {
+	if s.Tone != "" {
+		tone, err := chords.NormalizeKey(s.Tone)
+		if err != nil {
+			return err
+		}
+		s.Tone = tone
+	}
+
+	if s.OriginalKey != nil {
+		originalKey, err := chords.NormalizeKey(*s.OriginalKey)
+		if err != nil {
+			return err
+		}
+		s.OriginalKey = &originalKey
+	}
+
+	if s.TimeSignature != nil {
+		timeSignature, err := chords.NormalizeTimeSignature(*s.TimeSignature)
+		if err != nil {
+			return err
+		}
+		s.TimeSignature = &timeSignature
+	}
+
+	if s.EmbeddedUrl != nil && *s.EmbeddedUrl != "" {
+		link, err := medialinks.Parse(*s.EmbeddedUrl)
+		if err != nil {
+			return err
+		}
+		s.EmbeddedUrl = &link.Url
+	}
+	return nil
+}
+
+func (uc *songUseCase) Publish(s *song.Song) error {
+	now := time.Now()
+	s.IsPublic = true
+	s.PublishedAt = &now
+	return uc.Repo.UpdateVisibility(s)
+}
+
+func (uc *songUseCase) Remove(s *song.Song) error {
+	return uc.Repo.Remove(s)
+}
+
+// Renders the chart body in one of the reading modes. The ChordPro text is
+// the whole ChordPro file, metadata included.
+func (uc *songUseCase) RenderChart(s *song.Song, mode string, format string) *song.ChartDocument {
+	if mode == "" {
+		mode = render.ModeFull
+	}
+	if format == "" {
+		format = render.FormatText
+	}
+
+	document := render.Apply(render.Parse(s.Body), mode)
+	result := &song.ChartDocument{Mode: mode, Format: format}
+	switch {
+	case format == render.FormatHTML:
+		result.Text = render.HTML(document)
+	case format == render.FormatJSON:
+		for _, section := range document.Sections {
+			chartSection := &song.ChartSection{Label: section.Label}
+			for _, line := range section.Lines {
+				chartLine := &song.ChartLine{Kind: line.Kind}
+				for _, segment := range line.Segments {
+					chartLine.Segments = append(chartLine.Segments, &song.ChartSegment{
+						Chord:  segment.Chord,
+						Lyrics: segment.Lyrics,
+					})
+				}
+				chartSection.Lines = append(chartSection.Lines, chartLine)
+			}
+			result.Sections = append(result.Sections, chartSection)
+		}
+	case mode == render.ModeChordPro:
+		result.Text = uc.ExportChordPro(s)
+	default:
+		result.Text = render.Text(document)
+	}
+	return result
+}
+
+// Brings back the content of an old revision as the new current version
+func (uc *songUseCase) Restore(s *song.Song, r *song.Revision, author *account.Account) error {
+	s.Title = r.Title
+	s.Writter = r.Writter
+	s.Tone = r.Tone
+	s.OriginalKey = r.OriginalKey
+	s.Bpm = r.Bpm
+	s.TimeSignature = r.TimeSignature
+	s.DurationSeconds = r.DurationSeconds
+	s.Capo = r.Capo
+	s.Body = r.Body
+	s.EmbeddedUrl = r.EmbeddedUrl
+	s.Lyrics = chords.Lyrics(s.Body)
+	uc.indexKey(s)
+
+	revision := uc.newRevision(s, author)
+	revision.RestoredFrom = &r.Number
+	return uc.save(s, revision)
+}
+
+// Charts the song in another sounding key and capo, only the loaded song is
+// changed
+func (uc *songUseCase) Retune(s *song.Song, key *string, capo *int) error {
+	return retune(s, key, capo)
+}
+
+func (uc *songUseCase) Search(a *account.Account, query string, bandId uint, p *commoninputs.PagingParams) ([]*song.SearchResult, error) {
+	if p.Limit == 0 {
+		p.Limit = 100
+	}
+	return uc.Repo.Search(a, query, bandId, p)
+}
+
+// Replaces the content of a fork with the current upstream content, the
+// previous content stays available in the revision history
+func (uc *songUseCase) SyncUpstream(s *song.Song, author *account.Account) error {
+	if s.ForkedFromID == nil {
+		return errors.New("song is not a fork of a catalog song")
+	}
+
+	upstream, err := uc.Repo.FindPublicById(*s.ForkedFromID)
+	if err != nil {
+		return errors.New("upstream song is no longer available in the catalog")
+	}
+
+	now := time.Now()
+	copyContent(s, upstream)
+	s.UpstreamSyncedAt = &now
+	return uc.Update(s, author)
+}
+
+// Rewrites the song chords as scale degrees of its tone, never persisted
+func (uc *songUseCase) ToNashville(s *song.Song) error {
+	key, err := chords.ParseKey(s.Tone)
+	if err != nil {
+		return fmt.Errorf("song tone %q is not a valid key", s.Tone)
+	}
+	s.Body = chords.ToNashville(s.Body, *key)
+	return nil
+}
+
+func (uc *songUseCase) Transpose(s *song.Song, semitones int, key string) error {
+	body, tone, err := chords.TransposeSong(s.Body, s.Tone, semitones, key)
+	if err != nil {
+		return err
+	}
+	s.Body = body
+	s.Tone = tone
+	return nil
+}
+
+func (uc *songUseCase) Unpublish(s *song.Song) error {
+	s.IsPublic = false
+	s.PublishedAt = nil
+	return uc.Repo.UpdateVisibility(s)
+}
+
+func (uc *songUseCase) Update(s *song.Song, author *account.Account) error {
+	s.Lyrics = chords.Lyrics(s.Body)
+	uc.indexKey(s)
+	return uc.save(s, uc.newRevision(s, author))
+}
+
+// Persists a content change, moving the line annotations along with the body
+func (uc *songUseCase) save(s *song.Song, revision *song.Revision) error {
+	previous, err := uc.Repo.FindById(s.ID)
+	if err != nil {
+		return err
+	}
+	annotations, err := uc.AnnotationRepo.FindAnchored(s)
+	if err != nil {
+		return err
+	}
+	return uc.Repo.Update(s, revision, reanchor(annotations, previous.Body, s.Body))
+}
+
+// Snapshot of the current song content authored by the given account
+func (uc *songUseCase) newRevision(s *song.Song, author *account.Account) *song.Revision {
+	return &song.Revision{
+		AuthorID:        author.ID,
+		Title:           s.Title,
+		Writter:         s.Writter,
+		Tone:            s.Tone,
+		OriginalKey:     s.OriginalKey,
+		Bpm:             s.Bpm,
+		TimeSignature:   s.TimeSignature,
+		DurationSeconds: s.DurationSeconds,
+		Capo:            s.Capo,
+		Body:            s.Body,
+		EmbeddedUrl:     s.EmbeddedUrl,
+	}
+}
+
+// Maps a ChordPro document into a song, metadata that can not be read is
+// dropped and a missing key is taken from the first chord of the chart
+func (uc *songUseCase) fromDocument(document *chordpro.Song, b *band.Band) *song.Song {
+	s := &song.Song{
+		Title:   document.Title,
+		Writter: document.Artist,
+		Tone:    document.Key,
+		Body:    document.Body,
+		BandID:  b.ID,
+		Band:    *b,
+	}
+	if s.Writter == "" {
+		s.Writter = document.Composer
+	}
+	// Tempo is kept as a directive in the body when it is not plain bpm
+	if document.Tempo != "" {
+		if bpm, err := strconv.Atoi(document.Tempo); err == nil && bpm > 0 {
+			s.Bpm = &bpm
+		} else {
+			s.Body = "{tempo: " + document.Tempo + "}\n" + s.Body
+		}
+	}
+	if timeSignature, err := chords.NormalizeTimeSignature(document.Time); err == nil {
+		s.TimeSignature = &timeSignature
+	}
+	if duration, err := chords.ParseDuration(document.Duration); err == nil {
+		s.DurationSeconds = &duration
+	}
+	if capo, err := strconv.Atoi(document.Capo); err == nil && capo >= 0 && capo < 12 {
+		s.Capo = capo
+	}
+	if key, err := chords.NormalizeKey(s.Tone); err == nil {
+		s.Tone = key
+	} else {
+		s.Tone = ""
+	}
+	// Without a valid key directive the first chord of the chart gives the tone
+	if s.Tone == "" {
+		if symbols := chords.Chords(s.Body); len(symbols) > 0 {
+			if chord, err := chords.ParseChord(symbols[0]); err == nil {
+				s.Tone = chords.NoteName(chord.Root, false)
+				if chord.IsMinor() {
+					s.Tone += "m"
+				}
+			}
+		}
+	}
+	return s
+}
+
+// Builds the song of a bulk import record, or the reason it can not be imported
+func (uc *songUseCase) fromRecord(record *bulkimport.Record, b *band.Band) (*song.Song, string) {
+	if record.Err != nil {
+		return nil, record.Err.Error()
+	}
+	document := record.Song
+	document.Title = strings.TrimSpace(document.Title)
+	document.Body = strings.TrimSpace(document.Body)
+	if document.Key != "" {
+		if _, err := chords.NormalizeKey(document.Key); err != nil {
+			return nil, err.Error()
+		}
+	}
+
+	s := uc.fromDocument(document, b)
+	switch {
+	case len([]rune(s.Title)) < 2:
+		return s, "title must have at least 2 characters"
+	case s.Body == "":
+		return s, "body is empty"
+	case s.Tone == "":
+		return s, "key is missing and could not be read from the chords"
+	}
+	return s, ""
+}
+
+// Keeps the sortable key index in sync with the tone, songs with a tone
+// that is not a valid key are sorted last
+func (uc *songUseCase) indexKey(s *song.Song) {
+	key, err := chords.ParseKey(s.Tone)
+	if err != nil {
+		s.KeyIndex = nil
+		return
+	}
+	index := key.Index()
+	s.Tone = key.String()
+	s.KeyIndex = &index
+}
+
+// Printed chart with the musical metadata summarized under the title
+func (uc *songUseCase) pdfChart(s *song.Song) *pdf.Chart {
+	var details []string
+	if s.Writter != "" {
+		details = append(details, s.Writter)
+	}
+	if s.Tone != "" {
+		details = append(details, "Key: "+s.Tone)
+	}
+	if s.Bpm != nil {
+		details = append(details, strconv.Itoa(*s.Bpm)+" BPM")
+	}
+	if s.TimeSignature != nil {
+		details = append(details, *s.TimeSignature)
+	}
+	if s.Capo > 0 {
+		details = append(details, "Capo "+strconv.Itoa(s.Capo))
+	}
+	return &pdf.Chart{
+		Title:    s.Title,
+		Subtitle: strings.Join(details, " · "),
+		Body:     s.Body,
+	}
+}
+
+func (uc *songUseCase) pdfOptions(p *songinputs.PdfParams) pdf.Options {
+	opts := pdf.Options{FontSize: float64(p.FontSize), Size: pdf.A4}
+	if p.Paper == "letter" {
+		opts.Size = pdf.Letter
+	}
+	return opts
+}
+
+// Titles like "Grande É o Senhor (Ao Vivo)" contain the whole of
+// "Grande é o Senhor", so word overlap is considered next to edit distance
+// Song texts compared when looking for duplicates
+type duplicateTexts struct {
+	title  *textnorm.Text
+	writer *textnorm.Text
+	lyrics *textnorm.Text
+}
+
+func newDuplicateTexts(s *song.Song) *duplicateTexts {
+	// Lyrics are stored without chords, the body is only a fallback
+	lyrics := s.Lyrics
+	if lyrics == "" {
+		lyrics = chords.Lyrics(s.Body)
+	}
+	return &duplicateTexts{
+		title:  textnorm.NewText(s.Title, 1),
+		writer: textnorm.NewText(s.Writter, 1),
+		lyrics: textnorm.NewText(lyrics, 3),
+	}
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
+
+// Musical content shared between a catalog song and its forks
+func copyContent(to *song.Song, from *song.Song) {
+	to.Title = from.Title
+	to.Writter = from.Writter
+	to.Tone = from.Tone
+	to.OriginalKey = from.OriginalKey
+	to.Bpm = from.Bpm
+	to.TimeSignature = from.TimeSignature
+	to.DurationSeconds = from.DurationSeconds
+	to.Capo = from.Capo
+	to.Body = from.Body
+	to.EmbeddedUrl = from.EmbeddedUrl
+}
+
+// Verse name prefixes of OpenLyrics and the section labels they stand for
+var verseLabels = []struct {
+	prefix string
+	label  string
+}{
+	{"v", "Verse"},
+	{"c", "Chorus"},
+	{"p", "Pre-Chorus"},
+	{"b", "Bridge"},
+	{"i", "Intro"},
+	{"e", "Ending"},
+	{"o", "Other"},
+}
+
+var verseNameRegex = regexp.MustCompile(`^([a-zA-Z]+?)([0-9].*)?$`)
+
+// Writes verses as a chart in the given order, a verse played again is
+// written as a bare heading. Verses left out of the order are kept after
+// it and the names of missing verses are returned.
+func openLyricsBody(verses []openlyrics.Verse, order []string) (string, []string) {
+	byName := map[string]openlyrics.Verse{}
+	for _, verse := range verses {
+		if _, exists := byName[verse.Name]; !exists {
+			byName[verse.Name] = verse
+		}
+	}
+	order = append([]string{}, order...)
+	for _, verse := range verses {
+		order = append(order, verse.Name)
+	}
+
+	var parts, unknown []string
+	written := map[string]bool{}
+	for i, name := range order {
+		verse, ok := byName[name]
+		if !ok {
+			unknown = appendMissing(unknown, name)
+			continue
+		}
+		// Verses appended after the order are only written once
+		if written[name] && i >= len(order)-len(verses) {
+			continue
+		}
+		heading := "[" + verseLabel(name) + "]"
+		if written[name] {
+			parts = append(parts, heading)
+			continue
+		}
+		written[name] = true
+		parts = append(parts, heading+"\n"+verse.Text)
+	}
+	return strings.Join(parts, "\n\n"), unknown
+}
+
+// Section label of a verse name, e.g. "v1" is "Verse 1" and "c" is "Chorus"
+func verseLabel(name string) string {
+	match := verseNameRegex.FindStringSubmatch(name)
+	if match == nil {
+		return name
+	}
+	for _, candidate := range verseLabels {
+		if candidate.prefix == strings.ToLower(match[1]) {
+			return strings.TrimSpace(candidate.label + " " + match[2])
+		}
+	}
+	return name
+}
+
+// Verse name of a chart section, labels written by the import like
+// "Verse 1" keep their name and other sections are numbered by kind
+func verseName(section chart.Section, used map[string]bool) string {
+	label := strings.ToLower(section.Label)
+	for _, candidate := range verseLabels {
+		lower := strings.ToLower(candidate.label)
+		if label != lower && !strings.HasPrefix(label, lower+" ") {
+			continue
+		}
+		name := candidate.prefix + strings.TrimSpace(strings.TrimPrefix(label, lower))
+		if openlyrics.IsVerseName(name) && !used[name] {
+			return name
+		}
+	}
+
+	prefix := "o"
+	switch section.Kind {
+	case chart.KindVerse:
+		prefix = "v"
+	case chart.KindChorus:
+		prefix = "c"
+		if strings.Contains(label, "pre") {
+			prefix = "p"
+		}
+	case chart.KindBridge:
+		prefix = "b"
+	case chart.KindIntro:
+		prefix = "i"
+	case chart.KindOutro:
+		prefix = "e"
+	}
+	for n := 1; ; n++ {
+		if name := prefix + strconv.Itoa(n); !used[name] {
+			return name
+		}
+	}
+}
+
+// Section body as OpenLyrics verse text, with inline chords and a single
+// blank line between blocks
+func openLyricsText(body string) string {
+	var lines []string
+	for _, line := range strings.Split(chordpro.ToInline(body), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if chords.IsDirective(strings.TrimSpace(line)) {
+			continue
+		}
+		if line == "" && (len(lines) == 0 || lines[len(lines)-1] == "") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// Writter is a free text field, co-writers are usually separated by commas
+// or ampersands
+func splitAuthors(writter string) []string {
+	var authors []string
+	for _, author := range strings.FieldsFunc(writter, func(r rune) bool { return r == ',' || r == '&' || r == ';' }) {
+		if author = strings.TrimSpace(author); author != "" {
+			authors = append(authors, author)
+		}
+	}
+	return authors
+}
+
+func appendMissing(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
 	}
+	return append(values, value)
 }
@@ -0,0 +1,92 @@
+package songusecase
+
+import (
+	"errors"
+	"strings"
+
+	songrepo "github.com/mazurco066/playliter-api-go/data/repositories/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+)
+
+type TagUseCase interface {
+	Create(*song.Tag) error
+	FindByBand(*band.Band) ([]*song.Tag, error)
+	FindById(uint) (*song.Tag, error)
+	FindByIds(*band.Band, []uint) ([]song.Tag, error)
+	Remove(*song.Tag) error
+	Update(*song.Tag) error
+}
+
+type tagUseCase struct {
+	Repo songrepo.TagRepo
+}
+
+func NewTagUseCase(repo songrepo.TagRepo) TagUseCase {
+	return &tagUseCase{
+		Repo: repo,
+	}
+}
+
+func (uc *tagUseCase) Create(tag *song.Tag) error {
+	tag.Title = strings.TrimSpace(tag.Title)
+	if uc.titleTaken(tag) {
+		return errors.New("a tag with this title already exists")
+	}
+	return uc.Repo.Create(tag)
+}
+
+func (uc *tagUseCase) FindByBand(b *band.Band) ([]*song.Tag, error) {
+	return uc.Repo.FindByBand(b)
+}
+
+func (uc *tagUseCase) FindById(id uint) (*song.Tag, error) {
+	result, err := uc.Repo.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Resolves tag ids of a band, failing if any of them does not belong to it
+func (uc *tagUseCase) FindByIds(b *band.Band, ids []uint) ([]song.Tag, error) {
+	tags := []song.Tag{}
+	if len(ids) == 0 {
+		return tags, nil
+	}
+
+	results, err := uc.Repo.FindByIds(b, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	unique := map[uint]bool{}
+	for _, id := range ids {
+		unique[id] = true
+	}
+	if len(results) != len(unique) {
+		return nil, errors.New("one or more tags were not found in this band")
+	}
+
+	for _, tag := range results {
+		tags = append(tags, *tag)
+	}
+	return tags, nil
+}
+
+func (uc *tagUseCase) Remove(tag *song.Tag) error {
+	return uc.Repo.Remove(tag)
+}
+
+func (uc *tagUseCase) Update(tag *song.Tag) error {
+	tag.Title = strings.TrimSpace(tag.Title)
+	if uc.titleTaken(tag) {
+		return errors.New("a tag with this title already exists")
+	}
+	return uc.Repo.Update(tag)
+}
+
+func (uc *tagUseCase) titleTaken(tag *song.Tag) bool {
+	existing, _ := uc.Repo.FindByTitle(&tag.Band, tag.Title)
+	return existing != nil && existing.ID != tag.ID
+}
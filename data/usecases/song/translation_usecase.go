@@ -0,0 +1,51 @@
+package songusecase
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	songrepo "github.com/mazurco066/playliter-api-go/data/repositories/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+)
+
+// Language tags like "en", "pt-BR" or "zh-Hant"
+var languageRegex = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+type TranslationUseCase interface {
+	Find(*song.Song, string) (*song.Translation, error)
+	FindBySong(*song.Song) ([]*song.Translation, error)
+	Remove(*song.Translation) error
+	Save(*song.Translation) error
+}
+
+type translationUseCase struct {
+	Repo songrepo.TranslationRepo
+}
+
+func NewTranslationUseCase(repo songrepo.TranslationRepo) TranslationUseCase {
+	return &translationUseCase{
+		Repo: repo,
+	}
+}
+
+func (uc *translationUseCase) Find(s *song.Song, language string) (*song.Translation, error) {
+	return uc.Repo.Find(s, language)
+}
+
+func (uc *translationUseCase) FindBySong(s *song.Song) ([]*song.Translation, error) {
+	return uc.Repo.FindBySong(s)
+}
+
+func (uc *translationUseCase) Remove(t *song.Translation) error {
+	return uc.Repo.Remove(t)
+}
+
+func (uc *translationUseCase) Save(t *song.Translation) error {
+	if !languageRegex.MatchString(t.Language) {
+		return errors.New("invalid language, expected a tag like \"en\" or \"pt-BR\"")
+	}
+	t.Title = strings.TrimSpace(t.Title)
+	t.Body = strings.TrimSpace(t.Body)
+	return uc.Repo.Save(t)
+}
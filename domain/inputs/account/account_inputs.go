@@ -20,3 +20,10 @@ type LoginInput struct {
 	UsernameOrEmail string `json:"username_or_email" validate:"required,min=8"`
 	Password        string `json:"password" validate:"required,min=8"`
 }
+
+// Both days are included
+type UnavailabilityInput struct {
+	From string `json:"from" validate:"required,datetime=2006-01-02"`
+	To   string `json:"to" validate:"required,datetime=2006-01-02"`
+	Note string `json:"note" validate:"omitempty,max=255"`
+}
@@ -17,5 +17,6 @@ type UpdateInviteInput struct {
 }
 
 type UpdateMemberInput struct {
-	Role string `json:"role"` // "member", "admin"
+	Role     string `json:"role"` // "member", "admin", kept when empty
+	Required *bool  `json:"required"`
 }
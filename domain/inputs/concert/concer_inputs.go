@@ -1 +1,64 @@
 package concertinputs
+
+import "time"
+
+const (
+	WhenUpcoming = "upcoming"
+	WhenPast     = "past"
+)
+
+type CreateInput struct {
+	BandID      uint      `json:"band_id" validate:"required"`
+	Title       string    `json:"title" validate:"required,min=2"`
+	Description string    `json:"description"`
+	Date        time.Time `json:"date" validate:"required"`
+	Venue       string    `json:"venue" validate:"omitempty,max=255"`
+	TimeZone    string    `json:"time_zone" validate:"omitempty,timezone"` // UTC when empty
+}
+
+type UpdateInput struct {
+	Title       string     `json:"title" validate:"omitempty,min=2"`
+	Description *string    `json:"description"`
+	Date        *time.Time `json:"date"`
+	Venue       *string    `json:"venue" validate:"omitempty,max=255"`
+	TimeZone    *string    `json:"time_zone" validate:"omitempty,timezone"`
+}
+
+type ListParams struct {
+	When string     `form:"when" validate:"omitempty,oneof=upcoming past"`
+	From *time.Time `form:"from" time_format:"2006-01-02"`
+	To   *time.Time `form:"to" time_format:"2006-01-02"` // Exclusive
+}
+
+type AddSongInput struct {
+	SongID   uint `json:"song_id" validate:"required"`
+	Position *int `json:"position" validate:"omitempty,min=0"` // Appended when empty
+}
+
+type MoveSongInput struct {
+	Position *int `json:"position" validate:"required,min=0"`
+}
+
+type ReorderInput struct {
+	Entries []uint `json:"entries" validate:"required"` // Every setlist entry id, in the new order
+}
+
+// Replaces every override of a setlist entry, the ones left out fall back to the song
+type OverridesInput struct {
+	Key            *string                `json:"key" validate:"omitempty"`
+	Capo           *int                   `json:"capo" validate:"omitempty,min=0,max=11"`
+	Arrangement    []*SetlistSectionInput `json:"arrangement" validate:"omitempty,dive"`
+	LeadVocalistID *uint                  `json:"lead_vocalist_id" validate:"omitempty,min=1"`
+	Segue          bool                   `json:"segue"`
+	Transition     string                 `json:"transition" validate:"omitempty,max=500"`
+}
+
+type SetlistSectionInput struct {
+	SectionID uint `json:"section_id" validate:"required"`
+	Repeat    int  `json:"repeat" validate:"omitempty,min=1,max=16"`
+}
+
+type RSVPInput struct {
+	Response string `json:"response" validate:"required,oneof=yes no maybe"`
+	Note     string `json:"note" validate:"omitempty,max=255"`
+}
@@ -1 +1,172 @@
 package songinputs
+
+type CreateInput struct {
+	BandID          uint    `json:"band_id" validate:"required"`
+	Title           string  `json:"title" validate:"required,min=2"`
+	Writter         string  `json:"writter" validate:"omitempty"`
+	Tone            string  `json:"tone" validate:"required"`
+	OriginalKey     *string `json:"original_key" validate:"omitempty"`
+	Bpm             *int    `json:"bpm" validate:"omitempty,min=20,max=400"`
+	TimeSignature   *string `json:"time_signature" validate:"omitempty"`
+	DurationSeconds *int    `json:"duration_seconds" validate:"omitempty,min=1,max=7200"`
+	Capo            int     `json:"capo" validate:"omitempty,min=0,max=11"`
+	Body            string  `json:"body" validate:"required"`
+	Notation        string  `json:"notation" validate:"omitempty,oneof=letters nashville"` // Notation the body is written in
+	EmbeddedUrl     *string `json:"embedded_url" validate:"omitempty,url"`
+	TagIDs          []uint  `json:"tag_ids" validate:"omitempty"`
+}
+
+type UpdateInput struct {
+	Title           string  `json:"title" validate:"omitempty,min=2"`
+	Writter         string  `json:"writter" validate:"omitempty"`
+	Tone            string  `json:"tone" validate:"omitempty"`
+	OriginalKey     *string `json:"original_key" validate:"omitempty"`
+	Bpm             *int    `json:"bpm" validate:"omitempty,min=20,max=400"`
+	TimeSignature   *string `json:"time_signature" validate:"omitempty"`
+	DurationSeconds *int    `json:"duration_seconds" validate:"omitempty,min=1,max=7200"`
+	Capo            *int    `json:"capo" validate:"omitempty,min=0,max=11"`
+	Body            string  `json:"body" validate:"omitempty"`
+	Notation        string  `json:"notation" validate:"omitempty,oneof=letters nashville"` // Notation the body is written in
+	EmbeddedUrl     *string `json:"embedded_url" validate:"omitempty,url"`
+	TagIDs          []uint  `json:"tag_ids" validate:"omitempty"` // Replaces song tags when present
+}
+
+type ListParams struct {
+	Tags     []uint `form:"tags"`
+	TagMatch string `form:"tag_match" validate:"omitempty,oneof=any all"`
+	Key      string `form:"key"`
+	MinBpm   int    `form:"min_bpm" validate:"omitempty,min=1"`
+	MaxBpm   int    `form:"max_bpm" validate:"omitempty,min=1"`
+	Sort     string `form:"sort" validate:"omitempty,oneof=title key bpm duration performances last_performed"`
+	Order    string `form:"order" validate:"omitempty,oneof=asc desc"`
+}
+
+type SearchParams struct {
+	Query  string `form:"q" validate:"required,min=2"`
+	BandID uint   `form:"band_id"`
+}
+
+type CatalogParams struct {
+	Query string `form:"q" validate:"omitempty,min=2"`
+}
+
+type ForkInput struct {
+	BandID uint `json:"band_id" validate:"required"`
+}
+
+type MediaLinkInput struct {
+	Url   string `json:"url" validate:"required"`
+	Label string `json:"label" validate:"omitempty,max=60"`
+}
+
+type DiagramParams struct {
+	Instrument string `form:"instrument" validate:"omitempty,oneof=guitar ukulele cavaquinho keyboard"`
+	Tuning     string `form:"tuning" validate:"omitempty,oneof=standard drop_d"`
+}
+
+type DuplicateParams struct {
+	MinScore float64 `form:"min_score" validate:"omitempty,min=0,max=1"`
+}
+
+type MergeInput struct {
+	DuplicateID uint `json:"duplicate_id" validate:"required"`
+}
+
+type NashvilleInput struct {
+	Body string `json:"body" validate:"required"`
+	Key  string `json:"key" validate:"required"`
+}
+
+type ImportParams struct {
+	DryRun bool `form:"dry_run"`
+}
+
+type PdfParams struct {
+	FontSize int    `form:"font_size" validate:"omitempty,min=8,max=18"`
+	Paper    string `form:"paper" validate:"omitempty,oneof=a4 letter"`
+}
+
+type PdfSelectionInput struct {
+	SongIDs []uint `json:"song_ids" validate:"required,min=1,max=500"`
+}
+
+type TagInput struct {
+	Title string  `json:"title" validate:"required,min=2"`
+	Color *string `json:"color" validate:"omitempty,hexcolor"`
+}
+
+type UpdateTagInput struct {
+	Title string  `json:"title" validate:"omitempty,min=2"`
+	Color *string `json:"color" validate:"omitempty,hexcolor"`
+}
+
+type TransposeInput struct {
+	Semitones int    `json:"semitones" validate:"omitempty,min=-11,max=11"`
+	Key       string `json:"key" validate:"omitempty"`
+}
+
+type SectionInput struct {
+	Kind  string `json:"kind" validate:"required,oneof=verse chorus bridge intro outro tag"`
+	Label string `json:"label" validate:"required"`
+	Body  string `json:"body" validate:"required"`
+}
+
+type UpdateSectionInput struct {
+	Kind  string `json:"kind" validate:"omitempty,oneof=verse chorus bridge intro outro tag"`
+	Label string `json:"label" validate:"omitempty"`
+	Body  string `json:"body" validate:"omitempty"`
+}
+
+type ArrangementItemInput struct {
+	SectionID uint `json:"section_id" validate:"required"`
+	Repeat    int  `json:"repeat" validate:"omitempty,min=1,max=16"`
+}
+
+type ArrangementInput struct {
+	Items []ArrangementItemInput `json:"items" validate:"required,dive"`
+}
+
+type RevisionDiffParams struct {
+	From int `form:"from" validate:"required,min=1"`
+	To   int `form:"to" validate:"required,min=1"`
+}
+
+// Replaces the whole preference, fields left out fall back to the song ones
+type PreferenceInput struct {
+	Key      *string `json:"key" validate:"omitempty"`
+	Capo     *int    `json:"capo" validate:"omitempty,min=0,max=11"`
+	Notation string  `json:"notation" validate:"omitempty,oneof=letters nashville"`
+}
+
+type SongParams struct {
+	Original bool `form:"original"` // Skips the member preference
+}
+
+type AnnotationInput struct {
+	Body      string `json:"body" validate:"required,max=2000"`
+	Kind      string `json:"kind" validate:"omitempty,oneof=comment annotation"`
+	Private   bool   `json:"private"`
+	Line      *int   `json:"line" validate:"omitempty,min=1"`
+	SectionID *uint  `json:"section_id" validate:"omitempty"`
+	ParentID  *uint  `json:"parent_id" validate:"omitempty"` // Replies to an existing thread
+}
+
+type UpdateAnnotationInput struct {
+	Body string `json:"body" validate:"required,max=2000"`
+}
+
+type RenderParams struct {
+	Mode   string `form:"mode" validate:"omitempty,oneof=full lyrics chords chordpro"`
+	Format string `form:"format" validate:"omitempty,oneof=text html json"`
+}
+
+type TranslationInput struct {
+	Title string `json:"title" validate:"omitempty,max=255"`
+	Body  string `json:"body" validate:"required"`
+}
+
+type PerformanceReportParams struct {
+	From  string `form:"from" validate:"omitempty,datetime=2006-01-02"`
+	To    string `form:"to" validate:"omitempty,datetime=2006-01-02"`
+	Limit int    `form:"limit" validate:"omitempty,min=1,max=100"`
+}
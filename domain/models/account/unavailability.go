@@ -0,0 +1,18 @@
+package account
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Unavailability is a period the account can't play with any of its bands,
+// both days included
+type Unavailability struct {
+	gorm.Model
+	AccountID uint      `gorm:"index" json:"account_id"`
+	Account   Account   `gorm:"foreignKey:AccountID" json:"account"`
+	StartsOn  time.Time `gorm:"type:date" json:"starts_on"`
+	EndsOn    time.Time `gorm:"type:date" json:"ends_on"`
+	Note      string    `json:"note"`
+}
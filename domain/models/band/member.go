@@ -15,5 +15,6 @@ type Member struct {
 	AccountID uint            `json:"account_id"`
 	Account   account.Account `gorm:"foreignKey:AccountID" json:"account"`
 	Role      string          `goem:"default:'member'" json:"role"` // "member", "admin"
+	Required  bool            `json:"required"`                     // Concerts can't be played without this member
 	JoinedAt  time.Time       `json:"joined_at"`
 }
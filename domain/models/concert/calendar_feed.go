@@ -0,0 +1,20 @@
+package concert
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+)
+
+// CalendarFeed is a subscribable iCalendar feed of an account, only the hash
+// of its secret token is stored. Feeds without a band cover every band of
+// the account.
+type CalendarFeed struct {
+	gorm.Model
+	TokenHash string          `gorm:"uniqueIndex" json:"token_hash"`
+	AccountID uint            `gorm:"index" json:"account_id"`
+	Account   account.Account `gorm:"foreignKey:AccountID" json:"account"`
+	BandID    *uint           `json:"band_id"`
+	Band      *band.Band      `gorm:"foreignKey:BandID" json:"band"`
+}
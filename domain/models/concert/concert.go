@@ -6,15 +6,17 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/mazurco066/playliter-api-go/domain/models/band"
-	"github.com/mazurco066/playliter-api-go/domain/models/song"
 )
 
 type Concert struct {
 	gorm.Model
-	Title       string      `json:"title"`
-	Description string      `json:"description"`
-	Date        time.Time   `json:"date"`
-	BandID      uint        `json:"band_id"`
-	Band        band.Band   `gorm:"foreignKey:BandID" json:"band"`
-	Songs       []song.Song `gorm:"many2many:concert_songs;" json:"songs"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Date        time.Time     `json:"date"`
+	Venue       string        `json:"venue"`
+	TimeZone    string        `gorm:"default:'UTC'" json:"time_zone"` // IANA zone it is played in, e.g. "America/Sao_Paulo"
+	Sequence    int           `json:"sequence"`                       // Revision published on calendar feeds
+	BandID      uint          `json:"band_id"`
+	Band        band.Band     `gorm:"foreignKey:BandID" json:"band"`
+	Songs       []ConcertSong `gorm:"foreignKey:ConcertID" json:"songs"`
 }
@@ -3,13 +3,26 @@ package concert
 import (
 	"gorm.io/gorm"
 
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
 	"github.com/mazurco066/playliter-api-go/domain/models/song"
 )
 
+// ConcertSong is an entry of a concert setlist, the same song may be played
+// more than once, e.g. as an encore reprise
 type ConcertSong struct {
 	gorm.Model
-	ConcertID uint      `json:"concert_id"`
+	ConcertID uint      `gorm:"index" json:"concert_id"`
 	Concert   Concert   `gorm:"foreignKey:ConcertID" json:"concert"`
-	SongID    uint      `json:"song_id"`
+	SongID    uint      `gorm:"index" json:"song_id"`
 	Song      song.Song `gorm:"foreignKey:SongID" json:"song"`
+	Position  int       `json:"position"` // Zero based order within the setlist
+	// Overrides for this performance only, the ones left empty fall back to
+	// the song and the song itself is never changed by them
+	Key            *string          `json:"key"` // Sounding key, e.g. "A"
+	Capo           *int             `json:"capo"`
+	Arrangement    []SetlistSection `gorm:"foreignKey:ConcertSongID" json:"arrangement"`
+	LeadVocalistID *uint            `json:"lead_vocalist_id"`
+	LeadVocalist   *account.Account `gorm:"foreignKey:LeadVocalistID" json:"lead_vocalist"`
+	Segue          bool             `json:"segue"`      // Runs into the next entry without a stop
+	Transition     string           `json:"transition"` // Notes on getting into the next entry
 }
@@ -0,0 +1,42 @@
+package concert
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+)
+
+const (
+	RSVPYes   = "yes"
+	RSVPNo    = "no"
+	RSVPMaybe = "maybe"
+)
+
+// RSVP is the answer of a band member on whether they can play a concert
+type RSVP struct {
+	gorm.Model
+	ConcertID uint            `gorm:"uniqueIndex:idx_rsvp_concert_account" json:"concert_id"`
+	Concert   Concert         `gorm:"foreignKey:ConcertID" json:"concert"`
+	AccountID uint            `gorm:"uniqueIndex:idx_rsvp_concert_account" json:"account_id"`
+	Account   account.Account `gorm:"foreignKey:AccountID" json:"account"`
+	Response  string          `json:"response"` // "yes", "no", "maybe"
+	Note      string          `json:"note"`
+}
+
+// RSVPSummary is the lineup of a concert as answered by the band, with the
+// owner and every member whether they answered or not
+type RSVPSummary struct {
+	Yes     int
+	No      int
+	Maybe   int
+	Pending int
+	Members []*RSVPStatus
+}
+
+type RSVPStatus struct {
+	Account     account.Account
+	Required    bool
+	Response    string // Empty while pending
+	Note        string
+	Unavailable *account.Unavailability // Period the concert day falls in, if any
+}
@@ -0,0 +1,18 @@
+package concert
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+)
+
+// SetlistSection is an item of the arrangement played on a setlist entry,
+// e.g. a shortened version without the second verse
+type SetlistSection struct {
+	gorm.Model
+	ConcertSongID uint         `gorm:"index" json:"concert_song_id"`
+	SectionID     uint         `json:"section_id"`
+	Section       song.Section `gorm:"foreignKey:SectionID" json:"section"`
+	Position      int          `json:"position"`
+	Repeat        int          `gorm:"default:1" json:"repeat"`
+}
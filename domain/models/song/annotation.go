@@ -0,0 +1,38 @@
+package song
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+)
+
+const (
+	AnnotationComment = "comment"    // Shared remark open for discussion
+	AnnotationNote    = "annotation" // Rehearsal note, may be kept private
+)
+
+// Annotation is a member note attached to a line of the song body or to
+// one of its sections. Replies belong to the thread of their parent and
+// share its anchor, only the thread root is anchored.
+type Annotation struct {
+	gorm.Model
+	SongID       uint            `gorm:"index" json:"song_id"`
+	Song         Song            `gorm:"foreignKey:SongID" json:"song"`
+	AuthorID     uint            `json:"author_id"`
+	Author       account.Account `gorm:"foreignKey:AuthorID" json:"author"`
+	ParentID     *uint           `gorm:"index" json:"parent_id"`
+	Replies      []Annotation    `gorm:"foreignKey:ParentID" json:"replies"`
+	Kind         string          `json:"kind"`
+	Private      bool            `json:"private"`   // Only visible to the author
+	Line         *int            `json:"line"`      // Line of the song body, starting at 1
+	LineText     string          `json:"line_text"` // Last known content of the anchored line
+	SectionID    *uint           `gorm:"index" json:"section_id"`
+	Orphaned     bool            `json:"orphaned"` // The anchored line or section was deleted
+	Body         string          `json:"body"`
+	Resolved     bool            `json:"resolved"`
+	ResolvedAt   *time.Time      `json:"resolved_at"`
+	ResolvedByID *uint           `json:"resolved_by_id"`
+	Hidden       bool            `json:"hidden"` // Hidden from other members by a band admin
+}
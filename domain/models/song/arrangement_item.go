@@ -0,0 +1,15 @@
+package song
+
+import (
+	"gorm.io/gorm"
+)
+
+type ArrangementItem struct {
+	gorm.Model
+	SongID    uint    `json:"song_id"`
+	Song      Song    `gorm:"foreignKey:SongID" json:"song"`
+	SectionID uint    `json:"section_id"`
+	Section   Section `gorm:"foreignKey:SectionID" json:"section"`
+	Position  int     `json:"position"`
+	Repeat    int     `gorm:"default:1" json:"repeat"`
+}
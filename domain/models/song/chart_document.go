@@ -0,0 +1,26 @@
+package song
+
+// ChartDocument is a song chart rendered for a reader, Text holds the text
+// and HTML formats while Sections hold the tokens of the JSON format
+type ChartDocument struct {
+	Mode     string
+	Format   string
+	Text     string
+	Sections []*ChartSection
+}
+
+type ChartSection struct {
+	Label string
+	Lines []*ChartLine
+}
+
+type ChartLine struct {
+	Kind     string // "lyrics", "chords" or "empty"
+	Segments []*ChartSegment
+}
+
+// ChartSegment is a chord and the lyrics sung from it until the next chord
+type ChartSegment struct {
+	Chord  string
+	Lyrics string
+}
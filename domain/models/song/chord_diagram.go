@@ -0,0 +1,19 @@
+package song
+
+// ChordDiagramSet holds the diagrams of every unique chord of one or more
+// songs for a single instrument, it is not persisted
+type ChordDiagramSet struct {
+	Instrument string          `json:"instrument"`
+	Tuning     string          `json:"tuning"`
+	Diagrams   []*ChordDiagram `json:"diagrams"`
+	Unvoiced   []string        `json:"unvoiced"` // Chords the voicing library has no shape for
+}
+
+type ChordDiagram struct {
+	Chord    string   `json:"chord"`
+	Frets    []int    `json:"frets"`     // Fretted instruments, from the lowest string, -1 when muted
+	BaseFret int      `json:"base_fret"` // Fretted instruments
+	Keys     []int    `json:"keys"`      // Keyboard, MIDI note numbers
+	Notes    []string `json:"notes"`
+	Svg      string   `json:"svg"`
+}
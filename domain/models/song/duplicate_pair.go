@@ -0,0 +1,12 @@
+package song
+
+// DuplicatePair is a pair of band songs that look like copies of each other,
+// the older song comes first. It is not persisted.
+type DuplicatePair struct {
+	Song      *Song   `json:"song"`
+	Duplicate *Song   `json:"duplicate"`
+	Score     float64 `json:"score"`
+	Title     float64 `json:"title"`
+	Writer    float64 `json:"writer"`
+	Lyrics    float64 `json:"lyrics"`
+}
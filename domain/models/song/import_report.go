@@ -0,0 +1,26 @@
+package song
+
+const (
+	ImportCreated = "created"
+	ImportSkipped = "skipped"
+	ImportFailed  = "failed"
+)
+
+// ImportReport is the outcome of a bulk import, row by row. On a dry run
+// created rows are the ones that would be created.
+type ImportReport struct {
+	DryRun  bool
+	Created int
+	Skipped int
+	Failed  int
+	Rows    []*ImportRow
+}
+
+type ImportRow struct {
+	Row    int
+	Source string
+	Title  string
+	Status string
+	Reason string
+	Song   *Song // Song created from the row, nil when skipped or failed
+}
@@ -0,0 +1,18 @@
+package song
+
+import (
+	"gorm.io/gorm"
+)
+
+type MediaLink struct {
+	gorm.Model
+	SongID       uint   `json:"song_id"`
+	Song         Song   `gorm:"foreignKey:SongID" json:"song"`
+	Label        string `json:"label"` // e.g. "Original recording" or "Live reference"
+	Position     int    `json:"position"`
+	Url          string `json:"url"`
+	Provider     string `json:"provider"`
+	MediaID      string `json:"media_id"`
+	Kind         string `json:"kind"`
+	StartSeconds int    `json:"start_seconds"`
+}
@@ -0,0 +1,8 @@
+package song
+
+// OpenLyricsImport is a song read from an OpenLyrics document along with
+// every element of the document that could not be mapped
+type OpenLyricsImport struct {
+	Song    *Song
+	Dropped []string
+}
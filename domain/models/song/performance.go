@@ -0,0 +1,44 @@
+package song
+
+import "time"
+
+// Performance is a concert, already played, that had the song in its setlist
+type Performance struct {
+	ConcertID uint
+	Date      time.Time
+	Venue     string
+}
+
+// PerformanceStats summarizes how often a song has been played
+type PerformanceStats struct {
+	SongID         uint
+	Performances   int
+	FirstPerformed *time.Time
+	LastPerformed  *time.Time
+	AverageGapDays *float64 // Nil until the song is played twice
+	Venues         []*VenueCount
+}
+
+type VenueCount struct {
+	Venue        string // Empty for concerts without a venue
+	Performances int
+}
+
+// PerformanceCount is the number of times a song was played within a
+// period, next to its whole history
+type PerformanceCount struct {
+	Song          *Song
+	Performances  int // Within the period
+	Total         int
+	LastPerformed *time.Time
+}
+
+// PerformanceReport ranks the songs of a band by how often they were
+// played within a period
+type PerformanceReport struct {
+	From        time.Time
+	To          time.Time
+	MostPlayed  []*PerformanceCount
+	LeastPlayed []*PerformanceCount
+	NeverPlayed []*Song
+}
@@ -0,0 +1,25 @@
+package song
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+)
+
+const (
+	NotationLetters   = "letters"
+	NotationNashville = "nashville"
+)
+
+// Preference is how a band member likes a song to be charted for them,
+// applied whenever they fetch it unless they ask for the original
+type Preference struct {
+	gorm.Model
+	SongID    uint            `gorm:"uniqueIndex:idx_preference_song_account" json:"song_id"`
+	Song      Song            `gorm:"foreignKey:SongID" json:"song"`
+	AccountID uint            `gorm:"uniqueIndex:idx_preference_song_account" json:"account_id"`
+	Account   account.Account `gorm:"foreignKey:AccountID" json:"account"`
+	Key       *string         `json:"key"`  // Sounding key the member sings the song in
+	Capo      *int            `json:"capo"` // Capo the member plays with, chords are reshaped for it
+	Notation  string          `json:"notation"`
+}
@@ -0,0 +1,27 @@
+package song
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+)
+
+type Revision struct {
+	gorm.Model
+	SongID          uint            `gorm:"uniqueIndex:idx_revision_song_number" json:"song_id"`
+	Song            Song            `gorm:"foreignKey:SongID" json:"song"`
+	Number          int             `gorm:"uniqueIndex:idx_revision_song_number" json:"number"`
+	AuthorID        uint            `json:"author_id"`
+	Author          account.Account `gorm:"foreignKey:AuthorID" json:"author"`
+	RestoredFrom    *int            `json:"restored_from"` // Number of the restored revision
+	Title           string          `json:"title"`
+	Writter         string          `json:"writter"`
+	Tone            string          `json:"tone"`
+	OriginalKey     *string         `json:"original_key"`
+	Bpm             *int            `json:"bpm"`
+	TimeSignature   *string         `json:"time_signature"`
+	DurationSeconds *int            `json:"duration_seconds"`
+	Capo            int             `json:"capo"`
+	Body            string          `json:"body"`
+	EmbeddedUrl     *string         `json:"embedded_url"`
+}
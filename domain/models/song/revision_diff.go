@@ -0,0 +1,22 @@
+package song
+
+// RevisionDiff is the comparison between two revisions, it is not persisted
+type RevisionDiff struct {
+	From   *Revision     `json:"from"`
+	To     *Revision     `json:"to"`
+	Fields []FieldChange `json:"fields"`
+	Lines  []DiffLine    `json:"lines"`
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+type DiffLine struct {
+	Op      string `json:"op"` // "equal", "insert", "delete"
+	OldLine int    `json:"old_line"`
+	NewLine int    `json:"new_line"`
+	Text    string `json:"text"`
+}
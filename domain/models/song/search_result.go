@@ -0,0 +1,8 @@
+package song
+
+// SearchResult is a ranked full text search hit, it is not persisted
+type SearchResult struct {
+	Song    *Song   `json:"song"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
@@ -0,0 +1,15 @@
+package song
+
+import (
+	"gorm.io/gorm"
+)
+
+type Section struct {
+	gorm.Model
+	SongID   uint   `json:"song_id"`
+	Song     Song   `gorm:"foreignKey:SongID" json:"song"`
+	Kind     string `json:"kind"` // "verse", "chorus", "bridge", "intro", "outro", "tag"
+	Label    string `json:"label"`
+	Position int    `json:"position"`
+	Body     string `json:"body"`
+}
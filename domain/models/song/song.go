@@ -1,6 +1,8 @@
 package song
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 
 	"github.com/mazurco066/playliter-api-go/domain/models/band"
@@ -8,12 +10,26 @@ import (
 
 type Song struct {
 	gorm.Model
-	Title       string    `json:"title"`
-	Writter     string    `json:"writter"`
-	Tone        string    `json:"tone"`
-	Body        string    `json:"body"`
-	EmbeddedUrl *string   `json:"embedded_url"`
-	Category    *string   `json:"category"`
-	BandID      uint      `json:"band_id"`
-	Band        band.Band `gorm:"foreignKey:BandID" json:"band"`
+	Title            string        `json:"title"`
+	Writter          string        `json:"writter"`
+	Tone             string        `json:"tone"`
+	KeyIndex         *int          `gorm:"index" json:"-"` // Chromatic order of the tone, used for sorting
+	OriginalKey      *string       `json:"original_key"`
+	Bpm              *int          `json:"bpm"`
+	TimeSignature    *string       `json:"time_signature"`
+	DurationSeconds  *int          `json:"duration_seconds"`
+	Capo             int           `json:"capo"`
+	Body             string        `json:"body"`
+	Lyrics           string        `json:"-"` // Body without chords, kept for full text search
+	EmbeddedUrl      *string       `json:"embedded_url"`
+	BandID           uint          `json:"band_id"`
+	Band             band.Band     `gorm:"foreignKey:BandID" json:"band"`
+	Tags             []Tag         `gorm:"many2many:song_tags;" json:"tags"`
+	MediaLinks       []MediaLink   `gorm:"foreignKey:SongID" json:"media_links"`
+	Translations     []Translation `gorm:"foreignKey:SongID" json:"translations"`
+	IsPublic         bool          `gorm:"index" json:"is_public"`
+	PublishedAt      *time.Time    `json:"published_at"`
+	ForkedFromID     *uint         `gorm:"index" json:"forked_from_id"`
+	ForkedFrom       *Song         `gorm:"foreignKey:ForkedFromID" json:"forked_from"`
+	UpstreamSyncedAt *time.Time    `json:"upstream_synced_at"` // Last time the fork pulled its upstream content
 }
@@ -0,0 +1,15 @@
+package song
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+)
+
+type Tag struct {
+	gorm.Model
+	Title  string    `json:"title"`
+	Color  *string   `json:"color"`
+	BandID uint      `json:"band_id"`
+	Band   band.Band `gorm:"foreignKey:BandID" json:"band"`
+}
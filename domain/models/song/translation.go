@@ -0,0 +1,16 @@
+package song
+
+import (
+	"gorm.io/gorm"
+)
+
+// Translation is the song lyrics in another language, written with the same
+// section headings as the song body so both can be lined up
+type Translation struct {
+	gorm.Model
+	SongID   uint   `gorm:"uniqueIndex:idx_translation_song_language" json:"song_id"`
+	Song     Song   `gorm:"foreignKey:SongID" json:"song"`
+	Language string `gorm:"uniqueIndex:idx_translation_song_language" json:"language"` // e.g. "en" or "pt-BR"
+	Title    string `json:"title"`
+	Body     string `json:"body"`
+}
@@ -16,3 +16,10 @@ type AccountPublicOutput struct {
 	Name   string `json:"name"`
 	Avatar string `json:"avatar"`
 }
+
+type UnavailabilityOutput struct {
+	ID   uint   `json:"id"`
+	From string `json:"from"`
+	To   string `json:"to"`
+	Note string `json:"note"`
+}
@@ -27,4 +27,5 @@ type MemberOutput struct {
 	Account  *accountoutputs.AccountOutput `json:"account"`
 	JoinedAt time.Time                     `json:"joined_at"`
 	Role     string                        `json:"role"`
+	Required bool                          `json:"required"`
 }
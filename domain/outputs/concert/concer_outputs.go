@@ -1 +1,71 @@
 package concertoutputs
+
+import (
+	"time"
+
+	accountoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/account"
+)
+
+type ConcertOutput struct {
+	ID          uint                 `json:"id"`
+	Title       string               `json:"title"`
+	Description string               `json:"description"`
+	Date        time.Time            `json:"date"`
+	Venue       string               `json:"venue"`
+	TimeZone    string               `json:"time_zone"`
+	BandID      uint                 `json:"band_id"`
+	Songs       []*ConcertSongOutput `json:"songs"`
+	Warnings    []string             `json:"warnings,omitempty"` // Required members unavailable on the concert day
+}
+
+type ConcertSongOutput struct {
+	ID             uint                    `json:"id"`
+	SongID         uint                    `json:"song_id"`
+	Position       int                     `json:"position"`
+	Title          string                  `json:"title"`
+	Writter        string                  `json:"writter"`
+	Tone           string                  `json:"tone"`
+	Key            *string                 `json:"key"`
+	Capo           *int                    `json:"capo"`
+	Arrangement    []*SetlistSectionOutput `json:"arrangement"`
+	LeadVocalistID *uint                   `json:"lead_vocalist_id"`
+	LeadVocalist   string                  `json:"lead_vocalist"`
+	Segue          bool                    `json:"segue"`
+	Transition     string                  `json:"transition"`
+}
+
+type SetlistSectionOutput struct {
+	SectionID uint   `json:"section_id"`
+	Label     string `json:"label"`
+	Repeat    int    `json:"repeat"`
+}
+
+// The token is only sent when the feed is created
+type CalendarFeedOutput struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+type RSVPOutput struct {
+	ConcertID uint   `json:"concert_id"`
+	AccountID uint   `json:"account_id"`
+	Response  string `json:"response"`
+	Note      string `json:"note"`
+}
+
+type RSVPSummaryOutput struct {
+	Yes     int                 `json:"yes"`
+	No      int                 `json:"no"`
+	Maybe   int                 `json:"maybe"`
+	Pending int                 `json:"pending"`
+	Members []*RSVPStatusOutput `json:"members"`
+}
+
+type RSVPStatusOutput struct {
+	Account         *accountoutputs.AccountPublicOutput `json:"account"`
+	Required        bool                                `json:"required"`
+	Response        string                              `json:"response"` // Empty while pending
+	Note            string                              `json:"note"`
+	Unavailable     bool                                `json:"unavailable"`
+	UnavailableNote string                              `json:"unavailable_note"`
+}
@@ -1 +1,253 @@
 package songoutputs
+
+import (
+	"time"
+
+	accountoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/account"
+	bandoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/band"
+)
+
+type SongOutput struct {
+	ID               uint                    `json:"id"`
+	Title            string                  `json:"title"`
+	Writter          string                  `json:"writter"`
+	Tone             string                  `json:"tone"`
+	OriginalKey      string                  `json:"original_key"`
+	Bpm              int                     `json:"bpm"`
+	TimeSignature    string                  `json:"time_signature"`
+	DurationSeconds  int                     `json:"duration_seconds"`
+	Capo             int                     `json:"capo"`
+	Body             string                  `json:"body"`
+	EmbeddedUrl      string                  `json:"embedded_url"`
+	Band             *bandoutputs.BandOutput `json:"band"`
+	Tags             []*TagOutput            `json:"tags"`
+	Embed            *EmbedOutput            `json:"embed"`
+	MediaLinks       []*MediaLinkOutput      `json:"media_links"`
+	IsPublic         bool                    `json:"is_public"`
+	PublishedAt      *time.Time              `json:"published_at"`
+	ForkedFromID     uint                    `json:"forked_from_id"`
+	UpstreamSyncedAt *time.Time              `json:"upstream_synced_at"`
+	Personalized     bool                    `json:"personalized"` // Charted after the member preference
+}
+
+type EmbedOutput struct {
+	Provider string `json:"provider"`
+	ID       string `json:"id"`
+	Kind     string `json:"kind"`
+	Url      string `json:"url"`
+	EmbedUrl string `json:"embed_url"`
+	Start    int    `json:"start"`
+}
+
+type MediaLinkOutput struct {
+	ID       uint         `json:"id"`
+	Label    string       `json:"label"`
+	Position int          `json:"position"`
+	Embed    *EmbedOutput `json:"embed"`
+}
+
+type TagOutput struct {
+	ID    uint   `json:"id"`
+	Title string `json:"title"`
+	Color string `json:"color"`
+}
+
+type SectionOutput struct {
+	ID       uint   `json:"id"`
+	Kind     string `json:"kind"`
+	Label    string `json:"label"`
+	Position int    `json:"position"`
+	Body     string `json:"body"`
+}
+
+type ArrangementItemOutput struct {
+	ID       uint           `json:"id"`
+	Position int            `json:"position"`
+	Repeat   int            `json:"repeat"`
+	Section  *SectionOutput `json:"section"`
+}
+
+type ChartOutput struct {
+	ID    uint   `json:"id"`
+	Title string `json:"title"`
+	Tone  string `json:"tone"`
+	Body  string `json:"body"`
+}
+
+type ChartDocumentOutput struct {
+	ID       uint                  `json:"id"`
+	Title    string                `json:"title"`
+	Tone     string                `json:"tone"`
+	Mode     string                `json:"mode"`
+	Sections []*ChartSectionOutput `json:"sections"`
+}
+
+type ChartSectionOutput struct {
+	Label string             `json:"label"`
+	Lines []*ChartLineOutput `json:"lines"`
+}
+
+type ChartLineOutput struct {
+	Kind     string                `json:"kind"`
+	Segments []*ChartSegmentOutput `json:"segments"`
+}
+
+type ChartSegmentOutput struct {
+	Chord  string `json:"chord"`
+	Lyrics string `json:"lyrics"`
+}
+
+type SongSearchOutput struct {
+	Song    *SongOutput `json:"song"`
+	Rank    float64     `json:"rank"`
+	Snippet string      `json:"snippet"`
+}
+
+type RevisionOutput struct {
+	ID              uint                                `json:"id"`
+	Number          int                                 `json:"number"`
+	Author          *accountoutputs.AccountPublicOutput `json:"author"`
+	RestoredFrom    *int                                `json:"restored_from"`
+	CreatedAt       time.Time                           `json:"created_at"`
+	Title           string                              `json:"title"`
+	Writter         string                              `json:"writter"`
+	Tone            string                              `json:"tone"`
+	OriginalKey     string                              `json:"original_key"`
+	Bpm             int                                 `json:"bpm"`
+	TimeSignature   string                              `json:"time_signature"`
+	DurationSeconds int                                 `json:"duration_seconds"`
+	Capo            int                                 `json:"capo"`
+	Body            string                              `json:"body"`
+	EmbeddedUrl     string                              `json:"embedded_url"`
+}
+
+type FieldChangeOutput struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+type DiffLineOutput struct {
+	Op      string `json:"op"`
+	OldLine int    `json:"old_line"`
+	NewLine int    `json:"new_line"`
+	Text    string `json:"text"`
+}
+
+type RevisionDiffOutput struct {
+	From   *RevisionOutput      `json:"from"`
+	To     *RevisionOutput      `json:"to"`
+	Fields []*FieldChangeOutput `json:"fields"`
+	Lines  []*DiffLineOutput    `json:"lines"`
+}
+
+type ChordDiagramSetOutput struct {
+	Instrument string                `json:"instrument"`
+	Tuning     string                `json:"tuning"`
+	Diagrams   []*ChordDiagramOutput `json:"diagrams"`
+	Unvoiced   []string              `json:"unvoiced"`
+}
+
+type ChordDiagramOutput struct {
+	Chord    string   `json:"chord"`
+	Frets    []int    `json:"frets"`
+	BaseFret int      `json:"base_fret"`
+	Keys     []int    `json:"keys"`
+	Notes    []string `json:"notes"`
+	Svg      string   `json:"svg"`
+}
+
+type DuplicatePairOutput struct {
+	Song      *SongOutput `json:"song"`
+	Duplicate *SongOutput `json:"duplicate"`
+	Score     float64     `json:"score"`
+	Title     float64     `json:"title"`
+	Writer    float64     `json:"writer"`
+	Lyrics    float64     `json:"lyrics"`
+}
+
+type ImportReportOutput struct {
+	DryRun  bool               `json:"dry_run"`
+	Created int                `json:"created"`
+	Skipped int                `json:"skipped"`
+	Failed  int                `json:"failed"`
+	Rows    []*ImportRowOutput `json:"rows"`
+}
+
+type ImportRowOutput struct {
+	Row    int    `json:"row"`
+	Source string `json:"source"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+	SongID uint   `json:"song_id"`
+}
+
+type PreferenceOutput struct {
+	SongID   uint    `json:"song_id"`
+	Key      *string `json:"key"`
+	Capo     *int    `json:"capo"`
+	Notation string  `json:"notation"`
+}
+
+type AnnotationOutput struct {
+	ID         uint                                `json:"id"`
+	Kind       string                              `json:"kind"`
+	Private    bool                                `json:"private"`
+	Line       int                                 `json:"line"`
+	LineText   string                              `json:"line_text"`
+	SectionID  uint                                `json:"section_id"`
+	Orphaned   bool                                `json:"orphaned"`
+	Body       string                              `json:"body"`
+	Author     *accountoutputs.AccountPublicOutput `json:"author"`
+	Resolved   bool                                `json:"resolved"`
+	ResolvedAt *time.Time                          `json:"resolved_at"`
+	Hidden     bool                                `json:"hidden"`
+	CreatedAt  time.Time                           `json:"created_at"`
+	UpdatedAt  time.Time                           `json:"updated_at"`
+	Replies    []*AnnotationOutput                 `json:"replies"`
+}
+
+type TranslationOutput struct {
+	ID       uint   `json:"id"`
+	SongID   uint   `json:"song_id"`
+	Language string `json:"language"`
+	Title    string `json:"title"`
+	Body     string `json:"body"`
+}
+
+type OpenLyricsImportOutput struct {
+	Song         *SongOutput          `json:"song"`
+	Translations []*TranslationOutput `json:"translations"`
+	Dropped      []string             `json:"dropped"`
+}
+
+type PerformanceStatsOutput struct {
+	SongID         uint                `json:"song_id"`
+	Performances   int                 `json:"performances"`
+	FirstPerformed *time.Time          `json:"first_performed"`
+	LastPerformed  *time.Time          `json:"last_performed"`
+	AverageGapDays *float64            `json:"average_gap_days"`
+	Venues         []*VenueCountOutput `json:"venues"`
+}
+
+type VenueCountOutput struct {
+	Venue        string `json:"venue"`
+	Performances int    `json:"performances"`
+}
+
+type PerformanceCountOutput struct {
+	SongID        uint       `json:"song_id"`
+	Title         string     `json:"title"`
+	Performances  int        `json:"performances"`
+	Total         int        `json:"total"`
+	LastPerformed *time.Time `json:"last_performed"`
+}
+
+type PerformanceReportOutput struct {
+	From        time.Time                 `json:"from"`
+	To          time.Time                 `json:"to"`
+	MostPlayed  []*PerformanceCountOutput `json:"most_played"`
+	LeastPlayed []*PerformanceCountOutput `json:"least_played"`
+	NeverPlayed []*PerformanceCountOutput `json:"never_played"`
+}
@@ -0,0 +1,275 @@
+package bulkimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mazurco066/playliter-api-go/infra/chordpro"
+	"github.com/mazurco066/playliter-api-go/infra/textnorm"
+)
+
+const (
+	maxEntrySize   = 1 << 20  // Upper bound of bytes read from a single archive entry
+	maxArchiveSize = 32 << 20 // Upper bound of bytes read from all entries together
+)
+
+// Record is a song read from an import file, before any validation
+type Record struct {
+	Row        int
+	Source     string // Line of the CSV or file name inside the archive
+	Song       *chordpro.Song
+	Categories []string
+	Err        error // Set when the record itself could not be read
+}
+
+// Header aliases in english and portuguese
+var columns = map[string]string{
+	"title":      "title",
+	"titulo":     "title",
+	"name":       "title",
+	"nome":       "title",
+	"musica":     "title",
+	"writer":     "writer",
+	"writter":    "writer",
+	"author":     "writer",
+	"autor":      "writer",
+	"artist":     "writer",
+	"artista":    "writer",
+	"compositor": "writer",
+	"key":        "key",
+	"tone":       "key",
+	"tom":        "key",
+	"tonalidade": "key",
+	"category":   "category",
+	"categoria":  "category",
+	"categories": "category",
+	"tags":       "category",
+	"body":       "body",
+	"letra":      "body",
+	"cifra":      "body",
+	"lyrics":     "body",
+	"chart":      "body",
+}
+
+var defaultColumns = []string{"title", "writer", "key", "category", "body"}
+
+var textExtensions = []string{".txt"}
+
+var chordProExtensions = []string{".cho", ".chordpro", ".chopro", ".crd"}
+
+// ParseCSV reads songs from a CSV with the title, writer, key, category and
+// body columns. A header row is optional and may name the columns in any
+// order, semicolon separated files exported by spreadsheets are accepted.
+func ParseCSV(content []byte) ([]*Record, error) {
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+	if !utf8.Valid(content) {
+		return nil, errors.New("csv file must be UTF-8 encoded")
+	}
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = delimiter(content)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv file: %s", err.Error())
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("csv file has no rows")
+	}
+
+	order := defaultColumns
+	first := 0
+	if header, ok := headerColumns(rows[0]); ok {
+		order = header
+		first = 1
+	}
+
+	var records []*Record
+	for i := first; i < len(rows); i++ {
+		if isBlank(rows[i]) {
+			continue
+		}
+		record := &Record{Row: len(records) + 1, Source: fmt.Sprintf("line %d", i+1), Song: &chordpro.Song{}}
+		for j, value := range rows[i] {
+			if j >= len(order) {
+				break
+			}
+			value = strings.TrimSpace(value)
+			switch order[j] {
+			case "title":
+				record.Song.Title = value
+			case "writer":
+				record.Song.Artist = value
+			case "key":
+				record.Song.Key = value
+			case "category":
+				record.Categories = splitCategories(value)
+			case "body":
+				record.Song.Body = strings.ReplaceAll(value, "\r\n", "\n")
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// ParseZip reads songs from every text and ChordPro file of an archive.
+// Plain text files are named after the file, ChordPro files may hold
+// several songs. Reading stops with an error once the archive holds more
+// than maxRecords songs or too many bytes once uncompressed.
+func ParseZip(content []byte, maxRecords int) ([]*Record, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, errors.New("invalid zip archive")
+	}
+
+	var records []*Record
+	var uncompressed uint64
+	for _, file := range archive.File {
+		if len(records) > maxRecords {
+			return nil, fmt.Errorf("zip archive has more than %d songs", maxRecords)
+		}
+
+		name := file.Name
+		base := path.Base(name)
+		extension := strings.ToLower(path.Ext(base))
+		if file.FileInfo().IsDir() || strings.HasPrefix(name, "__MACOSX/") || strings.HasPrefix(base, ".") {
+			continue
+		}
+		isText, isChordPro := contains(textExtensions, extension), contains(chordProExtensions, extension)
+		if !isText && !isChordPro {
+			continue
+		}
+
+		// The declared size is enforced by the zip reader while reading
+		uncompressed += file.UncompressedSize64
+		if uncompressed > maxArchiveSize {
+			return nil, errors.New("zip archive is too large once uncompressed")
+		}
+
+		text, readErr := readEntry(file)
+		if readErr != nil {
+			records = append(records, &Record{Row: len(records) + 1, Source: name, Err: readErr})
+			continue
+		}
+
+		title := strings.TrimSuffix(base, path.Ext(base))
+		if isText {
+			document := &chordpro.Song{Title: title, Body: text}
+			records = append(records, &Record{Row: len(records) + 1, Source: name, Song: document})
+			continue
+		}
+
+		songs, parseErr := chordpro.Parse(text)
+		if parseErr != nil {
+			records = append(records, &Record{Row: len(records) + 1, Source: name, Err: parseErr})
+			continue
+		}
+		// Songs without a title directive are named after the file
+		for i, document := range songs {
+			record := &Record{Row: len(records) + 1, Source: name, Song: document}
+			if len(songs) > 1 {
+				record.Source = fmt.Sprintf("%s #%d", name, i+1)
+			}
+			if document.Title == "" {
+				document.Title = title
+				if len(songs) > 1 {
+					document.Title = fmt.Sprintf("%s (%d)", title, i+1)
+				}
+			}
+			records = append(records, record)
+		}
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("zip archive has no .txt or .cho files")
+	}
+	if len(records) > maxRecords {
+		return nil, fmt.Errorf("zip archive has more than %d songs", maxRecords)
+	}
+	return records, nil
+}
+
+func readEntry(file *zip.File) (string, error) {
+	if file.UncompressedSize64 > maxEntrySize {
+		return "", errors.New("file is too large")
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(io.LimitReader(reader, maxEntrySize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(content) > maxEntrySize {
+		return "", errors.New("file is too large")
+	}
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+	if !utf8.Valid(content) {
+		return "", errors.New("file must be UTF-8 encoded")
+	}
+	return strings.TrimSpace(strings.ReplaceAll(string(content), "\r\n", "\n")), nil
+}
+
+// Spreadsheets in portuguese locales export with semicolons
+func delimiter(content []byte) rune {
+	firstLine, _, _ := bytes.Cut(content, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+func headerColumns(row []string) ([]string, bool) {
+	var order []string
+	found := false
+	for _, cell := range row {
+		column := columns[textnorm.Simplify(cell)]
+		if column == "title" {
+			found = true
+		}
+		order = append(order, column)
+	}
+	return order, found
+}
+
+// Several categories may be given separated by "|" or ";"
+func splitCategories(value string) []string {
+	var categories []string
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == '|' || r == ';' }) {
+		if part = strings.TrimSpace(part); part != "" {
+			categories = append(categories, part)
+		}
+	}
+	return categories
+}
+
+func isBlank(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
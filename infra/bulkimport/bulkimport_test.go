@@ -0,0 +1,227 @@
+package bulkimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/mazurco066/playliter-api-go/infra/chordpro"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []*Record
+	}{
+		{
+			name:    "default columns without header",
+			content: "Oceans,Hillsong,D,Worship|Live,\"[D]You call me\"\n",
+			want: []*Record{
+				{Row: 1, Source: "line 1", Song: &chordpro.Song{Title: "Oceans", Artist: "Hillsong", Key: "D", Body: "[D]You call me"}, Categories: []string{"Worship", "Live"}},
+			},
+		},
+		{
+			name:    "header aliases in any order",
+			content: "\ufeffTom,Título,Autor,Letra\nG,Grace,John,Amazing\n",
+			want: []*Record{
+				{Row: 1, Source: "line 2", Song: &chordpro.Song{Title: "Grace", Artist: "John", Key: "G", Body: "Amazing"}},
+			},
+		},
+		{
+			name:    "semicolon delimiter and blank rows",
+			content: "titulo;artista;categoria\nUm;Ana;Louvor; Ceia \n;;\nDois;Bia;\n",
+			want: []*Record{
+				{Row: 1, Source: "line 2", Song: &chordpro.Song{Title: "Um", Artist: "Ana"}, Categories: []string{"Louvor"}},
+				{Row: 2, Source: "line 4", Song: &chordpro.Song{Title: "Dois", Artist: "Bia"}},
+			},
+		},
+		{
+			name:    "unknown header columns are ignored",
+			content: "title,notes\nSong,skip me\n",
+			want: []*Record{
+				{Row: 1, Source: "line 2", Song: &chordpro.Song{Title: "Song"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCSV([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("ParseCSV error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCSV = %s, want %s", describe(got), describe(tt.want))
+			}
+		})
+	}
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+	}{
+		{name: "empty", content: nil},
+		{name: "not utf-8", content: []byte{'t', 'i', 't', 'l', 'e', '\n', 0xff, 0xfe}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if records, err := ParseCSV(tt.content); err == nil {
+				t.Errorf("ParseCSV = %s, want error", describe(records))
+			}
+		})
+	}
+}
+
+func TestParseZip(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries map[string]string
+		want    []*Record
+	}{
+		{
+			name: "text file named after the file",
+			entries: map[string]string{
+				"songs/Grace.txt":      "\ufeffAmazing grace\r\n",
+				"songs/cover.png":      "binary",
+				"__MACOSX/._Grace.txt": "junk",
+				".hidden.txt":          "hidden",
+			},
+			want: []*Record{
+				{Row: 1, Source: "songs/Grace.txt", Song: &chordpro.Song{Title: "Grace", Body: "Amazing grace"}},
+			},
+		},
+		{
+			name: "chordpro file with several songs",
+			entries: map[string]string{
+				"set.cho": "{title: One}\n[G]First\n{new_song}\n[C]Second\n",
+			},
+			want: []*Record{
+				{Row: 1, Source: "set.cho #1", Song: &chordpro.Song{Title: "One", Body: "[G]First"}},
+				{Row: 2, Source: "set.cho #2", Song: &chordpro.Song{Title: "set (2)", Body: "[C]Second"}},
+			},
+		},
+		{
+			name: "entry that is not utf-8",
+			entries: map[string]string{
+				"bad.txt": "\xff\xfe",
+			},
+			want: []*Record{
+				{Row: 1, Source: "bad.txt", Err: errNotUTF8},
+			},
+		},
+		{
+			name: "entry over the size limit",
+			entries: map[string]string{
+				"long.txt": strings.Repeat("a", maxEntrySize+1),
+			},
+			want: []*Record{
+				{Row: 1, Source: "long.txt", Err: errTooLarge},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseZip(archive(t, tt.entries), 10)
+			if err != nil {
+				t.Fatalf("ParseZip error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseZip = %s, want %s", describe(got), describe(tt.want))
+			}
+			for i := range got {
+				if (got[i].Err == nil) != (tt.want[i].Err == nil) {
+					t.Errorf("record %d error = %v, want %v", i, got[i].Err, tt.want[i].Err)
+				}
+				got[i].Err, tt.want[i].Err = nil, nil
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseZip = %s, want %s", describe(got), describe(tt.want))
+			}
+		})
+	}
+}
+
+func TestParseZipErrors(t *testing.T) {
+	// Enough full sized entries to go over the archive limit
+	large := map[string]string{}
+	for i := 0; i <= maxArchiveSize/maxEntrySize; i++ {
+		large[fmt.Sprintf("song%02d.txt", i)] = strings.Repeat("a", maxEntrySize)
+	}
+
+	tests := []struct {
+		name       string
+		content    []byte
+		maxRecords int
+	}{
+		{name: "not an archive", content: []byte("plain text"), maxRecords: 10},
+		{name: "no song files", content: archive(t, map[string]string{"cover.png": "binary"}), maxRecords: 10},
+		{
+			name:       "more songs than allowed",
+			content:    archive(t, map[string]string{"a.txt": "A", "b.txt": "B", "c.txt": "C"}),
+			maxRecords: 2,
+		},
+		{
+			name:       "more songs than allowed in one chordpro file",
+			content:    archive(t, map[string]string{"set.cho": "[G]One\n{ns}\n[C]Two\n{ns}\n[D]Three"}),
+			maxRecords: 2,
+		},
+		{name: "too large once uncompressed", content: archive(t, large), maxRecords: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if records, err := ParseZip(tt.content, tt.maxRecords); err == nil {
+				t.Errorf("ParseZip = %s, want error", describe(records))
+			}
+		})
+	}
+}
+
+// Only whether a record failed is compared, not the message
+var (
+	errNotUTF8  = errors.New("file must be UTF-8 encoded")
+	errTooLarge = errors.New("file is too large")
+)
+
+// Writes the entries into an archive, sorted by name
+func archive(t *testing.T, entries map[string]string) []byte {
+	var names []string
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, name := range names {
+		entry, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := entry.Write([]byte(entries[name])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func describe(records []*Record) string {
+	var parts []string
+	for _, record := range records {
+		parts = append(parts, fmt.Sprintf("%+v %+v", *record, record.Song))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
@@ -0,0 +1,291 @@
+package chart
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mazurco066/playliter-api-go/infra/chordpro"
+	"github.com/mazurco066/playliter-api-go/infra/chords"
+)
+
+const (
+	KindVerse  = "verse"
+	KindChorus = "chorus"
+	KindBridge = "bridge"
+	KindIntro  = "intro"
+	KindOutro  = "outro"
+	KindTag    = "tag"
+)
+
+var (
+	Kinds = []string{KindVerse, KindChorus, KindBridge, KindIntro, KindOutro, KindTag}
+
+	repeatRegex      = regexp.MustCompile(`(?i)\s*\(?\s*(?:x\s*(\d+)|(\d+)\s*x)\s*\)?$`)
+	environmentRegex = regexp.MustCompile(`(?i)^\{\s*(start_of_|end_of_|so|eo)(chorus|verse|bridge|tab|grid|c|v|b|t|g)\s*(?::\s*(.*?))?\s*\}$`)
+	commentRegex     = regexp.MustCompile(`(?i)^\{\s*(?:comment|c|comment_italic|ci|comment_box|cb)\s*:\s*(.*?)\s*\}$`)
+	chorusRegex      = regexp.MustCompile(`(?i)^\{\s*chorus\s*(?::\s*(.*?))?\s*\}$`)
+)
+
+// Keywords, in english and portuguese, used to guess the kind of a section
+var kindKeywords = []struct {
+	kind     string
+	keywords []string
+}{
+	{KindChorus, []string{"pre-chorus", "pre chorus", "pré-refrão", "pre-refrao", "chorus", "refrão", "refrao", "coro"}},
+	{KindBridge, []string{"bridge", "ponte"}},
+	{KindIntro, []string{"intro", "introdução", "introducao"}},
+	{KindOutro, []string{"outro", "ending", "final", "finalização", "finalizacao", "coda"}},
+	{KindTag, []string{"tag"}},
+	{KindVerse, []string{"verse", "verso", "estrofe", "parte"}},
+}
+
+// Section is a named block of a chart, e.g. "Verse 1" or "Chorus"
+type Section struct {
+	Kind  string
+	Label string
+	Body  string
+}
+
+// Entry is a reference to a section in the arrangement order
+type Entry struct {
+	Section int // Index of the referenced section
+	Repeat  int
+}
+
+// RenderEntry is an expanded arrangement entry ready to be rendered
+type RenderEntry struct {
+	Label  string
+	Body   string
+	Repeat int
+}
+
+// ParseSections splits a chart body into named sections and the order in
+// which they are played. Headings like "[Chorus]", "Verse 1:", ChordPro
+// environments and comments start a new section, a heading that repeats an
+// earlier label without new content reuses the existing section. Charts
+// without headings are split into paragraphs and identical paragraphs are
+// treated as a chorus.
+func ParseSections(body string) ([]Section, []Entry) {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	if !hasHeadings(lines) {
+		return parseParagraphs(lines)
+	}
+
+	type block struct {
+		label  string
+		repeat int
+		lines  []string
+	}
+	var blocks []*block
+	current := &block{repeat: 1}
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if label, isEnd, ok := heading(trimmed); ok {
+			blocks = append(blocks, current)
+			current = &block{repeat: 1}
+			if !isEnd {
+				current.label, current.repeat = splitRepeat(label)
+			}
+			continue
+		}
+		// Metadata directives like {title} or {key} do not belong to a section
+		if chords.IsDirective(trimmed) {
+			continue
+		}
+		current.lines = append(current.lines, line)
+	}
+	blocks = append(blocks, current)
+
+	var sections []Section
+	var entries []Entry
+	used := map[string]int{}
+	for _, b := range blocks {
+		content := strings.Trim(strings.Join(b.lines, "\n"), "\n")
+		blank := strings.TrimSpace(content) == ""
+		if b.label == "" && blank {
+			continue
+		}
+
+		if index, exists := used[strings.ToLower(b.label)]; exists && (blank || content == sections[index].Body) {
+			entries = append(entries, Entry{Section: index, Repeat: b.repeat})
+			continue
+		}
+
+		kind, label := KindVerse, "Verse"
+		if b.label != "" {
+			kind, label = Kind(b.label), b.label
+		}
+		label = uniqueLabel(label, used)
+
+		sections = append(sections, Section{Kind: kind, Label: label, Body: content})
+		used[strings.ToLower(label)] = len(sections) - 1
+		entries = append(entries, Entry{Section: len(sections) - 1, Repeat: b.repeat})
+	}
+	return sections, entries
+}
+
+// Render expands an arrangement into the full chart, every repetition of a
+// section is written out under its heading
+func Render(entries []RenderEntry) string {
+	var parts []string
+	for _, entry := range entries {
+		repeat := entry.Repeat
+		if repeat < 1 {
+			repeat = 1
+		}
+		for i := 0; i < repeat; i++ {
+			parts = append(parts, "["+entry.Label+"]\n"+entry.Body)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// Kind guesses the kind of a section from its label
+func Kind(label string) string {
+	lower := strings.ToLower(label)
+	for _, candidate := range kindKeywords {
+		for _, keyword := range candidate.keywords {
+			if strings.Contains(lower, keyword) {
+				return candidate.kind
+			}
+		}
+	}
+	return KindVerse
+}
+
+// IsKind reports whether kind is one of the supported section kinds
+func IsKind(kind string) bool {
+	for _, k := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func hasHeadings(lines []string) bool {
+	for _, line := range lines {
+		if _, _, ok := heading(strings.TrimSpace(line)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// heading returns the label of a heading line and whether it closes an environment
+func heading(line string) (string, bool, bool) {
+	if match := environmentRegex.FindStringSubmatch(line); match != nil {
+		isEnd := strings.HasPrefix(strings.ToLower(match[1]), "e")
+		label := match[3]
+		if label == "" {
+			label = environmentLabel(strings.ToLower(match[2]))
+		}
+		return label, isEnd, true
+	}
+	if match := commentRegex.FindStringSubmatch(line); match != nil {
+		return match[1], false, true
+	}
+	if match := chorusRegex.FindStringSubmatch(line); match != nil {
+		if match[1] == "" {
+			return "Chorus", false, true
+		}
+		return match[1], false, true
+	}
+	if label := chordpro.Heading(line); label != "" {
+		return label, false, true
+	}
+	return "", false, false
+}
+
+func environmentLabel(name string) string {
+	switch name {
+	case "chorus", "c":
+		return "Chorus"
+	case "bridge", "b":
+		return "Bridge"
+	case "tab", "t":
+		return "Tab"
+	case "grid", "g":
+		return "Grid"
+	}
+	return "Verse"
+}
+
+func splitRepeat(label string) (string, int) {
+	match := repeatRegex.FindStringSubmatchIndex(label)
+	if match == nil {
+		return strings.TrimSpace(label), 1
+	}
+	var digits string
+	if match[2] >= 0 {
+		digits = label[match[2]:match[3]]
+	} else {
+		digits = label[match[4]:match[5]]
+	}
+	repeat, err := strconv.Atoi(digits)
+	if err != nil || repeat < 1 {
+		repeat = 1
+	}
+	return strings.TrimSpace(label[:match[0]]), repeat
+}
+
+func uniqueLabel(label string, used map[string]int) string {
+	if _, exists := used[strings.ToLower(label)]; !exists {
+		return label
+	}
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s %d", label, n)
+		if _, exists := used[strings.ToLower(candidate)]; !exists {
+			return candidate
+		}
+	}
+}
+
+func parseParagraphs(lines []string) ([]Section, []Entry) {
+	var paragraphs []string
+	var current []string
+	for _, line := range append(lines, "") {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, strings.Join(current, "\n"))
+			}
+			current = nil
+			continue
+		}
+		current = append(current, line)
+	}
+
+	occurrences := map[string]int{}
+	for _, p := range paragraphs {
+		occurrences[p]++
+	}
+
+	var sections []Section
+	var entries []Entry
+	byBody := map[string]int{}
+	verses, choruses := 0, 0
+	for _, p := range paragraphs {
+		if index, exists := byBody[p]; exists {
+			entries = append(entries, Entry{Section: index, Repeat: 1})
+			continue
+		}
+		section := Section{Kind: KindVerse, Body: p}
+		if occurrences[p] > 1 {
+			choruses++
+			section.Kind = KindChorus
+			section.Label = "Chorus"
+			if choruses > 1 {
+				section.Label = fmt.Sprintf("Chorus %d", choruses)
+			}
+		} else {
+			verses++
+			section.Label = fmt.Sprintf("Verse %d", verses)
+		}
+		sections = append(sections, section)
+		byBody[p] = len(sections) - 1
+		entries = append(entries, Entry{Section: len(sections) - 1, Repeat: 1})
+	}
+	return sections, entries
+}
@@ -0,0 +1,132 @@
+package chart
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		sections []Section
+		entries  []Entry
+	}{
+		{
+			name: "bracket headings with a repeated chorus",
+			body: "[Verse 1]\nG\nOne\n\n[Chorus]\nC\nSing\n\n[Verse 2]\nTwo\n\n[Chorus]",
+			sections: []Section{
+				{Kind: KindVerse, Label: "Verse 1", Body: "G\nOne"},
+				{Kind: KindChorus, Label: "Chorus", Body: "C\nSing"},
+				{Kind: KindVerse, Label: "Verse 2", Body: "Two"},
+			},
+			entries: []Entry{{Section: 0, Repeat: 1}, {Section: 1, Repeat: 1}, {Section: 2, Repeat: 1}, {Section: 1, Repeat: 1}},
+		},
+		{
+			name: "colon headings with repeat counts",
+			body: "Refrão (x2):\nLa la\nPonte 2x:\nOh",
+			sections: []Section{
+				{Kind: KindChorus, Label: "Refrão", Body: "La la"},
+				{Kind: KindBridge, Label: "Ponte", Body: "Oh"},
+			},
+			entries: []Entry{{Section: 0, Repeat: 2}, {Section: 1, Repeat: 2}},
+		},
+		{
+			name: "chordpro environments skip metadata directives",
+			body: "{title: Song}\n{start_of_verse}\n[G]One\n{end_of_verse}\n{soc: Coro}\n[C]Sing\n{eoc}\n{chorus}",
+			sections: []Section{
+				{Kind: KindVerse, Label: "Verse", Body: "[G]One"},
+				{Kind: KindChorus, Label: "Coro", Body: "[C]Sing"},
+				{Kind: KindChorus, Label: "Chorus", Body: ""},
+			},
+			entries: []Entry{{Section: 0, Repeat: 1}, {Section: 1, Repeat: 1}, {Section: 2, Repeat: 1}},
+		},
+		{
+			name: "same label with new content gets a numbered label",
+			body: "[Verse]\nOne\n[Verse]\nTwo",
+			sections: []Section{
+				{Kind: KindVerse, Label: "Verse", Body: "One"},
+				{Kind: KindVerse, Label: "Verse 2", Body: "Two"},
+			},
+			entries: []Entry{{Section: 0, Repeat: 1}, {Section: 1, Repeat: 1}},
+		},
+		{
+			name: "paragraphs without headings",
+			body: "One\n\nSing\n\nTwo\n\nSing",
+			sections: []Section{
+				{Kind: KindVerse, Label: "Verse 1", Body: "One"},
+				{Kind: KindChorus, Label: "Chorus", Body: "Sing"},
+				{Kind: KindVerse, Label: "Verse 2", Body: "Two"},
+			},
+			entries: []Entry{{Section: 0, Repeat: 1}, {Section: 1, Repeat: 1}, {Section: 2, Repeat: 1}, {Section: 1, Repeat: 1}},
+		},
+		{
+			name: "empty",
+			body: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sections, entries := ParseSections(tt.body)
+			if !reflect.DeepEqual(sections, tt.sections) {
+				t.Errorf("ParseSections(%q) sections = %+v, want %+v", tt.body, sections, tt.sections)
+			}
+			if !reflect.DeepEqual(entries, tt.entries) {
+				t.Errorf("ParseSections(%q) entries = %+v, want %+v", tt.body, entries, tt.entries)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []RenderEntry
+		want    string
+	}{
+		{name: "empty", want: ""},
+		{
+			name:    "repeats are written out",
+			entries: []RenderEntry{{Label: "Verse", Body: "One", Repeat: 1}, {Label: "Chorus", Body: "Sing", Repeat: 2}},
+			want:    "[Verse]\nOne\n\n[Chorus]\nSing\n\n[Chorus]\nSing",
+		},
+		{
+			name:    "repeat below one is played once",
+			entries: []RenderEntry{{Label: "Tag", Body: "End", Repeat: 0}},
+			want:    "[Tag]\nEnd",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.entries); got != tt.want {
+				t.Errorf("Render = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKind(t *testing.T) {
+	tests := []struct {
+		label string
+		want  string
+	}{
+		{label: "Verse 2", want: KindVerse},
+		{label: "Pre-Chorus", want: KindChorus},
+		{label: "Refrão", want: KindChorus},
+		{label: "Ponte", want: KindBridge},
+		{label: "Introdução", want: KindIntro},
+		{label: "Final", want: KindOutro},
+		{label: "Tag", want: KindTag},
+		{label: "Solo", want: KindVerse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			if got := Kind(tt.label); got != tt.want {
+				t.Errorf("Kind(%q) = %q, want %q", tt.label, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package chorddiagrams
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Fretted diagram geometry, in pixels
+const (
+	stringGap  = 16.0
+	fretGap    = 18.0
+	fretsShown = 5
+	gridLeft   = 22.0
+	gridTop    = 42.0
+)
+
+// Keyboard diagram geometry, in pixels
+const (
+	whiteWidth  = 14.0
+	whiteHeight = 60.0
+	blackWidth  = 9.0
+	blackHeight = 36.0
+	keysTop     = 24.0
+)
+
+// Pitch classes of the black keys
+var blackKeys = map[int]bool{1: true, 3: true, 6: true, 8: true, 10: true}
+
+// SVG draws the diagram as a standalone SVG document
+func SVG(d *Diagram) string {
+	if d.Instrument == Keyboard {
+		return keyboardSVG(d)
+	}
+	return frettedSVG(d)
+}
+
+func frettedSVG(d *Diagram) string {
+	count := len(d.Frets)
+	gridWidth := stringGap * float64(count-1)
+	width := gridLeft*2 + gridWidth
+	height := gridTop + fretGap*fretsShown + 12
+
+	var b strings.Builder
+	open(&b, width, height)
+	fmt.Fprintf(&b, `<text x="%s" y="16" text-anchor="middle" font-size="14" font-weight="bold">%s</text>`, num(width/2), html.EscapeString(d.Chord))
+
+	// Frets, with the nut drawn thicker when the shape starts at the first fret
+	for i := 0; i <= fretsShown; i++ {
+		y := gridTop + fretGap*float64(i)
+		stroke := 1.0
+		if i == 0 && d.BaseFret == 1 {
+			stroke = 4
+		}
+		fmt.Fprintf(&b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="#000" stroke-width="%s"/>`, num(gridLeft), num(y), num(gridLeft+gridWidth), num(y), num(stroke))
+	}
+	if d.BaseFret > 1 {
+		fmt.Fprintf(&b, `<text x="%s" y="%s" text-anchor="end" font-size="10">%dfr</text>`, num(gridLeft-4), num(gridTop+fretGap*0.5+4), d.BaseFret)
+	}
+
+	for i, fret := range d.Frets {
+		x := gridLeft + stringGap*float64(i)
+		fmt.Fprintf(&b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="#000" stroke-width="1"/>`, num(x), num(gridTop), num(x), num(gridTop+fretGap*fretsShown))
+		switch {
+		case fret < 0:
+			fmt.Fprintf(&b, `<text x="%s" y="%s" text-anchor="middle" font-size="11">×</text>`, num(x), num(gridTop-6))
+		case fret == 0:
+			fmt.Fprintf(&b, `<circle cx="%s" cy="%s" r="4" fill="none" stroke="#000"/>`, num(x), num(gridTop-10))
+		default:
+			y := gridTop + fretGap*(float64(fret-d.BaseFret)+0.5)
+			fmt.Fprintf(&b, `<circle cx="%s" cy="%s" r="6" fill="#000"/>`, num(x), num(y))
+		}
+	}
+
+	b.WriteString("</svg>")
+	return b.String()
+}
+
+// Draws whole octaves, from the one of the lowest key to the one of the
+// highest, marking every key to press
+func keyboardSVG(d *Diagram) string {
+	low, high := d.Keys[0], d.Keys[0]
+	for _, key := range d.Keys {
+		low, high = min(low, key), max(high, key)
+	}
+	first := low - low%12
+	last := high - high%12 + 11
+
+	pressed := map[int]bool{}
+	for _, key := range d.Keys {
+		pressed[key] = true
+	}
+
+	whites := 0
+	for key := first; key <= last; key++ {
+		if !blackKeys[key%12] {
+			whites++
+		}
+	}
+	width := whiteWidth*float64(whites) + 2
+	height := keysTop + whiteHeight + 2
+
+	var b strings.Builder
+	open(&b, width, height)
+	fmt.Fprintf(&b, `<text x="%s" y="16" text-anchor="middle" font-size="14" font-weight="bold">%s</text>`, num(width/2), html.EscapeString(d.Chord))
+
+	// White keys first, black keys are drawn over them
+	x := 1.0
+	positions := map[int]float64{}
+	for key := first; key <= last; key++ {
+		if blackKeys[key%12] {
+			positions[key] = x - blackWidth/2
+			continue
+		}
+		positions[key] = x
+		fmt.Fprintf(&b, `<rect x="%s" y="%s" width="%s" height="%s" fill="#fff" stroke="#000"/>`, num(x), num(keysTop), num(whiteWidth), num(whiteHeight))
+		if pressed[key] {
+			fmt.Fprintf(&b, `<circle cx="%s" cy="%s" r="4" fill="#d33"/>`, num(x+whiteWidth/2), num(keysTop+whiteHeight-10))
+		}
+		x += whiteWidth
+	}
+	for key := first; key <= last; key++ {
+		if !blackKeys[key%12] {
+			continue
+		}
+		fmt.Fprintf(&b, `<rect x="%s" y="%s" width="%s" height="%s" fill="#000"/>`, num(positions[key]), num(keysTop), num(blackWidth), num(blackHeight))
+		if pressed[key] {
+			fmt.Fprintf(&b, `<circle cx="%s" cy="%s" r="3" fill="#d33"/>`, num(positions[key]+blackWidth/2), num(keysTop+blackHeight-8))
+		}
+	}
+
+	b.WriteString("</svg>")
+	return b.String()
+}
+
+func open(b *strings.Builder, width float64, height float64) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s" font-family="sans-serif">`, num(width), num(height), num(width), num(height))
+}
+
+func num(n float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", n), "0"), ".")
+}
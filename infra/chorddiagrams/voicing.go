@@ -0,0 +1,401 @@
+package chorddiagrams
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mazurco066/playliter-api-go/infra/chords"
+)
+
+const (
+	Guitar     = "guitar"
+	Ukulele    = "ukulele"
+	Cavaquinho = "cavaquinho"
+	Keyboard   = "keyboard"
+
+	Standard = "standard"
+	DropD    = "drop_d"
+)
+
+// Highest fret a fretted shape may start at and the widest stretch of a hand
+const (
+	maxPosition = 12
+	maxSpan     = 3
+	maxFingers  = 4
+)
+
+// Instrument is a fretted instrument tuning, strings are listed from the
+// lowest to the highest as MIDI note numbers
+type Instrument struct {
+	Name    string
+	Tuning  string
+	Strings []int
+	// Guitar shapes always have the root (or the slash bass) as the lowest
+	// note, the reentrant four string instruments do not
+	BassFirst bool
+}
+
+var instruments = []Instrument{
+	{Name: Guitar, Tuning: Standard, Strings: []int{40, 45, 50, 55, 59, 64}, BassFirst: true},
+	{Name: Guitar, Tuning: DropD, Strings: []int{38, 45, 50, 55, 59, 64}, BassFirst: true},
+	{Name: Ukulele, Tuning: Standard, Strings: []int{67, 60, 64, 69}},
+	{Name: Cavaquinho, Tuning: Standard, Strings: []int{62, 67, 71, 74}},
+}
+
+// Chord formulas of the voicing library, in semitones above the root.
+// Qualities are looked up after normalizing their spelling, see formulaKey.
+var formulas = map[string][]int{
+	"":       {0, 4, 7},
+	"m":      {0, 3, 7},
+	"5":      {0, 7},
+	"6":      {0, 4, 7, 9},
+	"m6":     {0, 3, 7, 9},
+	"69":     {0, 4, 7, 9, 2},
+	"7":      {0, 4, 7, 10},
+	"maj7":   {0, 4, 7, 11},
+	"m7":     {0, 3, 7, 10},
+	"mmaj7":  {0, 3, 7, 11},
+	"dim":    {0, 3, 6},
+	"dim7":   {0, 3, 6, 9},
+	"m7b5":   {0, 3, 6, 10},
+	"aug":    {0, 4, 8},
+	"7aug":   {0, 4, 8, 10},
+	"sus2":   {0, 2, 7},
+	"sus4":   {0, 5, 7},
+	"7sus4":  {0, 5, 7, 10},
+	"add9":   {0, 4, 7, 2},
+	"madd9":  {0, 3, 7, 2},
+	"9":      {0, 4, 7, 10, 2},
+	"m9":     {0, 3, 7, 10, 2},
+	"maj9":   {0, 4, 7, 11, 2},
+	"7b9":    {0, 4, 7, 10, 1},
+	"7#9":    {0, 4, 7, 10, 3},
+	"11":     {0, 7, 10, 2, 5},
+	"m11":    {0, 3, 7, 10, 5},
+	"13":     {0, 4, 7, 10, 9},
+	"7b13":   {0, 4, 7, 10, 8},
+	"maj7#5": {0, 4, 8, 11},
+}
+
+// Alternative spellings of the same quality, including the brazilian "7M",
+// "4" and "7(9)" forms
+var aliases = map[string]string{
+	"M":      "",
+	"maj":    "",
+	"min":    "m",
+	"-":      "m",
+	"M7":     "maj7",
+	"7M":     "maj7",
+	"7+":     "7aug",
+	"+":      "aug",
+	"7#5":    "7aug",
+	"aug7":   "7aug",
+	"°":      "dim",
+	"º":      "dim",
+	"°7":     "dim7",
+	"º7":     "dim7",
+	"ø":      "m7b5",
+	"ø7":     "m7b5",
+	"m7-5":   "m7b5",
+	"sus":    "sus4",
+	"4":      "sus4",
+	"2":      "sus2",
+	"74":     "7sus4",
+	"7sus":   "7sus4",
+	"add2":   "add9",
+	"79":     "9",
+	"m79":    "m9",
+	"maj79":  "maj9",
+	"7M9":    "maj9",
+	"m7M":    "mmaj7",
+	"mM7":    "mmaj7",
+	"mmaj":   "mmaj7",
+	"711":    "11",
+	"m711":   "m11",
+	"713":    "13",
+	"7-9":    "7b9",
+	"7+9":    "7#9",
+	"7+5":    "7aug",
+	"7-13":   "7b13",
+	"7M#5":   "maj7#5",
+	"maj7+5": "maj7#5",
+	"-7":     "m7",
+	"min7":   "m7",
+	"-6":     "m6",
+}
+
+// Diagram is a voiced chord. Fretted instruments fill Frets, one entry per
+// string from the lowest, -1 for a muted string and 0 for an open one.
+// Keyboards fill Keys with the MIDI notes to press.
+type Diagram struct {
+	Chord      string
+	Instrument string
+	Tuning     string
+	Frets      []int
+	BaseFret   int // First fret drawn, 1 unless the shape is up the neck
+	Keys       []int
+	Notes      []string // Sounding notes from the lowest
+}
+
+// FindInstrument returns the fretted instrument of a tuning, an empty tuning
+// is the standard one
+func FindInstrument(name string, tuning string) (*Instrument, error) {
+	if tuning == "" {
+		tuning = Standard
+	}
+	for i := range instruments {
+		if instruments[i].Name == name && instruments[i].Tuning == tuning {
+			return &instruments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported tuning %q for %s", tuning, name)
+}
+
+// Voice finds the easiest shape of a chord symbol for the instrument, or the
+// notes to press on a keyboard
+func Voice(symbol string, instrument string, tuning string) (*Diagram, error) {
+	chord, err := chords.ParseChord(symbol)
+	if err != nil {
+		return nil, err
+	}
+	formula, ok := formulas[formulaKey(chord.Quality)]
+	if !ok {
+		return nil, fmt.Errorf("no voicing for chord quality %q", chord.Quality)
+	}
+
+	if instrument == Keyboard {
+		return voiceKeyboard(symbol, chord, formula), nil
+	}
+
+	fretted, err := FindInstrument(instrument, tuning)
+	if err != nil {
+		return nil, err
+	}
+	frets := fretted.search(chord, formula)
+	if frets == nil {
+		return nil, fmt.Errorf("no playable shape for %s on %s", symbol, instrument)
+	}
+
+	diagram := &Diagram{
+		Chord:      symbol,
+		Instrument: fretted.Name,
+		Tuning:     fretted.Tuning,
+		Frets:      frets,
+		BaseFret:   1,
+	}
+	if highest := maxFret(frets); highest > maxSpan+1 {
+		diagram.BaseFret = minFretted(frets)
+	}
+	flats := prefersFlats(symbol)
+	for i, fret := range frets {
+		if fret >= 0 {
+			diagram.Notes = append(diagram.Notes, chords.NoteName(fretted.Strings[i]+fret, flats))
+		}
+	}
+	return diagram, nil
+}
+
+func formulaKey(quality string) string {
+	key := strings.NewReplacer("(", "", ")", "", ",", "", "/", "").Replace(quality)
+	if alias, ok := aliases[key]; ok {
+		return alias
+	}
+	return key
+}
+
+// Keyboard chords are played in root position around middle C, with the
+// slash bass an octave below
+func voiceKeyboard(symbol string, chord *chords.Chord, formula []int) *Diagram {
+	diagram := &Diagram{Chord: symbol, Instrument: Keyboard}
+	root := 60 + chord.Root
+	if chord.Bass >= 0 {
+		diagram.Keys = append(diagram.Keys, 48+chord.Bass)
+	}
+	previous := root - 1
+	for _, interval := range formula {
+		note := root + interval
+		for note <= previous {
+			note += 12
+		}
+		diagram.Keys = append(diagram.Keys, note)
+		previous = note
+	}
+	flats := prefersFlats(symbol)
+	for _, key := range diagram.Keys {
+		diagram.Notes = append(diagram.Notes, chords.NoteName(key, flats))
+	}
+	return diagram
+}
+
+// Tries every shape within a hand span at each position of the neck, keeping
+// the one with the lowest cost. Strings only ever play chord tones.
+func (in *Instrument) search(chord *chords.Chord, formula []int) []int {
+	tones := map[int]bool{}
+	for _, interval := range formula {
+		tones[(chord.Root+interval)%12] = true
+	}
+	bass := chord.Root
+	if chord.Bass >= 0 {
+		bass = chord.Bass
+		tones[bass] = true
+	}
+
+	// The fifth may be left out of chords with four or more notes
+	required := map[int]bool{}
+	for _, interval := range formula {
+		if interval != 7 || len(formula) < 4 {
+			required[(chord.Root+interval)%12] = true
+		}
+	}
+	required[bass] = true
+	if len(required) > len(in.Strings) {
+		return nil
+	}
+
+	var best []int
+	bestCost := 0
+	frets := make([]int, len(in.Strings))
+	for position := 0; position <= maxPosition; position++ {
+		options := make([][]int, len(in.Strings))
+		for i, open := range in.Strings {
+			options[i] = []int{-1}
+			if tones[open%12] {
+				options[i] = append(options[i], 0)
+			}
+			for fret := max(position, 1); fret <= position+maxSpan; fret++ {
+				if tones[(open+fret)%12] {
+					options[i] = append(options[i], fret)
+				}
+			}
+		}
+
+		var walk func(s int)
+		walk = func(s int) {
+			if s == len(in.Strings) {
+				if cost, ok := in.cost(frets, required, bass); ok && (best == nil || cost < bestCost) {
+					best = append([]int{}, frets...)
+					bestCost = cost
+				}
+				return
+			}
+			for _, fret := range options[s] {
+				frets[s] = fret
+				walk(s + 1)
+			}
+		}
+		walk(0)
+	}
+	return best
+}
+
+// Rates how hard a shape is to play, rejecting the ones that are not
+// playable or that miss a chord tone
+func (in *Instrument) cost(frets []int, required map[int]bool, bass int) (int, bool) {
+	sounding, mutes, opens := 0, 0, 0
+	lowest := -1
+	present := map[int]bool{}
+	for i, fret := range frets {
+		if fret < 0 {
+			// Muted strings are only allowed below the lowest sounding string
+			if in.BassFirst && sounding > 0 {
+				return 0, false
+			}
+			mutes++
+			continue
+		}
+		if fret == 0 {
+			opens++
+		}
+		sounding++
+		pitch := in.Strings[i] + fret
+		if lowest < 0 || pitch < lowest {
+			lowest = pitch
+		}
+		present[pitch%12] = true
+	}
+
+	if in.BassFirst {
+		if sounding < 4 || lowest%12 != bass {
+			return 0, false
+		}
+	} else if mutes > 0 {
+		return 0, false
+	}
+	for tone := range required {
+		if !present[tone] {
+			return 0, false
+		}
+	}
+
+	fingers, low, high := fingering(frets)
+	if fingers > maxFingers || high-low > maxSpan {
+		return 0, false
+	}
+
+	cost := low*2 + (high-low)*2 + fingers*2 + mutes - opens
+	if lowest%12 != bass {
+		cost += 2
+	}
+	return cost, true
+}
+
+// Counts the fingers a shape needs, a barre on the lowest fret counts as a
+// single finger when no open string sounds above it
+func fingering(frets []int) (int, int, int) {
+	low, high := minFretted(frets), maxFret(frets)
+	if low == 0 {
+		return 0, 0, 0
+	}
+
+	first, count := -1, 0
+	for i, fret := range frets {
+		if fret == low {
+			if first < 0 {
+				first = i
+			}
+			count++
+		}
+	}
+	barre := count > 1
+	for i := first; barre && i < len(frets); i++ {
+		if frets[i] == 0 || frets[i] == -1 && i > first {
+			barre = false
+		}
+	}
+
+	fingers := 0
+	for _, fret := range frets {
+		if fret > 0 && (fret != low || !barre) {
+			fingers++
+		}
+	}
+	if barre {
+		fingers++
+	}
+	return fingers, low, high
+}
+
+func minFretted(frets []int) int {
+	low := 0
+	for _, fret := range frets {
+		if fret > 0 && (low == 0 || fret < low) {
+			low = fret
+		}
+	}
+	return low
+}
+
+func maxFret(frets []int) int {
+	high := 0
+	for _, fret := range frets {
+		if fret > high {
+			high = fret
+		}
+	}
+	return high
+}
+
+// Notes are spelled with flats when the chord itself is
+func prefersFlats(symbol string) bool {
+	return len(symbol) > 1 && symbol[1] == 'b'
+}
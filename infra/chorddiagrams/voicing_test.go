@@ -0,0 +1,133 @@
+package chorddiagrams
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVoice(t *testing.T) {
+	tests := []struct {
+		symbol     string
+		instrument string
+		tuning     string
+		frets      []int
+		baseFret   int
+		notes      []string
+	}{
+		{symbol: "C", instrument: Guitar, frets: []int{-1, 3, 2, 0, 1, 0}, baseFret: 1, notes: []string{"C", "E", "G", "C", "E"}},
+		{symbol: "G", instrument: Guitar, tuning: Standard, frets: []int{3, 2, 0, 0, 0, 3}, baseFret: 1, notes: []string{"G", "B", "D", "G", "B", "G"}},
+		{symbol: "D", instrument: Guitar, frets: []int{-1, -1, 0, 2, 3, 2}, baseFret: 1, notes: []string{"D", "A", "D", "F#"}},
+		{symbol: "D", instrument: Guitar, tuning: DropD, frets: []int{0, 0, 0, 2, 3, 2}, baseFret: 1, notes: []string{"D", "A", "D", "A", "D", "F#"}},
+		{symbol: "C/E", instrument: Guitar, frets: []int{-1, -1, 2, 0, 1, 0}, baseFret: 1, notes: []string{"E", "G", "C", "E"}},
+		{symbol: "Bb", instrument: Guitar, frets: []int{-1, 1, 0, 3, 3, 1}, baseFret: 1, notes: []string{"Bb", "D", "Bb", "D", "F"}},
+		{symbol: "C", instrument: Ukulele, frets: []int{0, 0, 0, 3}, baseFret: 1, notes: []string{"G", "C", "E", "C"}},
+		{symbol: "G7", instrument: Ukulele, frets: []int{0, 2, 1, 2}, baseFret: 1, notes: []string{"G", "D", "F", "B"}},
+		{symbol: "Am", instrument: Ukulele, frets: []int{2, 0, 0, 0}, baseFret: 1, notes: []string{"A", "C", "E", "A"}},
+		{symbol: "D", instrument: Cavaquinho, frets: []int{0, 2, 3, 4}, baseFret: 1, notes: []string{"D", "A", "D", "F#"}},
+		{symbol: "G", instrument: Cavaquinho, frets: []int{0, 0, 0, 0}, baseFret: 1, notes: []string{"D", "G", "B", "D"}},
+		{symbol: "Gm", instrument: Cavaquinho, frets: []int{0, 3, 3, 5}, baseFret: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.instrument+" "+tt.tuning+" "+tt.symbol, func(t *testing.T) {
+			got, err := Voice(tt.symbol, tt.instrument, tt.tuning)
+			if err != nil {
+				t.Fatalf("Voice(%q) error: %v", tt.symbol, err)
+			}
+			if !reflect.DeepEqual(got.Frets, tt.frets) {
+				t.Errorf("Voice(%q).Frets = %v, want %v", tt.symbol, got.Frets, tt.frets)
+			}
+			if got.BaseFret != tt.baseFret {
+				t.Errorf("Voice(%q).BaseFret = %d, want %d", tt.symbol, got.BaseFret, tt.baseFret)
+			}
+			if tt.notes != nil && !reflect.DeepEqual(got.Notes, tt.notes) {
+				t.Errorf("Voice(%q).Notes = %v, want %v", tt.symbol, got.Notes, tt.notes)
+			}
+		})
+	}
+}
+
+func TestVoiceKeyboard(t *testing.T) {
+	tests := []struct {
+		symbol string
+		keys   []int
+		notes  []string
+	}{
+		{symbol: "C7M", keys: []int{60, 64, 67, 71}, notes: []string{"C", "E", "G", "B"}},
+		{symbol: "C/G", keys: []int{55, 60, 64, 67}, notes: []string{"G", "C", "E", "G"}},
+		{symbol: "Eb", keys: []int{63, 67, 70}, notes: []string{"Eb", "G", "Bb"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			got, err := Voice(tt.symbol, Keyboard, "")
+			if err != nil {
+				t.Fatalf("Voice(%q) error: %v", tt.symbol, err)
+			}
+			if !reflect.DeepEqual(got.Keys, tt.keys) || !reflect.DeepEqual(got.Notes, tt.notes) {
+				t.Errorf("Voice(%q) = %v %v, want %v %v", tt.symbol, got.Keys, got.Notes, tt.keys, tt.notes)
+			}
+		})
+	}
+}
+
+func TestVoiceErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		symbol     string
+		instrument string
+		tuning     string
+	}{
+		{name: "invalid chord", symbol: "H", instrument: Guitar},
+		{name: "unknown quality", symbol: "C(#11)", instrument: Guitar},
+		{name: "unknown tuning", symbol: "C", instrument: Guitar, tuning: "open_g"},
+		{name: "unknown instrument", symbol: "C", instrument: "banjo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diagram, err := Voice(tt.symbol, tt.instrument, tt.tuning); err == nil {
+				t.Errorf("Voice(%q) = %+v, want error", tt.symbol, diagram)
+			}
+		})
+	}
+}
+
+func TestSVG(t *testing.T) {
+	tests := []struct {
+		name     string
+		diagram  *Diagram
+		contains []string
+	}{
+		{
+			name:     "open shape draws the nut",
+			diagram:  &Diagram{Chord: "C", Instrument: Guitar, Frets: []int{-1, 3, 2, 0, 1, 0}, BaseFret: 1},
+			contains: []string{`<svg xmlns="http://www.w3.org/2000/svg"`, `stroke-width="4"`, `>×</text>`},
+		},
+		{
+			name:     "shape up the neck names its fret",
+			diagram:  &Diagram{Chord: "Gm", Instrument: Cavaquinho, Frets: []int{0, 3, 3, 5}, BaseFret: 3},
+			contains: []string{`>3fr</text>`},
+		},
+		{
+			name:     "chord name is escaped",
+			diagram:  &Diagram{Chord: "C<7>", Instrument: Keyboard, Keys: []int{60, 64, 67, 70}},
+			contains: []string{`>C&lt;7&gt;</text>`, `fill="#d33"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SVG(tt.diagram)
+			if !strings.HasSuffix(got, "</svg>") {
+				t.Errorf("SVG = %s, want a closed document", got)
+			}
+			for _, fragment := range tt.contains {
+				if !strings.Contains(got, fragment) {
+					t.Errorf("SVG = %s, want it to contain %s", got, fragment)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,401 @@
+package chordpro
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/mazurco066/playliter-api-go/infra/chords"
+)
+
+var (
+	directiveRegex = regexp.MustCompile(`^\{\s*([A-Za-z_\-]+)\s*(?:[:\s]\s*(.*?))?\s*\}$`)
+	headingRegex   = regexp.MustCompile(`^\[([^\]]+)\]$`)
+	labelRegex     = regexp.MustCompile(`^([^\s:\[\]{}][^:\[\]{}]*):$`)
+	inlineRegex    = regexp.MustCompile(`\[([^\]]+)\]`)
+)
+
+// Directive aliases defined by the ChordPro specification
+var aliases = map[string]string{
+	"t":  "title",
+	"st": "subtitle",
+	"c":  "comment",
+	"ns": "new_song",
+}
+
+// Song is the metadata and body extracted from a ChordPro document
+type Song struct {
+	Title    string
+	Subtitle string
+	Artist   string
+	Composer string
+	Key      string
+	Tempo    string
+	Time     string
+	Duration string
+	Capo     string
+	Body     string
+}
+
+// Parse reads a ChordPro document, splitting it into songs at every
+// {new_song} directive. Metadata directives are extracted while comments,
+// environments and any other directive are kept in the song body.
+func Parse(content string) ([]*Song, error) {
+	content = strings.ReplaceAll(strings.TrimPrefix(content, "\ufeff"), "\r\n", "\n")
+
+	var songs []*Song
+	current := &Song{}
+	var body []string
+
+	flush := func() {
+		current.Body = strings.Trim(strings.Join(body, "\n"), "\n")
+		if current.Title != "" || current.Body != "" {
+			songs = append(songs, current)
+		}
+		current = &Song{}
+		body = nil
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		name, value, ok := directive(trimmed)
+		if !ok {
+			body = append(body, strings.TrimRight(line, " \t"))
+			continue
+		}
+
+		switch name {
+		case "new_song":
+			flush()
+		case "title":
+			current.Title = value
+		case "subtitle":
+			current.Subtitle = value
+		case "artist":
+			current.Artist = value
+		case "composer":
+			current.Composer = value
+		case "key":
+			current.Key = value
+		case "tempo":
+			current.Tempo = value
+		case "time":
+			current.Time = value
+		case "duration":
+			current.Duration = value
+		case "capo":
+			current.Capo = value
+		case "meta":
+			if !current.setMeta(value) {
+				body = append(body, trimmed)
+			}
+		default:
+			body = append(body, trimmed)
+		}
+	}
+	flush()
+
+	if len(songs) == 0 {
+		return nil, errors.New("no songs found in chordpro content")
+	}
+	return songs, nil
+}
+
+// Serialize renders a song as a ChordPro document
+func Serialize(s *Song) string {
+	var builder strings.Builder
+	writeDirective(&builder, "title", s.Title)
+	writeDirective(&builder, "subtitle", s.Subtitle)
+	writeDirective(&builder, "artist", s.Artist)
+	writeDirective(&builder, "composer", s.Composer)
+	writeDirective(&builder, "key", s.Key)
+	writeDirective(&builder, "tempo", s.Tempo)
+	writeDirective(&builder, "time", s.Time)
+	writeDirective(&builder, "duration", s.Duration)
+	writeDirective(&builder, "capo", s.Capo)
+	builder.WriteString("\n")
+	builder.WriteString(ToInline(s.Body))
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+// SerializeAll renders several songs into a single multi-song document
+func SerializeAll(songs []*Song) string {
+	var parts []string
+	for _, s := range songs {
+		parts = append(parts, Serialize(s))
+	}
+	return strings.Join(parts, "{new_song}\n")
+}
+
+// ToInline converts a chords-over-lyrics chart into inline ChordPro chords.
+// Section headings such as "[Chorus]" or "Verse 1:" become comments so they
+// are not mistaken for chords.
+func ToInline(body string) string {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	var out []string
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if heading := Heading(trimmed); heading != "" {
+			out = append(out, "{comment: "+heading+"}")
+			continue
+		}
+
+		if !chords.IsChordLine(line) {
+			out = append(out, line)
+			continue
+		}
+
+		// A labelled chord line such as "[Intro] C  G" starts a section
+		if label, rest := chords.ChordLineLabel(line); label != "" {
+			out = append(out, "{comment: "+label+"}")
+			line = rest
+		}
+
+		if i+1 < len(lines) && isLyricLine(lines[i+1]) {
+			out = append(out, mergeChordLine(line, lines[i+1]))
+			i++
+			continue
+		}
+		out = append(out, mergeChordLine(line, ""))
+	}
+	return strings.Join(out, "\n")
+}
+
+// FromInline converts inline ChordPro chords into a chords-over-lyrics chart,
+// the inverse of ToInline. Comments and section environments become
+// "[Label]" headings while any other directive is dropped.
+func FromInline(body string) string {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	var out []string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if name, value, ok := directive(trimmed); ok {
+			if heading := environmentHeading(name, value); heading != "" {
+				out = append(out, "["+heading+"]")
+			}
+			continue
+		}
+
+		if !chords.HasInlineChords(line) {
+			out = append(out, line)
+			continue
+		}
+
+		chordLine, lyric := splitInline(line)
+		if strings.TrimSpace(lyric) == "" {
+			out = append(out, chordLine)
+			continue
+		}
+		out = append(out, chordLine, lyric)
+	}
+	return strings.Join(out, "\n")
+}
+
+// Heading returns the section name of a "[Verse 1]" or "Chorus:" line
+func Heading(line string) string {
+	if match := headingRegex.FindStringSubmatch(line); match != nil && !chords.IsChord(strings.TrimSpace(match[1])) {
+		return strings.TrimSpace(match[1])
+	}
+	if match := labelRegex.FindStringSubmatch(line); match != nil && len(strings.Fields(match[1])) <= 3 {
+		return strings.TrimSpace(match[1])
+	}
+	return ""
+}
+
+// SectionHeading returns the section name started by a comment or section
+// environment directive such as "{comment: Chorus}" or "{start_of_verse}"
+func SectionHeading(line string) string {
+	name, value, ok := directive(strings.TrimSpace(line))
+	if !ok {
+		return ""
+	}
+	return environmentHeading(name, value)
+}
+
+func isLyricLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed != "" &&
+		!chords.IsChordLine(line) &&
+		!chords.IsDirective(line) &&
+		Heading(trimmed) == ""
+}
+
+func mergeChordLine(chordLine string, lyric string) string {
+	type placement struct {
+		column int
+		symbol string
+	}
+	var placements []placement
+	runes := []rune(chordLine)
+	for i := 0; i < len(runes); {
+		if runes[i] == ' ' || runes[i] == '\t' {
+			i++
+			continue
+		}
+		start := i
+		for i < len(runes) && runes[i] != ' ' && runes[i] != '\t' {
+			i++
+		}
+		placements = append(placements, placement{column: start, symbol: string(runes[start:i])})
+	}
+
+	if lyric == "" {
+		var symbols []string
+		for _, p := range placements {
+			if core := strings.Trim(p.symbol, "()|"); chords.IsChord(core) {
+				symbols = append(symbols, "["+core+"]")
+				continue
+			}
+			symbols = append(symbols, p.symbol)
+		}
+		return strings.Join(symbols, " ")
+	}
+
+	lyricRunes := []rune(lyric)
+	var builder strings.Builder
+	cursor := 0
+	for _, p := range placements {
+		for cursor < p.column {
+			if cursor < len(lyricRunes) {
+				builder.WriteRune(lyricRunes[cursor])
+			} else {
+				builder.WriteRune(' ')
+			}
+			cursor++
+		}
+		if core := strings.Trim(p.symbol, "()|"); chords.IsChord(core) {
+			builder.WriteString("[" + core + "]")
+		}
+	}
+	if cursor < len(lyricRunes) {
+		builder.WriteString(string(lyricRunes[cursor:]))
+	}
+	return strings.TrimRight(builder.String(), " ")
+}
+
+// Heading text of a comment or of the start of a section environment
+func environmentHeading(name string, value string) string {
+	switch name {
+	case "comment", "comment_italic", "ci", "comment_box", "cb", "highlight":
+		return value
+	case "chorus":
+		if value != "" {
+			return value
+		}
+		return "Chorus"
+	}
+
+	// Short forms are matched whole, "so" also starts unrelated directives
+	// such as "{sorttitle}"
+	var environment string
+	switch {
+	case name == "soc":
+		environment = "chorus"
+	case name == "sov":
+		environment = "verse"
+	case name == "sob":
+		environment = "bridge"
+	case strings.HasPrefix(name, "start_of_"):
+		environment = strings.TrimPrefix(name, "start_of_")
+	default:
+		return ""
+	}
+
+	if value != "" {
+		return value
+	}
+	switch environment {
+	case "chorus":
+		return "Chorus"
+	case "verse":
+		return "Verse"
+	case "bridge":
+		return "Bridge"
+	}
+	return ""
+}
+
+// Splits a line with inline chords into the chord line and the lyric line,
+// lyrics are padded when consecutive chords would otherwise overlap
+func splitInline(line string) (string, string) {
+	var chordLine, lyric []rune
+	cursor := 0
+	for _, match := range inlineRegex.FindAllStringSubmatchIndex(line, -1) {
+		symbol := strings.TrimSpace(line[match[2]:match[3]])
+		if !chords.IsChord(symbol) {
+			continue
+		}
+		lyric = append(lyric, []rune(line[cursor:match[0]])...)
+		cursor = match[1]
+
+		if len(chordLine) > 0 {
+			for len(lyric) <= len(chordLine) {
+				lyric = append(lyric, ' ')
+			}
+		}
+		for len(chordLine) < len(lyric) {
+			chordLine = append(chordLine, ' ')
+		}
+		chordLine = append(chordLine, []rune(symbol)...)
+	}
+	lyric = append(lyric, []rune(line[cursor:])...)
+	return strings.TrimRight(string(chordLine), " "), strings.TrimRight(string(lyric), " ")
+}
+
+func directive(line string) (string, string, bool) {
+	match := directiveRegex.FindStringSubmatch(line)
+	if match == nil {
+		return "", "", false
+	}
+	name := strings.ToLower(match[1])
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+	return name, strings.TrimSpace(match[2]), true
+}
+
+func (s *Song) setMeta(value string) bool {
+	name, content, found := strings.Cut(value, " ")
+	if !found {
+		return false
+	}
+	content = strings.TrimSpace(content)
+	switch strings.ToLower(name) {
+	case "title":
+		s.Title = content
+	case "subtitle":
+		s.Subtitle = content
+	case "artist":
+		s.Artist = content
+	case "composer":
+		s.Composer = content
+	case "key":
+		s.Key = content
+	case "tempo":
+		s.Tempo = content
+	case "time":
+		s.Time = content
+	case "duration":
+		s.Duration = content
+	case "capo":
+		s.Capo = content
+	default:
+		return false
+	}
+	return true
+}
+
+func writeDirective(builder *strings.Builder, name string, value string) {
+	if value == "" {
+		return
+	}
+	builder.WriteString("{" + name + ": " + value + "}\n")
+}
@@ -0,0 +1,165 @@
+package chordpro
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []*Song
+		wantErr bool
+	}{
+		{
+			name:    "metadata and body",
+			content: "{title: Hello}\n{artist: Someone}\n{key: G}\n# a comment\n\n[G]Hello [C]world  \n",
+			want:    []*Song{{Title: "Hello", Artist: "Someone", Key: "G", Body: "[G]Hello [C]world"}},
+		},
+		{
+			name:    "aliases and other directives kept in the body",
+			content: "{t: One}\n{st: Live}\n{soc}\n[C]La\n{eoc}",
+			want:    []*Song{{Title: "One", Subtitle: "Live", Body: "{soc}\n[C]La\n{eoc}"}},
+		},
+		{
+			name:    "several songs",
+			content: "{title: A}\nLa\n{new_song}\n{title: B}\nLe",
+			want:    []*Song{{Title: "A", Body: "La"}, {Title: "B", Body: "Le"}},
+		},
+		{
+			name:    "byte order mark and crlf",
+			content: "\ufeff{title: A}\r\nLa\r\n",
+			want:    []*Song{{Title: "A", Body: "La"}},
+		},
+		{name: "empty", content: "# only comments\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.content)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSerialize(t *testing.T) {
+	tests := []struct {
+		name string
+		song *Song
+		want string
+	}{
+		{
+			name: "empty metadata skipped",
+			song: &Song{Title: "Hello", Key: "G", Body: "G     C\nHello world"},
+			want: "{title: Hello}\n{key: G}\n\n[G]Hello [C]world\n",
+		},
+		{
+			name: "headings become comments",
+			song: &Song{Title: "A", Body: "[Chorus]\nC\nLa"},
+			want: "{title: A}\n\n{comment: Chorus}\n[C]La\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Serialize(tt.song); got != tt.want {
+				t.Errorf("Serialize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToInline(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "chords over lyrics", body: "C     G\nHello world", want: "[C]Hello [G]world"},
+		{name: "chords past the lyrics", body: "C      G    D\nHello", want: "[C]Hello  [G]     [D]"},
+		{name: "chord line alone", body: "C  G/B  Am", want: "[C] [G/B] [Am]"},
+		{name: "labelled chord line", body: "[Intro] C  G/B", want: "{comment: Intro}\n[C] [G/B]"},
+		{name: "headings", body: "[Verse 1]\nChorus:", want: "{comment: Verse 1}\n{comment: Chorus}"},
+		{name: "inline kept", body: "[C]Already inline", want: "[C]Already inline"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToInline(tt.body); got != tt.want {
+				t.Errorf("ToInline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromInline(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "inline chords", body: "[C]Hello [G]world", want: "C     G\nHello world"},
+		{name: "environments become headings", body: "{soc}\n[C]La\n{eoc}", want: "[Chorus]\nC\nLa"},
+		{name: "other directives dropped", body: "{sorttitle: A}\nLa", want: "La"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromInline(tt.body); got != tt.want {
+				t.Errorf("FromInline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeading(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"[Verse 1]", "Verse 1"},
+		{"Chorus:", "Chorus"},
+		{"Pre chorus part two:", ""},
+		{"[Am]", ""},
+		{"Hello world", ""},
+	}
+	for _, tt := range tests {
+		if got := Heading(tt.line); got != tt.want {
+			t.Errorf("Heading(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestSectionHeading(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"{comment: Bridge}", "Bridge"},
+		{"{c: Intro}", "Intro"},
+		{"{soc}", "Chorus"},
+		{"{sov}", "Verse"},
+		{"{sob}", "Bridge"},
+		{"{start_of_verse: Verse 2}", "Verse 2"},
+		{"{start_of_chorus}", "Chorus"},
+		{"{start_of_tab}", ""},
+		{"{chorus}", "Chorus"},
+		{"{sorttitle: Hello}", ""},
+		{"{songwriter: Someone}", ""},
+		{"{eoc}", ""},
+		{"Not a directive", ""},
+	}
+	for _, tt := range tests {
+		if got := SectionHeading(tt.line); got != tt.want {
+			t.Errorf("SectionHeading(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
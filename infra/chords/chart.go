@@ -0,0 +1,229 @@
+package chords
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	inlineChordRegex = regexp.MustCompile(`\[([^\]]+)\]`)
+	repeatRegex      = regexp.MustCompile(`^[xX]?\d+[xX]?$`)
+)
+
+// ChordMapper rewrites a single chord symbol found inside a chart
+type ChordMapper func(symbol string, chord *Chord) string
+
+// chordParser recognizes chord symbols, letter chords or Nashville numbers
+type chordParser func(symbol string) (*Chord, error)
+
+// MapChords applies the mapper to every chord of a chart body. Chords are
+// recognized both on chords-over-lyrics lines and as inline "[C]" markers,
+// column alignment of chord lines is preserved whenever possible.
+func MapChords(body string, mapper ChordMapper) string {
+	return mapChart(body, ParseChord, mapper)
+}
+
+func mapChart(body string, parse chordParser, mapper ChordMapper) string {
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		carriage := strings.HasSuffix(line, "\r")
+		line = strings.TrimSuffix(line, "\r")
+		line = mapLine(line, parse, mapper)
+		if carriage {
+			line += "\r"
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// IsDirective reports whether the line is a ChordPro style "{...}" directive
+func IsDirective(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}")
+}
+
+// HasInlineChords reports whether the line contains "[C]" style chords
+func HasInlineChords(line string) bool {
+	return hasInlineChords(line, ParseChord)
+}
+
+func hasInlineChords(line string, parse chordParser) bool {
+	for _, match := range inlineChordRegex.FindAllStringSubmatch(line, -1) {
+		if _, err := parse(strings.TrimSpace(match[1])); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// IsChordLine reports whether every token of the line is a chord, a bar
+// separator, a repeat mark or a leading label such as "Intro:" or "[Intro]"
+func IsChordLine(line string) bool {
+	return isChordLine(line, ParseChord)
+}
+
+func isChordLine(line string, parse chordParser) bool {
+	if IsDirective(line) || hasInlineChords(line, parse) {
+		return false
+	}
+	tokens := tokenize(line, parse)
+	chordCount := 0
+	for i, token := range tokens {
+		switch {
+		case token.chord != nil:
+			chordCount++
+		case token.core == "" || repeatRegex.MatchString(token.core) || token.core == "%" || token.core == "-" || token.core == "...":
+		case i == 0 && token.label:
+		default:
+			return false
+		}
+	}
+	return chordCount > 0
+}
+
+// ChordLineLabel splits the leading label off a chord line, returning it
+// along with the line where the label is blanked out so chords keep their
+// columns, e.g. "Intro" and "        C  G/B" for "[Intro] C  G/B"
+func ChordLineLabel(line string) (string, string) {
+	if !IsChordLine(line) {
+		return "", line
+	}
+	tokens := tokenize(line, ParseChord)
+	if !tokens[0].label {
+		return "", line
+	}
+	runes := []rune(line)
+	end := tokens[0].column + len([]rune(tokens[0].text))
+	rest := string(runes[:tokens[0].column]) + strings.Repeat(" ", end-tokens[0].column) + string(runes[end:])
+	return strings.TrimSpace(strings.TrimSuffix(tokens[0].core, ":")), rest
+}
+
+// Chords returns every chord symbol found in a chart body in reading order
+func Chords(body string) []string {
+	var symbols []string
+	MapChords(body, func(symbol string, chord *Chord) string {
+		symbols = append(symbols, symbol)
+		return symbol
+	})
+	return symbols
+}
+
+func mapLine(line string, parse chordParser, mapper ChordMapper) string {
+	if IsDirective(line) {
+		return line
+	}
+	if hasInlineChords(line, parse) {
+		return inlineChordRegex.ReplaceAllStringFunc(line, func(marker string) string {
+			symbol := strings.TrimSpace(marker[1 : len(marker)-1])
+			chord, err := parse(symbol)
+			if err != nil {
+				return marker
+			}
+			return "[" + mapper(symbol, chord) + "]"
+		})
+	}
+	if !isChordLine(line, parse) {
+		return line
+	}
+
+	var rebuilt []rune
+	for _, token := range tokenize(line, parse) {
+		text := token.text
+		if token.chord != nil {
+			text = token.prefix + mapper(token.core, token.chord) + token.suffix
+		}
+		if len(rebuilt) < token.column {
+			rebuilt = append(rebuilt, []rune(strings.Repeat(" ", token.column-len(rebuilt)))...)
+		} else if len(rebuilt) > 0 && !unicode.IsSpace(rebuilt[len(rebuilt)-1]) {
+			rebuilt = append(rebuilt, ' ')
+		}
+		rebuilt = append(rebuilt, []rune(text)...)
+	}
+	return string(rebuilt)
+}
+
+type chartToken struct {
+	column int
+	text   string
+	prefix string
+	core   string
+	suffix string
+	chord  *Chord
+	label  bool // Leading "Intro:" or "[Intro]", never read as chords
+}
+
+func tokenize(line string, parse chordParser) []chartToken {
+	var tokens []chartToken
+	runes := []rune(line)
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+		start := i
+		// A bracketed label may hold spaces, e.g. "[Pre Chorus]"
+		if len(tokens) == 0 && runes[i] == '[' {
+			if end := strings.IndexRune(string(runes[i:]), ']'); end > 0 {
+				end = i + len([]rune(string(runes[i:])[:end]))
+				core := string(runes[i+1 : end])
+				if _, err := parse(strings.TrimSpace(core)); err != nil {
+					i = end + 1
+					tokens = append(tokens, chartToken{
+						column: start,
+						text:   string(runes[start:i]),
+						prefix: "[",
+						core:   core,
+						suffix: "]",
+						label:  true,
+					})
+					continue
+				}
+			}
+		}
+		for i < len(runes) && !unicode.IsSpace(runes[i]) {
+			i++
+		}
+		text := string(runes[start:i])
+		core := strings.TrimLeft(text, "(|[")
+		prefix := text[:len(text)-len(core)]
+		trimmedCore := strings.TrimRight(core, ")|]")
+		suffix := core[len(trimmedCore):]
+		token := chartToken{
+			column: start,
+			text:   text,
+			prefix: prefix,
+			core:   trimmedCore,
+			suffix: suffix,
+		}
+		if chord, err := parse(trimmedCore); err == nil {
+			token.chord = chord
+		} else if len(tokens) == 0 && strings.HasSuffix(trimmedCore, ":") {
+			token.label = true
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// Lyrics strips every chord, directive and chord line from a chart body,
+// leaving only the sung text
+func Lyrics(body string) string {
+	var lyrics []string
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		if IsDirective(line) || IsChordLine(line) {
+			continue
+		}
+		if HasInlineChords(line) {
+			line = inlineChordRegex.ReplaceAllStringFunc(line, func(marker string) string {
+				if IsChord(strings.TrimSpace(marker[1 : len(marker)-1])) {
+					return ""
+				}
+				return marker
+			})
+		}
+		lyrics = append(lyrics, strings.TrimRight(line, " \t"))
+	}
+	return strings.TrimSpace(strings.Join(lyrics, "\n"))
+}
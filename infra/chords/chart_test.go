@@ -0,0 +1,91 @@
+package chords
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsChordLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{"C  G/B  Am7  F", true},
+		{"| C | G | Am | F |", true},
+		{"(C)  G  x2", true},
+		{"Intro: C G", true},
+		{"[Intro] C  G/B  Am7  F", true},
+		{"[Pre Chorus]  Dm  F", true},
+		{"A long time ago", false},
+		{"[Intro]", false},
+		{"Hello [C]world", false},
+		{"{title: Song}", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsChordLine(tt.line); got != tt.want {
+			t.Errorf("IsChordLine(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestChordLineLabel(t *testing.T) {
+	tests := []struct {
+		line      string
+		wantLabel string
+		wantRest  string
+	}{
+		{"[Intro] C  G/B", "Intro", "        C  G/B"},
+		{"Intro: C G", "Intro", "       C G"},
+		{"  [Pre Chorus] Dm", "Pre Chorus", "               Dm"},
+		{"C  G", "", "C  G"},
+		{"Just lyrics", "", "Just lyrics"},
+	}
+	for _, tt := range tests {
+		label, rest := ChordLineLabel(tt.line)
+		if label != tt.wantLabel || rest != tt.wantRest {
+			t.Errorf("ChordLineLabel(%q) = %q, %q, want %q, %q", tt.line, label, rest, tt.wantLabel, tt.wantRest)
+		}
+	}
+}
+
+func TestChords(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{name: "chords over lyrics", body: "C     G\nHello world", want: []string{"C", "G"}},
+		{name: "inline", body: "[Am]Hello [F]world", want: []string{"Am", "F"}},
+		{name: "labelled line", body: "[Intro] C  G/B", want: []string{"C", "G/B"}},
+		{name: "directives ignored", body: "{key: C}\n[Verse]\nNo chords", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Chords(tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chords() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLyrics(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "chords over lyrics", body: "C     G\nHello world", want: "Hello world"},
+		{name: "inline", body: "[Am]Hello [F]world", want: "Hello world"},
+		{name: "labelled chord line", body: "[Intro] C G\nSing", want: "Sing"},
+		{name: "headings kept", body: "[Verse]\nC\nSing", want: "[Verse]\nSing"},
+		{name: "directives dropped", body: "{title: Song}\nSing", want: "Sing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Lyrics(tt.body); got != tt.want {
+				t.Errorf("Lyrics() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
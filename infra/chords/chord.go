@@ -0,0 +1,95 @@
+package chords
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	sharpNames = [12]string{"C", "C#", "D", "D#", "E", "F", "F#", "G", "G#", "A", "A#", "B"}
+	flatNames  = [12]string{"C", "Db", "D", "Eb", "E", "F", "Gb", "G", "Ab", "A", "Bb", "B"}
+	naturals   = map[byte]int{'C': 0, 'D': 2, 'E': 4, 'F': 5, 'G': 7, 'A': 9, 'B': 11}
+	chordRegex = regexp.MustCompile(`^([A-G])([#b]?)((?:maj|min|dim|aug|sus|add|alt|m|M|º|°|ø|\+|-|\d|b|#|\(|\)|,)*)(?:/([A-G])([#b]?))?$`)
+)
+
+// Chord is a parsed chord symbol such as "F#m7b5/E"
+type Chord struct {
+	Root    int    // Pitch class from 0 (C) to 11 (B)
+	Quality string // Everything between the root and the bass note, e.g. "m7b5"
+	Bass    int    // Pitch class of the slash bass note, -1 when absent
+}
+
+// ParseChord parses a chord symbol into its root, quality and bass
+func ParseChord(s string) (*Chord, error) {
+	match := chordRegex.FindStringSubmatch(s)
+	if match == nil {
+		return nil, fmt.Errorf("invalid chord %q", s)
+	}
+	chord := &Chord{
+		Root:    pitchClass(match[1], match[2]),
+		Quality: match[3],
+		Bass:    -1,
+	}
+	if match[4] != "" {
+		chord.Bass = pitchClass(match[4], match[5])
+	}
+	return chord, nil
+}
+
+// IsChord reports whether s is a valid chord symbol
+func IsChord(s string) bool {
+	return chordRegex.MatchString(s)
+}
+
+// Transpose returns a copy of the chord shifted by the given semitones
+func (c Chord) Transpose(semitones int) Chord {
+	transposed := Chord{
+		Root:    mod12(c.Root + semitones),
+		Quality: c.Quality,
+		Bass:    -1,
+	}
+	if c.Bass >= 0 {
+		transposed.Bass = mod12(c.Bass + semitones)
+	}
+	return transposed
+}
+
+// IsMinor reports whether the chord quality describes a minor triad
+func (c Chord) IsMinor() bool {
+	q := c.Quality
+	return strings.HasPrefix(q, "m") && !strings.HasPrefix(q, "maj") ||
+		strings.HasPrefix(q, "min") || strings.HasPrefix(q, "-")
+}
+
+// Format renders the chord using flats or sharps for accidentals
+func (c Chord) Format(flats bool) string {
+	name := NoteName(c.Root, flats) + c.Quality
+	if c.Bass >= 0 {
+		name += "/" + NoteName(c.Bass, flats)
+	}
+	return name
+}
+
+// NoteName returns the name of a pitch class spelled with flats or sharps
+func NoteName(pitch int, flats bool) string {
+	if flats {
+		return flatNames[mod12(pitch)]
+	}
+	return sharpNames[mod12(pitch)]
+}
+
+func pitchClass(letter string, accidental string) int {
+	pitch := naturals[letter[0]]
+	switch accidental {
+	case "#":
+		pitch++
+	case "b":
+		pitch--
+	}
+	return mod12(pitch)
+}
+
+func mod12(n int) int {
+	return ((n % 12) + 12) % 12
+}
@@ -0,0 +1,87 @@
+package chords
+
+import "testing"
+
+func TestParseChord(t *testing.T) {
+	tests := []struct {
+		symbol  string
+		want    Chord
+		wantErr bool
+	}{
+		{symbol: "C", want: Chord{Root: 0, Bass: -1}},
+		{symbol: "F#m7b5/E", want: Chord{Root: 6, Quality: "m7b5", Bass: 4}},
+		{symbol: "Bbmaj7", want: Chord{Root: 10, Quality: "maj7", Bass: -1}},
+		{symbol: "G/B", want: Chord{Root: 7, Bass: 11}},
+		{symbol: "Asus4", want: Chord{Root: 9, Quality: "sus4", Bass: -1}},
+		{symbol: "Cb", want: Chord{Root: 11, Bass: -1}},
+		{symbol: "H", wantErr: true},
+		{symbol: "Intro", wantErr: true},
+		{symbol: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			got, err := ParseChord(tt.symbol)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseChord(%q) = %+v, want an error", tt.symbol, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != tt.want {
+				t.Errorf("ParseChord(%q) = %+v, want %+v", tt.symbol, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChordTranspose(t *testing.T) {
+	tests := []struct {
+		symbol    string
+		semitones int
+		flats     bool
+		want      string
+	}{
+		{"C", 2, false, "D"},
+		{"Am7", 3, false, "Cm7"},
+		{"G/B", 1, true, "Ab/C"},
+		{"G/B", 1, false, "G#/C"},
+		{"D", -3, true, "B"},
+		{"E", 12, false, "E"},
+		{"F#m7b5/E", -1, false, "Fm7b5/D#"},
+	}
+	for _, tt := range tests {
+		chord, err := ParseChord(tt.symbol)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := chord.Transpose(tt.semitones).Format(tt.flats); got != tt.want {
+			t.Errorf("%s transposed by %d = %q, want %q", tt.symbol, tt.semitones, got, tt.want)
+		}
+	}
+}
+
+func TestChordIsMinor(t *testing.T) {
+	tests := []struct {
+		symbol string
+		want   bool
+	}{
+		{"Am", true},
+		{"Amin7", true},
+		{"A-7", true},
+		{"Amaj7", false},
+		{"A", false},
+		{"A7", false},
+	}
+	for _, tt := range tests {
+		chord, err := ParseChord(tt.symbol)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := chord.IsMinor(); got != tt.want {
+			t.Errorf("IsMinor(%q) = %v, want %v", tt.symbol, got, tt.want)
+		}
+	}
+}
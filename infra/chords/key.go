@@ -0,0 +1,115 @@
+package chords
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	keyRegex      = regexp.MustCompile(`^([A-Ga-g])\s*([#b♯♭]?)\s*(m|min|minor|menor|-|maj|major|maior|M)?$`)
+	latinKeyRegex = regexp.MustCompile(`^(do|re|mi|fa|sol|la|si)\s*(#|b|♯|♭|sustenido|bemol)?\s*(m|min|minor|menor|maj|major|maior)?$`)
+)
+
+// Solfège note names used in portuguese, spanish and italian charts
+var latinNotes = map[string]string{
+	"do":  "C",
+	"re":  "D",
+	"mi":  "E",
+	"fa":  "F",
+	"sol": "G",
+	"la":  "A",
+	"si":  "B",
+}
+
+var foldAccents = strings.NewReplacer("á", "a", "é", "e", "ó", "o", "Á", "a", "É", "e", "Ó", "o")
+
+// Key is a tonal center, e.g. "Bb" or "F#m"
+type Key struct {
+	Root  int
+	Minor bool
+}
+
+// ParseKey parses key names like "G", "Bb", "F#m", "C minor" or "Eb major",
+// as well as latin names like "Ré menor", "Sib" or "Fá sustenido maior"
+func ParseKey(s string) (*Key, error) {
+	trimmed := strings.TrimSpace(s)
+	if match := keyRegex.FindStringSubmatch(trimmed); match != nil {
+		return newKey(strings.ToUpper(match[1]), match[2], match[3]), nil
+	}
+
+	latin := foldAccents.Replace(strings.ToLower(trimmed))
+	if match := latinKeyRegex.FindStringSubmatch(latin); match != nil {
+		return newKey(latinNotes[match[1]], match[2], match[3]), nil
+	}
+
+	return nil, fmt.Errorf("invalid key %q", s)
+}
+
+// NormalizeKey returns the canonical spelling of a key, e.g. "Ré menor" is "Dm"
+func NormalizeKey(s string) (string, error) {
+	key, err := ParseKey(s)
+	if err != nil {
+		return "", err
+	}
+	return key.String(), nil
+}
+
+func newKey(letter string, accidental string, quality string) *Key {
+	switch accidental {
+	case "♯", "sustenido":
+		accidental = "#"
+	case "♭", "bemol":
+		accidental = "b"
+	}
+	switch quality {
+	case "m", "min", "minor", "menor", "-":
+		return &Key{Root: pitchClass(letter, accidental), Minor: true}
+	}
+	return &Key{Root: pitchClass(letter, accidental)}
+}
+
+// PrefersFlats reports whether the key signature of the key is written with flats
+func (k Key) PrefersFlats() bool {
+	major := k.Root
+	if k.Minor {
+		major = mod12(k.Root + 3)
+	}
+	switch major {
+	case 1, 3, 5, 8, 10:
+		return true
+	}
+	return false
+}
+
+// Transpose returns a copy of the key shifted by the given semitones
+func (k Key) Transpose(semitones int) Key {
+	return Key{Root: mod12(k.Root + semitones), Minor: k.Minor}
+}
+
+// String renders the key in its conventional spelling, e.g. "Eb" or "C#m"
+func (k Key) String() string {
+	name := NoteName(k.Root, k.PrefersFlats())
+	if k.Minor {
+		name += "m"
+	}
+	return name
+}
+
+// Index orders keys chromatically from C, each major key before its parallel minor
+func (k Key) Index() int {
+	index := k.Root * 2
+	if k.Minor {
+		index++
+	}
+	return index
+}
+
+// SemitonesBetween returns the shortest shift that moves from one key to another
+func SemitonesBetween(from Key, to Key) int {
+	shift := mod12(to.Root - from.Root)
+	if shift > 6 {
+		shift -= 12
+	}
+	return shift
+}
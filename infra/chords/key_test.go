@@ -0,0 +1,59 @@
+package chords
+
+import "testing"
+
+func TestNormalizeKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{key: "G", want: "G"},
+		{key: "bb", want: "Bb"},
+		{key: "F# m", want: "F#m"},
+		{key: "C minor", want: "Cm"},
+		{key: "Eb major", want: "Eb"},
+		{key: "A#", want: "Bb"},
+		{key: "D#m", want: "D#m"},
+		{key: "H", wantErr: true},
+		{key: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := NormalizeKey(tt.key)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NormalizeKey(%q) = %q, want an error", tt.key, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeKey(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemitonesBetween(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		want int
+	}{
+		{"C", "D", 2},
+		{"C", "C", 0},
+		{"A", "C", 3},
+		{"G", "E", -3},
+		{"Am", "Em", -5},
+	}
+	for _, tt := range tests {
+		from, _ := ParseKey(tt.from)
+		to, _ := ParseKey(tt.to)
+		if got := SemitonesBetween(*from, *to); got != tt.want {
+			t.Errorf("SemitonesBetween(%s, %s) = %d, want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
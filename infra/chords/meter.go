@@ -0,0 +1,61 @@
+package chords
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	timeSignatureRegex = regexp.MustCompile(`^(\d{1,2})\s*/\s*(\d{1,2})$`)
+	durationRegex      = regexp.MustCompile(`^(?:(\d+):)?(\d{1,2}):(\d{2})$`)
+)
+
+// NormalizeTimeSignature validates a time signature like "4/4" or "6/8",
+// the lower number has to be a note value (a power of two up to 32)
+func NormalizeTimeSignature(s string) (string, error) {
+	match := timeSignatureRegex.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return "", fmt.Errorf("invalid time signature %q", s)
+	}
+	beats, _ := strconv.Atoi(match[1])
+	value, _ := strconv.Atoi(match[2])
+	if beats < 1 || beats > 32 {
+		return "", fmt.Errorf("invalid time signature %q", s)
+	}
+	switch value {
+	case 1, 2, 4, 8, 16, 32:
+	default:
+		return "", fmt.Errorf("invalid time signature %q", s)
+	}
+	return fmt.Sprintf("%d/%d", beats, value), nil
+}
+
+// ParseDuration reads a song length as "3:45", "1:02:30" or plain seconds
+func ParseDuration(s string) (int, error) {
+	trimmed := strings.TrimSpace(s)
+	if seconds, err := strconv.Atoi(trimmed); err == nil && seconds > 0 {
+		return seconds, nil
+	}
+
+	match := durationRegex.FindStringSubmatch(trimmed)
+	if match == nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	hours := 0
+	if match[1] != "" {
+		hours, _ = strconv.Atoi(match[1])
+	}
+	minutes, _ := strconv.Atoi(match[2])
+	seconds, _ := strconv.Atoi(match[3])
+	if seconds > 59 || (match[1] != "" && minutes > 59) {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return hours*3600 + minutes*60 + seconds, nil
+}
+
+// FormatDuration renders seconds as "m:ss", the form ParseDuration reads back
+func FormatDuration(seconds int) string {
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
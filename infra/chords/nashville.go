@@ -0,0 +1,103 @@
+package chords
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var numberRegex = regexp.MustCompile(`^([#b♯♭]?)([1-7])((?:maj|min|dim|aug|sus|add|alt|m|M|º|°|ø|\+|-|\d|b|#|\(|\)|,)*)(?:/([#b♯♭]?)([1-7]))?$`)
+
+// Semitones above the tonic of every scale degree, minor keys are numbered
+// after the natural minor scale so the relative major of Am is "3", not "b3"
+var (
+	majorScale = [7]int{0, 2, 4, 5, 7, 9, 11}
+	minorScale = [7]int{0, 2, 3, 5, 7, 8, 10}
+)
+
+// Degree written for every semitone above the tonic, notes outside of the
+// scale are spelled as altered degrees such as "b7" or "#4"
+var (
+	majorDegrees = [12]string{"1", "b2", "2", "b3", "3", "4", "#4", "5", "b6", "6", "b7", "7"}
+	minorDegrees = [12]string{"1", "b2", "2", "3", "#3", "4", "b5", "5", "6", "#6", "7", "#7"}
+)
+
+// ToNashville rewrites every chord of a chart body as a scale degree of the
+// key, e.g. "Am7 D/F# G" in G is "2m7 5/7 1". Qualities are kept as written,
+// borrowed chords get an accidental such as "b7".
+func ToNashville(body string, key Key) string {
+	return MapChords(body, func(symbol string, chord *Chord) string {
+		number := degreeName(chord.Root, key) + chord.Quality
+		if chord.Bass >= 0 {
+			number += "/" + degreeName(chord.Bass, key)
+		}
+		return number
+	})
+}
+
+// FromNashville rewrites a chart written in Nashville numbers into letter
+// chords of the given key. Notes are spelled after the key signature, unless
+// the number itself is altered, so "b3" in G is "Bb" and not "A#".
+func FromNashville(body string, key Key) string {
+	return mapChart(body, numberParser(key), func(symbol string, chord *Chord) string {
+		match := numberRegex.FindStringSubmatch(symbol)
+		name := NoteName(chord.Root, spellFlat(match[1], key)) + chord.Quality
+		if chord.Bass >= 0 {
+			name += "/" + NoteName(chord.Bass, spellFlat(match[4], key))
+		}
+		return name
+	})
+}
+
+// Parses numbers into the chords they stand for in the key
+func numberParser(key Key) chordParser {
+	return func(s string) (*Chord, error) {
+		match := numberRegex.FindStringSubmatch(s)
+		if match == nil {
+			return nil, fmt.Errorf("invalid nashville number %q", s)
+		}
+		chord := &Chord{
+			Root:    degreePitch(match[1], match[2], key),
+			Quality: match[3],
+			Bass:    -1,
+		}
+		if match[5] != "" {
+			chord.Bass = degreePitch(match[4], match[5], key)
+		}
+		return chord, nil
+	}
+}
+
+func spellFlat(accidental string, key Key) bool {
+	switch accidental {
+	case "b", "♭":
+		return true
+	case "#", "♯":
+		return false
+	}
+	return key.PrefersFlats()
+}
+
+func degreeName(pitch int, key Key) string {
+	interval := mod12(pitch - key.Root)
+	if key.Minor {
+		return minorDegrees[interval]
+	}
+	return majorDegrees[interval]
+}
+
+func degreePitch(accidental string, degree string, key Key) int {
+	n, _ := strconv.Atoi(degree)
+	scale := majorScale
+	if key.Minor {
+		scale = minorScale
+	}
+	pitch := key.Root + scale[n-1]
+	switch accidental {
+	case "#", "♯":
+		pitch++
+	case "b", "♭":
+		pitch--
+	}
+	return mod12(pitch)
+}
@@ -0,0 +1,40 @@
+package chords
+
+import "fmt"
+
+// TransposeBody shifts every chord of a chart body by the given semitones
+func TransposeBody(body string, semitones int, flats bool) string {
+	return MapChords(body, func(symbol string, chord *Chord) string {
+		return chord.Transpose(semitones).Format(flats)
+	})
+}
+
+// TransposeSong shifts a chart and its tone either by a number of semitones
+// or, when target is not empty, into the target key, which must share the
+// mode of the tone. Accidentals are spelled according to the resulting key
+// signature.
+func TransposeSong(body string, tone string, semitones int, target string) (string, string, error) {
+	key, keyErr := ParseKey(tone)
+
+	if target != "" {
+		targetKey, err := ParseKey(target)
+		if err != nil {
+			return "", "", err
+		}
+		if keyErr != nil {
+			return "", "", fmt.Errorf("song tone %q is not a valid key", tone)
+		}
+		// Transposing keeps the mode, so "Am" can move to "Bm" but never to "C"
+		if targetKey.Minor != key.Minor {
+			return "", "", fmt.Errorf("target key %q does not match the mode of the song tone %q", target, tone)
+		}
+		semitones = SemitonesBetween(*key, *targetKey)
+	}
+
+	if keyErr != nil {
+		return TransposeBody(body, semitones, false), tone, nil
+	}
+
+	newKey := key.Transpose(semitones)
+	return TransposeBody(body, semitones, newKey.PrefersFlats()), newKey.String(), nil
+}
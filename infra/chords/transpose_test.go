@@ -0,0 +1,65 @@
+package chords
+
+import "testing"
+
+func TestTransposeBody(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		semitones int
+		flats     bool
+		want      string
+	}{
+		{name: "keeps columns", body: "C     G\nHello world", semitones: 2, want: "D     A\nHello world"},
+		{name: "longer symbols push on", body: "C G\nHi", semitones: 1, want: "C# G#\nHi"},
+		{name: "inline", body: "[Am]Hello [F]world", semitones: -2, flats: true, want: "[Gm]Hello [Eb]world"},
+		{name: "labelled line", body: "[Intro] C  G/B", semitones: 2, want: "[Intro] D  A/C#"},
+		{name: "lyrics untouched", body: "A day in the life", semitones: 5, want: "A day in the life"},
+		{name: "carriage returns", body: "C\r\nSing", semitones: 7, want: "G\r\nSing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TransposeBody(tt.body, tt.semitones, tt.flats); got != tt.want {
+				t.Errorf("TransposeBody() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransposeSong(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		tone      string
+		semitones int
+		target    string
+		wantBody  string
+		wantTone  string
+		wantErr   bool
+	}{
+		{name: "semitones", body: "C G", tone: "C", semitones: 2, wantBody: "D A", wantTone: "D"},
+		{name: "flat key signature", body: "C F", tone: "C", semitones: 3, wantBody: "Eb Ab", wantTone: "Eb"},
+		{name: "target key", body: "Am E", tone: "Am", target: "Bm", wantBody: "Bm F#", wantTone: "Bm"},
+		{name: "target of the other mode", body: "Am E", tone: "Am", target: "C", wantErr: true},
+		{name: "invalid target", body: "C", tone: "C", target: "X", wantErr: true},
+		{name: "invalid tone with target", body: "C", tone: "?", target: "D", wantErr: true},
+		{name: "invalid tone kept", body: "C", tone: "?", semitones: 2, wantBody: "D", wantTone: "?"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, tone, err := TransposeSong(tt.body, tt.tone, tt.semitones, tt.target)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if body != tt.wantBody || tone != tt.wantTone {
+				t.Errorf("TransposeSong() = %q, %q, want %q, %q", body, tone, tt.wantBody, tt.wantTone)
+			}
+		})
+	}
+}
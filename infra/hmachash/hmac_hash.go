@@ -4,7 +4,6 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
-	"hash"
 )
 
 type HMAC interface {
@@ -12,19 +11,19 @@ type HMAC interface {
 }
 
 type hm struct {
-	hmac hash.Hash
+	key []byte
 }
 
 func NewHMAC(key string) hm {
-	h := hmac.New(sha256.New, []byte(key))
 	return hm{
-		hmac: h,
+		key: []byte(key),
 	}
 }
 
+// A new hash is used per call, so concurrent requests can share the HMAC
 func (h hm) Hash(input string) string {
-	h.hmac.Reset()
-	h.hmac.Write([]byte(input))
-	hashedData := h.hmac.Sum(nil)
+	mac := hmac.New(sha256.New, h.key)
+	mac.Write([]byte(input))
+	hashedData := mac.Sum(nil)
 	return base64.URLEncoding.EncodeToString(hashedData)
 }
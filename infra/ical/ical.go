@@ -0,0 +1,242 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	// Zone data is embedded so feeds keep their time zones on hosts without it
+	_ "time/tzdata"
+)
+
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+
+	productID = "-//Playliter//Concerts//EN"
+	lineLimit = 75 // Octets per content line, longer lines are folded
+
+	dateTimeFormat = "20060102T150405"
+)
+
+// Calendar is a published feed of events
+type Calendar struct {
+	Name      string
+	Events    []Event
+	Generated time.Time // Written as the DTSTAMP of every event
+}
+
+// Event is a timed event of the feed. Clients match events by UID, so it
+// must never change, and keep the one with the highest Sequence.
+type Event struct {
+	UID         string
+	Sequence    int
+	Status      string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	Duration    time.Duration
+	TimeZone    *time.Location // Written as UTC when nil
+	Modified    time.Time
+}
+
+// Marshal writes the calendar as an iCalendar (RFC 5545) document, with a
+// VTIMEZONE for every zone its events are written in
+func Marshal(c *Calendar) []byte {
+	var b bytes.Buffer
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:"+productID)
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	if c.Name != "" {
+		writeLine(&b, "X-WR-CALNAME:"+escape(c.Name))
+	}
+
+	for _, zone := range zonesOf(c.Events) {
+		writeTimeZone(&b, zone.location, zone.from, zone.to)
+	}
+
+	for _, event := range c.Events {
+		end := event.Start.Add(event.Duration)
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+escape(event.UID))
+		writeLine(&b, "DTSTAMP:"+utc(c.Generated))
+		writeLine(&b, "LAST-MODIFIED:"+utc(event.Modified))
+		writeLine(&b, fmt.Sprintf("SEQUENCE:%d", event.Sequence))
+		writeLine(&b, "STATUS:"+event.Status)
+		writeLine(&b, "DTSTART"+dateTime(event.Start, event.TimeZone))
+		writeLine(&b, "DTEND"+dateTime(end, event.TimeZone))
+		writeLine(&b, "SUMMARY:"+escape(event.Summary))
+		if event.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escape(event.Description))
+		}
+		if event.Location != "" {
+			writeLine(&b, "LOCATION:"+escape(event.Location))
+		}
+		writeLine(&b, "END:VEVENT")
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+	return b.Bytes()
+}
+
+// Zone used by the events and the period they span
+type zoneRange struct {
+	location *time.Location
+	from     time.Time
+	to       time.Time
+}
+
+func zonesOf(events []Event) []*zoneRange {
+	byName := map[string]*zoneRange{}
+	for _, event := range events {
+		if isUTC(event.TimeZone) {
+			continue
+		}
+		end := event.Start.Add(event.Duration)
+		zone, ok := byName[event.TimeZone.String()]
+		if !ok {
+			byName[event.TimeZone.String()] = &zoneRange{location: event.TimeZone, from: event.Start, to: end}
+			continue
+		}
+		if event.Start.Before(zone.from) {
+			zone.from = event.Start
+		}
+		if end.After(zone.to) {
+			zone.to = end
+		}
+	}
+
+	var zones []*zoneRange
+	for _, zone := range byName {
+		zones = append(zones, zone)
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].location.String() < zones[j].location.String()
+	})
+	return zones
+}
+
+// Writes the zone with the offset in effect at "from" and every transition
+// up to "to", which is all the events written in it need
+func writeTimeZone(b *bytes.Buffer, loc *time.Location, from time.Time, to time.Time) {
+	writeLine(b, "BEGIN:VTIMEZONE")
+	writeLine(b, "TZID:"+loc.String())
+
+	start := from.In(loc)
+	name, offset := start.Zone()
+	writeObservance(b, start.IsDST(), time.Date(1970, 1, 1, 0, 0, 0, 0, time.FixedZone("", offset)), offset, offset, name)
+
+	for _, transition := range transitions(loc, from, to) {
+		at := transition.In(loc)
+		name, next := at.Zone()
+		writeObservance(b, at.IsDST(), transition, offset, next, name)
+		offset = next
+	}
+
+	writeLine(b, "END:VTIMEZONE")
+}
+
+// The onset is written as the local time before the transition
+func writeObservance(b *bytes.Buffer, dst bool, onset time.Time, offsetFrom int, offsetTo int, name string) {
+	kind := "STANDARD"
+	if dst {
+		kind = "DAYLIGHT"
+	}
+	writeLine(b, "BEGIN:"+kind)
+	writeLine(b, "DTSTART:"+onset.In(time.FixedZone("", offsetFrom)).Format(dateTimeFormat))
+	writeLine(b, "TZOFFSETFROM:"+utcOffset(offsetFrom))
+	writeLine(b, "TZOFFSETTO:"+utcOffset(offsetTo))
+	// Zones without an abbreviation are named by their offset, e.g. "-03"
+	if name != "" && !strings.ContainsAny(name[:1], "+-") {
+		writeLine(b, "TZNAME:"+escape(name))
+	}
+	writeLine(b, "END:"+kind)
+}
+
+// Instants the zone offset changes between from and to. Days are compared
+// first and the changed ones are searched to the second.
+func transitions(loc *time.Location, from time.Time, to time.Time) []time.Time {
+	var result []time.Time
+	_, offset := from.In(loc).Zone()
+	for day := from.Unix(); day < to.Unix(); day += 24 * 60 * 60 {
+		next := day + 24*60*60
+		if _, nextOffset := time.Unix(next, 0).In(loc).Zone(); nextOffset == offset {
+			continue
+		}
+		low, high := day, next
+		for high-low > 1 {
+			middle := low + (high-low)/2
+			if _, o := time.Unix(middle, 0).In(loc).Zone(); o == offset {
+				low = middle
+			} else {
+				high = middle
+			}
+		}
+		result = append(result, time.Unix(high, 0).UTC())
+		_, offset = time.Unix(high, 0).In(loc).Zone()
+	}
+	return result
+}
+
+// Value of a DTSTART or DTEND property, with its TZID parameter
+func dateTime(t time.Time, loc *time.Location) string {
+	if isUTC(loc) {
+		return ":" + utc(t)
+	}
+	return ";TZID=" + loc.String() + ":" + t.In(loc).Format(dateTimeFormat)
+}
+
+func utc(t time.Time) string {
+	return t.UTC().Format(dateTimeFormat) + "Z"
+}
+
+func isUTC(loc *time.Location) bool {
+	return loc == nil || loc.String() == "UTC"
+}
+
+// Formats an offset in seconds as "-0300", seconds are only written when set
+func utcOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	result := fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset%3600/60)
+	if seconds := offset % 60; seconds != 0 {
+		result += fmt.Sprintf("%02d", seconds)
+	}
+	return result
+}
+
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(s)
+}
+
+// Writes a content line folded at the octet limit, never inside a character
+func writeLine(b *bytes.Buffer, line string) {
+	limit := lineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// Continuation lines start with the folding space
+		limit = lineLimit - 1
+	}
+	b.WriteString(line + "\r\n")
+}
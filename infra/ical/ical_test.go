@@ -0,0 +1,224 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestMarshal(t *testing.T) {
+	calendar := &Calendar{
+		Name:      "Band, live",
+		Generated: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Events: []Event{
+			{
+				UID:         "concert-1@playliter",
+				Sequence:    2,
+				Status:      StatusConfirmed,
+				Summary:     "Release show; night one",
+				Description: "Doors at 7\nBring earplugs",
+				Location:    `Main St. 10, Hall "A"`,
+				Start:       time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC),
+				Duration:    2 * time.Hour,
+				Modified:    time.Date(2024, 4, 30, 10, 0, 0, 0, time.UTC),
+			},
+			{
+				UID:      "concert-2@playliter",
+				Status:   StatusCancelled,
+				Summary:  "Festival",
+				Start:    time.Date(2024, 7, 1, 18, 30, 0, 0, time.UTC),
+				Duration: time.Hour,
+				Modified: time.Date(2024, 4, 30, 10, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Playliter//Concerts//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		`X-WR-CALNAME:Band\, live`,
+		"BEGIN:VEVENT",
+		"UID:concert-1@playliter",
+		"DTSTAMP:20240501T120000Z",
+		"LAST-MODIFIED:20240430T100000Z",
+		"SEQUENCE:2",
+		"STATUS:CONFIRMED",
+		"DTSTART:20240601T200000Z",
+		"DTEND:20240601T220000Z",
+		`SUMMARY:Release show\; night one`,
+		`DESCRIPTION:Doors at 7\nBring earplugs`,
+		`LOCATION:Main St. 10\, Hall "A"`,
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:concert-2@playliter",
+		"DTSTAMP:20240501T120000Z",
+		"LAST-MODIFIED:20240430T100000Z",
+		"SEQUENCE:0",
+		"STATUS:CANCELLED",
+		"DTSTART:20240701T183000Z",
+		"DTEND:20240701T193000Z",
+		"SUMMARY:Festival",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n") + "\r\n"
+
+	if got := string(Marshal(calendar)); got != want {
+		t.Errorf("Marshal = %q, want %q", got, want)
+	}
+}
+
+func TestMarshalTimeZones(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		events   []Event
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "single offset",
+			events: []Event{
+				{UID: "1", Start: time.Date(2024, 6, 1, 20, 0, 0, 0, saoPaulo), Duration: time.Hour, TimeZone: saoPaulo},
+			},
+			contains: []string{
+				"BEGIN:VTIMEZONE\r\nTZID:America/Sao_Paulo\r\nBEGIN:STANDARD\r\nDTSTART:19700101T000000\r\nTZOFFSETFROM:-0300\r\nTZOFFSETTO:-0300\r\nEND:STANDARD\r\nEND:VTIMEZONE\r\n",
+				"DTSTART;TZID=America/Sao_Paulo:20240601T200000\r\n",
+				"DTEND;TZID=America/Sao_Paulo:20240601T210000\r\n",
+			},
+			excludes: []string{"TZNAME:-03"},
+		},
+		{
+			name: "events across a daylight saving change",
+			events: []Event{
+				{UID: "1", Start: time.Date(2024, 3, 1, 20, 0, 0, 0, newYork), Duration: time.Hour, TimeZone: newYork},
+				{UID: "2", Start: time.Date(2024, 3, 20, 20, 0, 0, 0, newYork), Duration: time.Hour, TimeZone: newYork},
+			},
+			contains: []string{
+				"BEGIN:STANDARD\r\nDTSTART:19700101T000000\r\nTZOFFSETFROM:-0500\r\nTZOFFSETTO:-0500\r\nTZNAME:EST\r\nEND:STANDARD\r\n",
+				"BEGIN:DAYLIGHT\r\nDTSTART:20240310T020000\r\nTZOFFSETFROM:-0500\r\nTZOFFSETTO:-0400\r\nTZNAME:EDT\r\nEND:DAYLIGHT\r\n",
+				"DTSTART;TZID=America/New_York:20240320T200000\r\n",
+			},
+			excludes: []string{"DTSTART:20241103"},
+		},
+		{
+			name: "utc events have no zone",
+			events: []Event{
+				{UID: "1", Start: time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC), Duration: time.Hour, TimeZone: time.UTC},
+			},
+			contains: []string{"DTSTART:20240601T200000Z\r\n"},
+			excludes: []string{"BEGIN:VTIMEZONE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(Marshal(&Calendar{Events: tt.events}))
+			for _, fragment := range tt.contains {
+				if !strings.Contains(got, fragment) {
+					t.Errorf("Marshal = %q, want it to contain %q", got, fragment)
+				}
+			}
+			for _, fragment := range tt.excludes {
+				if strings.Contains(got, fragment) {
+					t.Errorf("Marshal = %q, want it without %q", got, fragment)
+				}
+			}
+			if count := strings.Count(got, "BEGIN:VTIMEZONE"); count > 1 {
+				t.Errorf("Marshal wrote %d VTIMEZONE components, want at most one", count)
+			}
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "plain", want: "plain"},
+		{value: `back\slash`, want: `back\\slash`},
+		{value: "a;b,c", want: `a\;b\,c`},
+		{value: "one\r\ntwo\nthree\rfour", want: `one\ntwo\nthreefour`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := escape(tt.value); got != tt.want {
+				t.Errorf("escape(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		lines int
+	}{
+		{name: "short", line: "SUMMARY:Show", lines: 1},
+		{name: "at the limit", line: strings.Repeat("a", lineLimit), lines: 1},
+		{name: "folded", line: strings.Repeat("a", lineLimit+1), lines: 2},
+		{name: "multibyte", line: "SUMMARY:" + strings.Repeat("ção", 40), lines: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			writeLine(&b, tt.line)
+			written := b.String()
+
+			if !strings.HasSuffix(written, "\r\n") {
+				t.Fatalf("writeLine = %q, want it to end with CRLF", written)
+			}
+			lines := strings.Split(strings.TrimSuffix(written, "\r\n"), "\r\n")
+			if len(lines) != tt.lines {
+				t.Errorf("writeLine wrote %d lines, want %d", len(lines), tt.lines)
+			}
+			for i, line := range lines {
+				if len(line) > lineLimit {
+					t.Errorf("line %d has %d octets, want at most %d", i, len(line), lineLimit)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d %q splits a character", i, line)
+				}
+			}
+			if unfolded := strings.ReplaceAll(strings.TrimSuffix(written, "\r\n"), "\r\n ", ""); unfolded != tt.line {
+				t.Errorf("unfolded line = %q, want %q", unfolded, tt.line)
+			}
+		})
+	}
+}
+
+func TestUtcOffset(t *testing.T) {
+	tests := []struct {
+		offset int
+		want   string
+	}{
+		{offset: 0, want: "+0000"},
+		{offset: -3 * 3600, want: "-0300"},
+		{offset: 5*3600 + 30*60, want: "+0530"},
+		{offset: -(46*60 + 40), want: "-004640"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := utcOffset(tt.offset); got != tt.want {
+				t.Errorf("utcOffset(%d) = %q, want %q", tt.offset, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package medialinks
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	YouTube      = "youtube"
+	YouTubeMusic = "youtube_music"
+	Spotify      = "spotify"
+	Deezer       = "deezer"
+	SoundCloud   = "soundcloud"
+	Vimeo        = "vimeo"
+)
+
+var (
+	youTubeIDRegex    = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	spotifyIDRegex    = regexp.MustCompile(`^[A-Za-z0-9]{22}$`)
+	numericIDRegex    = regexp.MustCompile(`^\d+$`)
+	soundCloudIDRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+/(?:sets/)?[A-Za-z0-9_-]+$`)
+	offsetRegex       = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s?)?$`)
+	clockRegex        = regexp.MustCompile(`^(?:(\d+):)?(\d{1,2}):(\d{2})$`)
+)
+
+var ErrUnsupported = errors.New("unsupported media link")
+
+// Link is a recognized media reference, parsed from its URL only
+type Link struct {
+	Provider string
+	ID       string
+	Kind     string // track, album, playlist, episode or video
+	Url      string // Canonical address of the media
+	EmbedUrl string // Address of the provider embeddable player
+	Start    int    // Start offset in seconds
+}
+
+// Parse recognizes YouTube, YouTube Music, Spotify, Deezer, SoundCloud and
+// Vimeo links, including their start time parameters
+func Parse(raw string) (*Link, error) {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "spotify:") {
+		return parseSpotifyURI(raw)
+	}
+	address := raw
+	if !strings.Contains(address, "://") {
+		address = "https://" + address
+	}
+
+	u, err := url.Parse(address)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.ContainsAny(u.Host, " \t") {
+		return nil, fmt.Errorf("malformed media link %q", raw)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	host = strings.TrimPrefix(host, "m.")
+	segments := pathSegments(u.Path)
+
+	var link *Link
+	switch host {
+	case "youtube.com", "youtube-nocookie.com":
+		link = parseYouTube(u, segments, YouTube)
+	case "music.youtube.com":
+		link = parseYouTube(u, segments, YouTubeMusic)
+	case "youtu.be":
+		if len(segments) == 1 {
+			link = youTubeLink(segments[0], YouTube)
+		}
+	case "open.spotify.com":
+		link = parseSpotify(segments)
+	case "deezer.com":
+		link = parseDeezer(segments)
+	case "soundcloud.com":
+		link = parseSoundCloud(segments)
+	case "vimeo.com", "player.vimeo.com":
+		link = parseVimeo(segments)
+	}
+	if link == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnsupported, raw)
+	}
+
+	if link.Provider != Spotify && link.Provider != Deezer {
+		link.Start = startOffset(u)
+		link.EmbedUrl = withStart(link, link.EmbedUrl)
+		link.Url = withStart(link, link.Url)
+	}
+	return link, nil
+}
+
+func parseYouTube(u *url.URL, segments []string, provider string) *Link {
+	if len(segments) == 1 && segments[0] == "watch" {
+		return youTubeLink(u.Query().Get("v"), provider)
+	}
+	if len(segments) == 1 && segments[0] == "playlist" {
+		list := u.Query().Get("list")
+		if list == "" {
+			return nil
+		}
+		return &Link{
+			Provider: provider,
+			ID:       list,
+			Kind:     "playlist",
+			Url:      youTubeBase(provider) + "/playlist?list=" + list,
+			EmbedUrl: "https://www.youtube.com/embed/videoseries?list=" + list,
+		}
+	}
+	if len(segments) == 2 {
+		switch segments[0] {
+		case "embed", "shorts", "live", "v":
+			return youTubeLink(segments[1], provider)
+		}
+	}
+	return nil
+}
+
+func youTubeLink(id string, provider string) *Link {
+	if !youTubeIDRegex.MatchString(id) {
+		return nil
+	}
+	return &Link{
+		Provider: provider,
+		ID:       id,
+		Kind:     "video",
+		Url:      youTubeBase(provider) + "/watch?v=" + id,
+		EmbedUrl: "https://www.youtube.com/embed/" + id,
+	}
+}
+
+func youTubeBase(provider string) string {
+	if provider == YouTubeMusic {
+		return "https://music.youtube.com"
+	}
+	return "https://www.youtube.com"
+}
+
+func parseSpotify(segments []string) *Link {
+	// Localized links look like /intl-pt/track/ID
+	if len(segments) > 0 && strings.HasPrefix(segments[0], "intl-") {
+		segments = segments[1:]
+	}
+	if len(segments) > 0 && segments[0] == "embed" {
+		segments = segments[1:]
+	}
+	if len(segments) != 2 {
+		return nil
+	}
+	return spotifyLink(segments[0], segments[1])
+}
+
+func parseSpotifyURI(raw string) (*Link, error) {
+	parts := strings.Split(raw, ":")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed media link %q", raw)
+	}
+	link := spotifyLink(parts[1], parts[2])
+	if link == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnsupported, raw)
+	}
+	return link, nil
+}
+
+func spotifyLink(kind string, id string) *Link {
+	switch kind {
+	case "track", "album", "playlist", "episode":
+	default:
+		return nil
+	}
+	if !spotifyIDRegex.MatchString(id) {
+		return nil
+	}
+	return &Link{
+		Provider: Spotify,
+		ID:       id,
+		Kind:     kind,
+		Url:      "https://open.spotify.com/" + kind + "/" + id,
+		EmbedUrl: "https://open.spotify.com/embed/" + kind + "/" + id,
+	}
+}
+
+func parseDeezer(segments []string) *Link {
+	// Localized links look like /pt/track/ID
+	if len(segments) == 3 && len(segments[0]) == 2 {
+		segments = segments[1:]
+	}
+	if len(segments) != 2 || !numericIDRegex.MatchString(segments[1]) {
+		return nil
+	}
+	switch segments[0] {
+	case "track", "album", "playlist":
+	default:
+		return nil
+	}
+	return &Link{
+		Provider: Deezer,
+		ID:       segments[1],
+		Kind:     segments[0],
+		Url:      "https://www.deezer.com/" + segments[0] + "/" + segments[1],
+		EmbedUrl: "https://widget.deezer.com/widget/auto/" + segments[0] + "/" + segments[1],
+	}
+}
+
+func parseSoundCloud(segments []string) *Link {
+	id := strings.Join(segments, "/")
+	if !soundCloudIDRegex.MatchString(id) {
+		return nil
+	}
+	kind := "track"
+	if len(segments) == 3 {
+		kind = "playlist"
+	}
+	canonical := "https://soundcloud.com/" + id
+	return &Link{
+		Provider: SoundCloud,
+		ID:       id,
+		Kind:     kind,
+		Url:      canonical,
+		EmbedUrl: "https://w.soundcloud.com/player/?url=" + url.QueryEscape(canonical),
+	}
+}
+
+func parseVimeo(segments []string) *Link {
+	if len(segments) == 0 {
+		return nil
+	}
+	id := segments[len(segments)-1]
+	if !numericIDRegex.MatchString(id) {
+		return nil
+	}
+	return &Link{
+		Provider: Vimeo,
+		ID:       id,
+		Kind:     "video",
+		Url:      "https://vimeo.com/" + id,
+		EmbedUrl: "https://player.vimeo.com/video/" + id,
+	}
+}
+
+// Reads "t" or "start" from the query or the fragment, in seconds or in the
+// "1m30s" and "1:30" forms
+func startOffset(u *url.URL) int {
+	candidates := []string{u.Query().Get("t"), u.Query().Get("start")}
+	if fragment, err := url.ParseQuery(u.Fragment); err == nil {
+		candidates = append(candidates, fragment.Get("t"))
+	}
+	for _, candidate := range candidates {
+		if seconds := parseOffset(candidate); seconds > 0 {
+			return seconds
+		}
+	}
+	return 0
+}
+
+func parseOffset(value string) int {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return 0
+	}
+	if match := clockRegex.FindStringSubmatch(value); match != nil {
+		hours, _ := strconv.Atoi(match[1])
+		minutes, _ := strconv.Atoi(match[2])
+		seconds, _ := strconv.Atoi(match[3])
+		return hours*3600 + minutes*60 + seconds
+	}
+	if match := offsetRegex.FindStringSubmatch(value); match != nil {
+		hours, _ := strconv.Atoi(match[1])
+		minutes, _ := strconv.Atoi(match[2])
+		seconds, _ := strconv.Atoi(match[3])
+		return hours*3600 + minutes*60 + seconds
+	}
+	return 0
+}
+
+// Adds the start offset using the parameter each provider understands
+func withStart(link *Link, address string) string {
+	if link.Start == 0 {
+		return address
+	}
+	if link.Provider == Vimeo || link.Provider == SoundCloud {
+		return fmt.Sprintf("%s#t=%ds", address, link.Start)
+	}
+	separator, param := "?", "t"
+	if strings.Contains(address, "?") {
+		separator = "&"
+	}
+	if strings.Contains(address, "/embed/") {
+		param = "start"
+	}
+	return fmt.Sprintf("%s%s%s=%d", address, separator, param, link.Start)
+}
+
+func pathSegments(path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
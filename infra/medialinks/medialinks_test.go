@@ -0,0 +1,204 @@
+package medialinks
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want *Link
+	}{
+		{
+			name: "youtube watch",
+			raw:  "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+			want: &Link{
+				Provider: YouTube,
+				ID:       "dQw4w9WgXcQ",
+				Kind:     "video",
+				Url:      "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+				EmbedUrl: "https://www.youtube.com/embed/dQw4w9WgXcQ",
+			},
+		},
+		{
+			name: "youtube short link with start",
+			raw:  "youtu.be/dQw4w9WgXcQ?t=1m30s",
+			want: &Link{
+				Provider: YouTube,
+				ID:       "dQw4w9WgXcQ",
+				Kind:     "video",
+				Url:      "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=90",
+				EmbedUrl: "https://www.youtube.com/embed/dQw4w9WgXcQ?start=90",
+				Start:    90,
+			},
+		},
+		{
+			name: "youtube shorts on mobile",
+			raw:  "https://m.youtube.com/shorts/dQw4w9WgXcQ",
+			want: &Link{
+				Provider: YouTube,
+				ID:       "dQw4w9WgXcQ",
+				Kind:     "video",
+				Url:      "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+				EmbedUrl: "https://www.youtube.com/embed/dQw4w9WgXcQ",
+			},
+		},
+		{
+			name: "youtube playlist",
+			raw:  "https://www.youtube.com/playlist?list=PL123",
+			want: &Link{
+				Provider: YouTube,
+				ID:       "PL123",
+				Kind:     "playlist",
+				Url:      "https://www.youtube.com/playlist?list=PL123",
+				EmbedUrl: "https://www.youtube.com/embed/videoseries?list=PL123",
+			},
+		},
+		{
+			name: "youtube playlist with start",
+			raw:  "https://www.youtube.com/playlist?list=PL123&t=30",
+			want: &Link{
+				Provider: YouTube,
+				ID:       "PL123",
+				Kind:     "playlist",
+				Url:      "https://www.youtube.com/playlist?list=PL123&t=30",
+				EmbedUrl: "https://www.youtube.com/embed/videoseries?list=PL123&start=30",
+				Start:    30,
+			},
+		},
+		{
+			name: "youtube music",
+			raw:  "https://music.youtube.com/watch?v=dQw4w9WgXcQ",
+			want: &Link{
+				Provider: YouTubeMusic,
+				ID:       "dQw4w9WgXcQ",
+				Kind:     "video",
+				Url:      "https://music.youtube.com/watch?v=dQw4w9WgXcQ",
+				EmbedUrl: "https://www.youtube.com/embed/dQw4w9WgXcQ",
+			},
+		},
+		{
+			name: "spotify localized track ignores start",
+			raw:  "https://open.spotify.com/intl-pt/track/4uLU6hMCjMI75M1A2tKUQC?t=30",
+			want: &Link{
+				Provider: Spotify,
+				ID:       "4uLU6hMCjMI75M1A2tKUQC",
+				Kind:     "track",
+				Url:      "https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC",
+				EmbedUrl: "https://open.spotify.com/embed/track/4uLU6hMCjMI75M1A2tKUQC",
+			},
+		},
+		{
+			name: "spotify uri",
+			raw:  "spotify:album:4uLU6hMCjMI75M1A2tKUQC",
+			want: &Link{
+				Provider: Spotify,
+				ID:       "4uLU6hMCjMI75M1A2tKUQC",
+				Kind:     "album",
+				Url:      "https://open.spotify.com/album/4uLU6hMCjMI75M1A2tKUQC",
+				EmbedUrl: "https://open.spotify.com/embed/album/4uLU6hMCjMI75M1A2tKUQC",
+			},
+		},
+		{
+			name: "deezer localized track",
+			raw:  "https://www.deezer.com/pt/track/3135556",
+			want: &Link{
+				Provider: Deezer,
+				ID:       "3135556",
+				Kind:     "track",
+				Url:      "https://www.deezer.com/track/3135556",
+				EmbedUrl: "https://widget.deezer.com/widget/auto/track/3135556",
+			},
+		},
+		{
+			name: "soundcloud set",
+			raw:  "https://soundcloud.com/artist/sets/live",
+			want: &Link{
+				Provider: SoundCloud,
+				ID:       "artist/sets/live",
+				Kind:     "playlist",
+				Url:      "https://soundcloud.com/artist/sets/live",
+				EmbedUrl: "https://w.soundcloud.com/player/?url=https%3A%2F%2Fsoundcloud.com%2Fartist%2Fsets%2Flive",
+			},
+		},
+		{
+			name: "vimeo with fragment start",
+			raw:  "https://vimeo.com/76979871#t=1:05",
+			want: &Link{
+				Provider: Vimeo,
+				ID:       "76979871",
+				Kind:     "video",
+				Url:      "https://vimeo.com/76979871#t=65s",
+				EmbedUrl: "https://player.vimeo.com/video/76979871#t=65s",
+				Start:    65,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.raw)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.raw, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		unsupported bool
+	}{
+		{name: "unknown host", raw: "https://example.com/song", unsupported: true},
+		{name: "short youtube id", raw: "https://youtu.be/abc", unsupported: true},
+		{name: "unknown spotify kind", raw: "spotify:artist:4uLU6hMCjMI75M1A2tKUQC", unsupported: true},
+		{name: "non numeric deezer id", raw: "https://www.deezer.com/track/abc", unsupported: true},
+		{name: "spotify uri without id", raw: "spotify:track", unsupported: false},
+		{name: "unsupported scheme", raw: "ftp://youtube.com/watch?v=dQw4w9WgXcQ", unsupported: false},
+		{name: "empty", raw: "", unsupported: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link, err := Parse(tt.raw)
+			if err == nil {
+				t.Fatalf("Parse(%q) = %+v, want error", tt.raw, link)
+			}
+			if got := errors.Is(err, ErrUnsupported); got != tt.unsupported {
+				t.Errorf("Parse(%q) unsupported = %v, want %v (%v)", tt.raw, got, tt.unsupported, err)
+			}
+		})
+	}
+}
+
+func TestParseOffset(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{value: "", want: 0},
+		{value: "42", want: 42},
+		{value: "42s", want: 42},
+		{value: "1m30s", want: 90},
+		{value: "1h2m3s", want: 3723},
+		{value: "1:30", want: 90},
+		{value: "1:02:03", want: 3723},
+		{value: "soon", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := parseOffset(tt.value); got != tt.want {
+				t.Errorf("parseOffset(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
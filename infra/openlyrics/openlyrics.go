@@ -0,0 +1,425 @@
+package openlyrics
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mazurco066/playliter-api-go/infra/chords"
+)
+
+const (
+	Namespace = "http://openlyrics.info/namespace/2009/song"
+	Version   = "0.9"
+
+	// Bounds of a bpm tempo accepted by the schema
+	MinTempo = 30
+	MaxTempo = 250
+
+	xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+	application  = "Playliter"
+)
+
+var (
+	inlineRegex    = regexp.MustCompile(`\[([^\]]+)\]`)
+	verseNameRegex = regexp.MustCompile(`^[a-zA-Z]+[0-9]*[a-zA-Z]*$`)
+	spaceRegex     = regexp.MustCompile(`\s+`)
+)
+
+// Chord structures of OpenLyrics 0.9 and the quality written after the root
+var structures = map[string]string{
+	"":      "",
+	"maj":   "",
+	"min":   "m",
+	"dim":   "dim",
+	"aug":   "aug",
+	"dom7":  "7",
+	"maj7":  "maj7",
+	"min7":  "m7",
+	"dim7":  "dim7",
+	"sus2":  "sus2",
+	"sus4":  "sus4",
+	"6":     "6",
+	"min6":  "m6",
+	"9":     "9",
+	"add9":  "add9",
+	"min9":  "m9",
+	"maj9":  "maj9",
+	"11":    "11",
+	"13":    "13",
+	"7sus4": "7sus4",
+}
+
+// Song is the part of an OpenLyrics document the application understands
+type Song struct {
+	Titles     []Title
+	Authors    []string
+	Key        string
+	Tempo      int // Beats per minute, zero when unknown
+	VerseOrder []string
+	Verses     []Verse
+	// Elements of the document that could not be mapped, e.g. "copyright"
+	Dropped []string
+}
+
+type Title struct {
+	Lang string
+	Text string
+}
+
+// Verse is a block of lyrics in one language. Lines use inline ChordPro
+// chords and blocks of lines are separated by a blank line.
+type Verse struct {
+	Name string
+	Lang string
+	Text string
+}
+
+// Parse reads an OpenLyrics document of any 0.x version
+func Parse(content []byte) (*Song, error) {
+	root, err := readTree(content)
+	if err != nil {
+		return nil, err
+	}
+	if root.name.Local != "song" || root.name.Space != Namespace {
+		return nil, errors.New("not an OpenLyrics document")
+	}
+
+	s := &Song{}
+	dropped := map[string]bool{}
+	for _, child := range root.elements() {
+		switch child.name.Local {
+		case "properties":
+			s.parseProperties(child, dropped)
+		case "lyrics":
+			s.parseLyrics(child, dropped)
+		default:
+			dropped[child.name.Local] = true
+		}
+	}
+	if len(s.Verses) == 0 {
+		return nil, errors.New("OpenLyrics document has no lyrics")
+	}
+
+	for name := range dropped {
+		s.Dropped = append(s.Dropped, name)
+	}
+	sort.Strings(s.Dropped)
+	return s, nil
+}
+
+// Marshal writes the song as an OpenLyrics 0.9 document. The schema
+// requires at least one verse, so songs without lyrics are rejected.
+func Marshal(s *Song) ([]byte, error) {
+	if len(s.Verses) == 0 {
+		return nil, errors.New("song has no lyrics to write as OpenLyrics")
+	}
+
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	fmt.Fprintf(&b, `<song xmlns="%s" version="%s" createdIn="%s" modifiedIn="%s" modifiedDate="%s">`,
+		Namespace, Version, application, application, time.Now().UTC().Format("2006-01-02T15:04:05"))
+
+	b.WriteString("\n  <properties>\n    <titles>\n")
+	for _, title := range s.Titles {
+		b.WriteString("      <title" + langAttr(title.Lang) + ">" + escape(title.Text) + "</title>\n")
+	}
+	b.WriteString("    </titles>\n")
+	if len(s.Authors) > 0 {
+		b.WriteString("    <authors>\n")
+		for _, author := range s.Authors {
+			b.WriteString("      <author>" + escape(author) + "</author>\n")
+		}
+		b.WriteString("    </authors>\n")
+	}
+	if s.Tempo > 0 {
+		fmt.Fprintf(&b, "    <tempo type=\"bpm\">%d</tempo>\n", s.Tempo)
+	}
+	if s.Key != "" {
+		b.WriteString("    <key>" + escape(s.Key) + "</key>\n")
+	}
+	if len(s.VerseOrder) > 0 {
+		b.WriteString("    <verseOrder>" + escape(strings.Join(s.VerseOrder, " ")) + "</verseOrder>\n")
+	}
+	b.WriteString("  </properties>\n  <lyrics>\n")
+
+	for _, verse := range s.Verses {
+		b.WriteString(`    <verse name="` + escape(verse.Name) + `"` + langAttr(verse.Lang) + ">\n")
+		for _, block := range strings.Split(verse.Text, "\n\n") {
+			block = strings.Trim(block, "\n")
+			if block == "" {
+				continue
+			}
+			var lines []string
+			for _, line := range strings.Split(block, "\n") {
+				lines = append(lines, markupLine(line))
+			}
+			b.WriteString("      <lines>" + strings.Join(lines, "<br/>") + "</lines>\n")
+		}
+		b.WriteString("    </verse>\n")
+	}
+	b.WriteString("  </lyrics>\n</song>\n")
+	return b.Bytes(), nil
+}
+
+// IsVerseName reports whether name is a valid OpenLyrics verse name, e.g. "v1" or "c"
+func IsVerseName(name string) bool {
+	return verseNameRegex.MatchString(name)
+}
+
+func (s *Song) parseProperties(properties *node, dropped map[string]bool) {
+	for _, property := range properties.elements() {
+		switch property.name.Local {
+		case "titles":
+			for _, title := range property.elements() {
+				if text := collapse(title.text()); text != "" {
+					s.Titles = append(s.Titles, Title{Lang: title.lang(), Text: text})
+				}
+			}
+		case "authors":
+			for _, author := range property.elements() {
+				// Translators are credited to the translation, which has no author field
+				if author.attr("type") == "translation" {
+					dropped["author (translation)"] = true
+					continue
+				}
+				if text := collapse(author.text()); text != "" {
+					s.Authors = append(s.Authors, text)
+				}
+			}
+		case "key":
+			s.Key = collapse(property.text())
+		case "tempo":
+			bpm, err := strconv.Atoi(collapse(property.text()))
+			if property.attr("type") != "bpm" || err != nil || bpm <= 0 {
+				dropped["tempo (text)"] = true
+				continue
+			}
+			s.Tempo = bpm
+		case "verseOrder":
+			s.VerseOrder = strings.Fields(property.text())
+		default:
+			dropped[property.name.Local] = true
+		}
+	}
+}
+
+func (s *Song) parseLyrics(lyrics *node, dropped map[string]bool) {
+	for _, verse := range lyrics.elements() {
+		if verse.name.Local != "verse" {
+			dropped[verse.name.Local] = true
+			continue
+		}
+		if verse.attr("translit") != "" {
+			dropped["verse (transliteration)"] = true
+			continue
+		}
+
+		var blocks []string
+		for _, lines := range verse.elements() {
+			if lines.name.Local != "lines" {
+				dropped[lines.name.Local] = true
+				continue
+			}
+			if block := readLines(lines, dropped); block != "" {
+				blocks = append(blocks, block)
+			}
+		}
+		s.Verses = append(s.Verses, Verse{
+			Name: verse.attr("name"),
+			Lang: verse.lang(),
+			Text: strings.Join(blocks, "\n\n"),
+		})
+	}
+}
+
+// Reads the mixed content of a <lines> element into inline ChordPro lines.
+// Raw line breaks are only whitespace, lines are split by <br/> elements.
+func readLines(lines *node, dropped map[string]bool) string {
+	var b strings.Builder
+	var walk func(n *node)
+	walk = func(n *node) {
+		for _, child := range n.children {
+			if child.isText {
+				b.WriteString(strings.NewReplacer("\n", " ", "\r", " ", "\t", " ").Replace(child.data))
+				continue
+			}
+			switch child.name.Local {
+			case "br":
+				b.WriteString("\n")
+			case "chord":
+				if symbol := chordName(child); symbol != "" {
+					b.WriteString("[" + symbol + "]")
+				} else {
+					dropped["chord"] = true
+				}
+				// 0.9 chords may wrap the lyrics sung over them
+				walk(child)
+			case "comment":
+				dropped["comment"] = true
+			case "tag":
+				dropped["tag"] = true
+				walk(child)
+			default:
+				dropped[child.name.Local] = true
+			}
+		}
+	}
+	walk(lines)
+
+	var result []string
+	for _, line := range strings.Split(b.String(), "\n") {
+		result = append(result, strings.TrimSpace(spaceRegex.ReplaceAllString(line, " ")))
+	}
+	return strings.Trim(strings.Join(result, "\n"), "\n")
+}
+
+// Chord symbol of a <chord> element, either its name or its 0.9 root,
+// structure and bass attributes
+func chordName(n *node) string {
+	if name := n.attr("name"); name != "" {
+		return name
+	}
+	root := n.attr("root")
+	if root == "" {
+		return ""
+	}
+	quality, ok := structures[n.attr("structure")]
+	if !ok {
+		quality = n.attr("structure")
+	}
+	symbol := root + quality
+	if bass := n.attr("bass"); bass != "" {
+		symbol += "/" + bass
+	}
+	return symbol
+}
+
+// Writes an inline ChordPro line as OpenLyrics markup
+func markupLine(line string) string {
+	var b strings.Builder
+	cursor := 0
+	for _, match := range inlineRegex.FindAllStringSubmatchIndex(line, -1) {
+		symbol := strings.TrimSpace(line[match[2]:match[3]])
+		if !chords.IsChord(symbol) {
+			continue
+		}
+		b.WriteString(escape(line[cursor:match[0]]))
+		b.WriteString(`<chord name="` + escape(symbol) + `"/>`)
+		cursor = match[1]
+	}
+	b.WriteString(escape(line[cursor:]))
+	return b.String()
+}
+
+func langAttr(lang string) string {
+	if lang == "" {
+		return ""
+	}
+	return ` xml:lang="` + escape(lang) + `"`
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func collapse(s string) string {
+	return strings.TrimSpace(spaceRegex.ReplaceAllString(s, " "))
+}
+
+// Minimal element tree, mixed content like <lines> needs the text and the
+// elements in document order
+type node struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*node
+	isText   bool
+	data     string
+}
+
+func readTree(content []byte) (*node, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	var stack []*node
+	var root *node
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid XML: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			n := &node{name: t.Name, attrs: t.Attr}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root == nil {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, &node{isText: true, data: string(t)})
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.New("invalid XML: no root element")
+	}
+	return root, nil
+}
+
+func (n *node) elements() []*node {
+	var elements []*node
+	for _, child := range n.children {
+		if !child.isText {
+			elements = append(elements, child)
+		}
+	}
+	return elements
+}
+
+func (n *node) text() string {
+	var b strings.Builder
+	for _, child := range n.children {
+		if child.isText {
+			b.WriteString(child.data)
+		} else {
+			b.WriteString(child.text())
+		}
+	}
+	return b.String()
+}
+
+func (n *node) attr(name string) string {
+	for _, attr := range n.attrs {
+		if attr.Name.Local == name && attr.Name.Space == "" {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func (n *node) lang() string {
+	for _, attr := range n.attrs {
+		if attr.Name.Local == "lang" && (attr.Name.Space == xmlNamespace || attr.Name.Space == "xml") {
+			return attr.Value
+		}
+	}
+	return ""
+}
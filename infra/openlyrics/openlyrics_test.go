@@ -0,0 +1,214 @@
+package openlyrics
+
+import (
+	"encoding/xml"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var modifiedDateRegex = regexp.MustCompile(`modifiedDate="[^"]*"`)
+
+// Song written as testdata/amazing_grace.xml
+var amazingGrace = &Song{
+	Titles:     []Title{{Text: "Amazing Grace"}, {Lang: "pt", Text: "Maravilhosa Graça"}},
+	Authors:    []string{"John Newton"},
+	Key:        "G",
+	Tempo:      72,
+	VerseOrder: []string{"v1", "c", "v1"},
+	Verses: []Verse{
+		{Name: "v1", Text: "[G]Amazing grace how [C]sweet the [G]sound\nThat saved a wretch like [D]me\n\nLost & found"},
+		{Name: "c", Text: "[Em7]Grace <alone>"},
+		{Name: "v1", Lang: "pt", Text: "Maravilhosa graça"},
+	},
+}
+
+func TestMarshal(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/amazing_grace.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		song    *Song
+		want    string
+		wantErr bool
+	}{
+		{name: "fixture", song: amazingGrace, want: string(fixture)},
+		{name: "no verses", song: &Song{Titles: []Title{{Text: "Empty"}}}, wantErr: true},
+		{
+			name: "blank blocks are skipped",
+			song: &Song{
+				Titles: []Title{{Text: "Short"}},
+				Verses: []Verse{{Name: "v1", Text: "\n\nOne line\n\n\n"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.song)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkSchema(t, got)
+			if tt.want != "" && normalizeDate(string(got)) != normalizeDate(tt.want) {
+				t.Errorf("Marshal() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/amazing_grace.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		content     string
+		want        *Song
+		wantDropped []string
+		wantErr     bool
+	}{
+		{name: "fixture", content: string(fixture), want: amazingGrace},
+		{
+			name: "0.9 chord attributes and dropped elements",
+			content: `<song xmlns="` + Namespace + `" version="0.9">
+				<properties>
+					<titles><title>Song</title></titles>
+					<copyright>2024</copyright>
+					<tempo type="text">moderate</tempo>
+				</properties>
+				<lyrics>
+					<verse name="v1"><lines><chord root="A" structure="min7" bass="G">Hel</chord>lo<comment>soft</comment></lines></verse>
+				</lyrics>
+			</song>`,
+			want: &Song{
+				Titles: []Title{{Text: "Song"}},
+				Verses: []Verse{{Name: "v1", Text: "[Am7/G]Hello"}},
+			},
+			wantDropped: []string{"comment", "copyright", "tempo (text)"},
+		},
+		{name: "other namespace", content: `<song xmlns="urn:other"><lyrics/></song>`, wantErr: true},
+		{
+			name:    "no lyrics",
+			content: `<song xmlns="` + Namespace + `"><properties><titles><title>A</title></titles></properties><lyrics/></song>`,
+			wantErr: true,
+		},
+		{name: "not xml", content: `song`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.content))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			dropped := got.Dropped
+			got.Dropped = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(dropped, tt.wantDropped) {
+				t.Errorf("Dropped = %v, want %v", dropped, tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestIsVerseName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"v1", true},
+		{"c", true},
+		{"v1a", true},
+		{"bridge", true},
+		{"1", false},
+		{"v 1", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsVerseName(tt.name); got != tt.want {
+			t.Errorf("IsVerseName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func normalizeDate(s string) string {
+	return modifiedDateRegex.ReplaceAllString(s, `modifiedDate=""`)
+}
+
+// Checks the rules of the OpenLyrics 0.9 schema the writer has to follow:
+// the namespace and version, at least one non empty title, at least one
+// verse with a valid name and lines, a bpm tempo within bounds and a verse
+// order naming only existing verses
+func checkSchema(t *testing.T, content []byte) {
+	t.Helper()
+	var document struct {
+		XMLName xml.Name `xml:"song"`
+		Version string   `xml:"version,attr"`
+		Titles  []string `xml:"properties>titles>title"`
+		Tempo   *struct {
+			Type  string `xml:"type,attr"`
+			Value int    `xml:",chardata"`
+		} `xml:"properties>tempo"`
+		VerseOrder string `xml:"properties>verseOrder"`
+		Verses     []struct {
+			Name  string     `xml:"name,attr"`
+			Lines []xml.Name `xml:"lines"`
+		} `xml:"lyrics>verse"`
+	}
+	if err := xml.Unmarshal(content, &document); err != nil {
+		t.Fatalf("document is not well formed: %v", err)
+	}
+
+	if document.XMLName.Space != Namespace || document.Version != Version {
+		t.Errorf("root is %v version %q", document.XMLName, document.Version)
+	}
+	if len(document.Titles) == 0 {
+		t.Error("no title")
+	}
+	for _, title := range document.Titles {
+		if strings.TrimSpace(title) == "" {
+			t.Error("empty title")
+		}
+	}
+	if tempo := document.Tempo; tempo != nil && (tempo.Type != "bpm" || tempo.Value < MinTempo || tempo.Value > MaxTempo) {
+		t.Errorf("tempo %+v out of the schema bounds", *tempo)
+	}
+	if len(document.Verses) == 0 {
+		t.Error("no verse")
+	}
+	names := map[string]bool{}
+	for _, verse := range document.Verses {
+		if !IsVerseName(verse.Name) {
+			t.Errorf("invalid verse name %q", verse.Name)
+		}
+		if len(verse.Lines) == 0 {
+			t.Errorf("verse %q has no lines", verse.Name)
+		}
+		names[verse.Name] = true
+	}
+	for _, name := range strings.Fields(document.VerseOrder) {
+		if !names[name] {
+			t.Errorf("verse order names the unknown verse %q", name)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+)
+
+// Font is one of the standard PDF fonts, which every reader ships so nothing
+// has to be embedded in the document
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+	Courier
+	CourierBold
+)
+
+var fontNames = []string{"Helvetica", "Helvetica-Bold", "Courier", "Courier-Bold"}
+
+// PageSize in points (1/72 inch)
+type PageSize struct {
+	Width  float64
+	Height float64
+}
+
+var (
+	A4     = PageSize{Width: 595.28, Height: 841.89}
+	Letter = PageSize{Width: 612, Height: 792}
+)
+
+// Document is a minimal PDF writer that lays out text on pages
+type Document struct {
+	Title string
+	Size  PageSize
+	pages []*bytes.Buffer
+}
+
+func NewDocument(title string, size PageSize) *Document {
+	return &Document{Title: title, Size: size}
+}
+
+// AddPage starts a new page, every drawing call goes to the last page
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+// PageCount returns the number of pages added so far
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Text draws a single line with its baseline starting at x, y. The origin
+// is the bottom left corner of the page.
+func (d *Document) Text(x float64, y float64, font Font, size float64, text string) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	page := d.pages[len(d.pages)-1]
+	fmt.Fprintf(page, "BT /F%d %s Tf %s %s Td (%s) Tj ET\n", font+1, number(size), number(x), number(y), escape(encode(text)))
+}
+
+// Line draws a thin stroke between two points
+func (d *Document) Line(x1 float64, y1 float64, x2 float64, y2 float64) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	page := d.pages[len(d.pages)-1]
+	fmt.Fprintf(page, "0.5 w %s %s m %s %s l S\n", number(x1), number(y1), number(x2), number(y2))
+}
+
+// Bytes serializes the document, content streams are deflate compressed
+func (d *Document) Bytes() []byte {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Fixed objects: catalog, page tree, info and fonts, pages come after
+	fontsStart := 4
+	pagesStart := fontsStart + len(fontNames)
+	var kids []string
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", pagesStart+i*2))
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object(fmt.Sprintf("<< /Title (%s) /Producer (Playliter) >>", escape(encode(d.Title))))
+
+	var fonts []string
+	for i, name := range fontNames {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+		fonts = append(fonts, fmt.Sprintf("/F%d %d 0 R", i+1, fontsStart+i))
+	}
+
+	for i, page := range d.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			number(d.Size.Width), number(d.Size.Height), strings.Join(fonts, " "), pagesStart+i*2+1,
+		))
+
+		var compressed bytes.Buffer
+		writer := zlib.NewWriter(&compressed)
+		writer.Write(page.Bytes())
+		writer.Close()
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// TextWidth measures a line in points, Courier is monospaced while Helvetica
+// uses the average width of its lowercase glyphs as an approximation
+func TextWidth(font Font, size float64, text string) float64 {
+	count := float64(len([]rune(text)))
+	switch font {
+	case Courier, CourierBold:
+		return count * 0.6 * size
+	case HelveticaBold:
+		return count * 0.58 * size
+	}
+	return count * 0.53 * size
+}
+
+// DigitsWidth measures a number in Helvetica, where every digit is 556 units wide
+func DigitsWidth(size float64, text string) float64 {
+	return float64(len(text)) * 0.556 * size
+}
+
+func number(n float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", n), "0"), ".")
+}
+
+func escape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, "\r", "", "\n", "").Replace(text)
+}
+
+// Characters of the windows 1252 code page that differ from latin 1
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e, '‘': 0x91,
+	'’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98,
+	'™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+	'♯': '#', '♭': 'b', '\t': ' ',
+}
+
+// Converts UTF-8 text to the WinAnsi encoding of the standard fonts,
+// characters outside of it are replaced by "?"
+func encode(text string) string {
+	var out []byte
+	for _, r := range text {
+		switch {
+		case r < 0x80 && r != '\t':
+			out = append(out, byte(r))
+		case winAnsi[r] != 0:
+			out = append(out, winAnsi[r])
+		case r >= 0xa0 && r <= 0xff:
+			out = append(out, byte(r))
+		default:
+			out = append(out, '?')
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,118 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "plain", want: "plain"},
+		{text: "Ação", want: "A\xe7\xe3o"},
+		{text: "F♯ B♭", want: "F# Bb"},
+		{text: "€ “quoted”", want: "\x80 \x93quoted\x94"},
+		{text: "tab\there", want: "tab here"},
+		{text: "中", want: "?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := encode(tt.text); got != tt.want {
+				t.Errorf("encode(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "plain", want: "plain"},
+		{text: `(x2) \ end`, want: `\(x2\) \\ end`},
+		{text: "line\r\nbreak", want: "linebreak"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := escape(tt.text); got != tt.want {
+				t.Errorf("escape(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentBytes(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages int
+		want  int
+	}{
+		{name: "empty document has a blank page", pages: 0, want: 1},
+		{name: "one page", pages: 1, want: 1},
+		{name: "several pages", pages: 3, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document := NewDocument("Book (live)", A4)
+			for i := 0; i < tt.pages; i++ {
+				document.AddPage()
+				document.Text(50, 700, Helvetica, 11, "Hello")
+			}
+			out := document.Bytes()
+
+			if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+				t.Fatalf("Bytes = %q, want a PDF header and trailer", out)
+			}
+			if count := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(out); count == nil || string(count[1]) != strconv.Itoa(tt.want) {
+				t.Errorf("Bytes page count = %s, want %d", count, tt.want)
+			}
+			if !bytes.Contains(out, []byte(`/Title (Book \(live\))`)) {
+				t.Errorf("Bytes = %q, want the escaped title", out)
+			}
+			assertXref(t, out)
+		})
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		font Font
+		text string
+		want float64
+	}{
+		{font: Courier, text: "abcd", want: 24},
+		{font: CourierBold, text: "ação", want: 24},
+		{font: Helvetica, text: "ab", want: 10.6},
+		{font: HelveticaBold, text: "ab", want: 11.6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := TextWidth(tt.font, 10, tt.text); got < tt.want-1e-9 || got > tt.want+1e-9 {
+				t.Errorf("TextWidth(%d, %q) = %v, want %v", tt.font, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+// Every xref entry must point at the start of its object
+func assertXref(t *testing.T, out []byte) {
+	t.Helper()
+	xref := bytes.LastIndex(out, []byte("xref\n"))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		prefix := strconv.Itoa(i+1) + " 0 obj"
+		if !bytes.HasPrefix(out[offset:], []byte(prefix)) {
+			t.Errorf("xref entry %d points at %q, want %q", i+1, out[offset:offset+len(prefix)], prefix)
+		}
+	}
+}
@@ -0,0 +1,336 @@
+package pdf
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mazurco066/playliter-api-go/infra/chordpro"
+	"github.com/mazurco066/playliter-api-go/infra/chords"
+	"github.com/mazurco066/playliter-api-go/infra/textnorm"
+)
+
+const (
+	margin       = 50.0
+	footerOffset = 30.0
+)
+
+// Chart is a single song to be printed
+type Chart struct {
+	Title    string
+	Subtitle string
+	Body     string
+}
+
+// Options of the printed layout
+type Options struct {
+	FontSize float64
+	Size     PageSize
+}
+
+// SongSheet renders a single chart, without table of contents or index
+func SongSheet(chart *Chart, opts Options) []byte {
+	l := newLayout(opts)
+	l.chart(chart)
+	return l.render(chart.Title)
+}
+
+// Songbook renders several charts, each one starting on a new page, preceded
+// by a table of contents in the given order and followed by an alphabetical
+// index of titles
+func Songbook(title string, charts []*Chart, opts Options) []byte {
+	l := newLayout(opts)
+
+	// Contents are laid out once ahead, only to know how many pages they take
+	draft := newLayout(opts)
+	draft.list("Contents", title, make([]listEntry, len(charts)), false)
+	tocPages := len(draft.pages)
+
+	starts := make([]int, len(charts))
+	for i, chart := range charts {
+		starts[i] = tocPages + len(l.pages) + 1
+		l.chart(chart)
+	}
+
+	var contents []listEntry
+	for i, chart := range charts {
+		contents = append(contents, listEntry{title: chart.Title, page: starts[i]})
+	}
+	index := append([]listEntry{}, contents...)
+	sort.SliceStable(index, func(i, j int) bool {
+		return sortKey(index[i].title) < sortKey(index[j].title)
+	})
+
+	songPages := l.pages
+	l.pages = nil
+	l.list("Contents", title, contents, false)
+	l.pages = append(l.pages, songPages...)
+	l.list("Index", "", index, true)
+	return l.render(title)
+}
+
+type textOp struct {
+	x    float64
+	y    float64
+	font Font
+	size float64
+	text string
+	rule bool
+}
+
+type listEntry struct {
+	title string
+	page  int
+}
+
+type layout struct {
+	opts     Options
+	width    float64
+	leading  float64
+	maxChars int
+	pages    [][]textOp
+	y        float64
+}
+
+func newLayout(opts Options) *layout {
+	if opts.FontSize <= 0 {
+		opts.FontSize = 11
+	}
+	if opts.Size.Width == 0 {
+		opts.Size = A4
+	}
+	width := opts.Size.Width - margin*2
+	return &layout{
+		opts:     opts,
+		width:    width,
+		leading:  opts.FontSize * 1.3,
+		maxChars: int(width / (0.6 * opts.FontSize)),
+	}
+}
+
+func (l *layout) newPage() {
+	l.pages = append(l.pages, nil)
+	l.y = l.opts.Size.Height - margin
+}
+
+func (l *layout) add(op textOp) {
+	l.pages[len(l.pages)-1] = append(l.pages[len(l.pages)-1], op)
+}
+
+// Space left on the current page before the footer area
+func (l *layout) remaining() float64 {
+	return l.y - margin - footerOffset/2
+}
+
+func (l *layout) text(font Font, size float64, text string) {
+	l.y -= size * 1.3
+	l.add(textOp{x: margin, y: l.y, font: font, size: size, text: text})
+}
+
+// Lays out the chart starting on a new page, chord lines always stay on the
+// same page as their lyric and headings are never left at the bottom
+func (l *layout) chart(chart *Chart) {
+	fs := l.opts.FontSize
+	l.newPage()
+	l.text(HelveticaBold, fs+7, chart.Title)
+	if chart.Subtitle != "" {
+		l.y -= 2
+		l.text(Helvetica, fs-1, chart.Subtitle)
+	}
+	l.y -= fs * 0.6
+	l.add(textOp{x: margin, y: l.y, rule: true})
+	l.y -= fs * 0.4
+
+	blocks := chartBlocks(chordpro.FromInline(chart.Body), l.maxChars)
+	for i, block := range blocks {
+		needed := float64(len(block)) * l.leading
+		if block[0].font == HelveticaBold && i+1 < len(blocks) {
+			needed += float64(len(blocks[i+1])) * l.leading
+		}
+		if needed > l.remaining() {
+			// Blank lines are not worth a new page
+			if len(block) == 1 && block[0].text == "" {
+				continue
+			}
+			l.newPage()
+			l.text(Helvetica, fs-2, chart.Title+" (cont.)")
+			l.y -= fs * 0.5
+		}
+		for _, line := range block {
+			l.y -= l.leading
+			if line.text != "" {
+				l.add(textOp{x: margin, y: l.y, font: line.font, size: fs, text: line.text})
+			}
+		}
+	}
+}
+
+// Table of contents or index lines, "Title ........ 12"
+func (l *layout) list(heading string, subtitle string, entries []listEntry, letters bool) {
+	fs := l.opts.FontSize
+	start := func() {
+		l.newPage()
+		l.text(HelveticaBold, fs+7, heading)
+		if subtitle != "" {
+			l.text(Helvetica, fs-1, subtitle)
+		}
+		l.y -= fs
+	}
+	start()
+
+	lastLetter := ""
+	for _, entry := range entries {
+		if l.remaining() < l.leading*2 {
+			start()
+		}
+		if letters {
+			if letter := firstLetter(entry.title); letter != lastLetter {
+				lastLetter = letter
+				l.y -= l.leading * 0.3
+				l.text(HelveticaBold, fs, letter)
+			}
+		}
+		l.y -= l.leading
+		l.add(textOp{x: margin, y: l.y, font: Courier, size: fs, text: leaderLine(entry.title, entry.page, l.maxChars)})
+	}
+}
+
+// Draws every page along with its page number on the footer
+func (l *layout) render(title string) []byte {
+	document := NewDocument(title, l.opts.Size)
+	for i, page := range l.pages {
+		document.AddPage()
+		for _, op := range page {
+			if op.rule {
+				document.Line(margin, op.y, margin+l.width, op.y)
+				continue
+			}
+			document.Text(op.x, op.y, op.font, op.size, op.text)
+		}
+		pageNumber := strconv.Itoa(i + 1)
+		footerSize := l.opts.FontSize - 2
+		document.Text((l.opts.Size.Width-DigitsWidth(footerSize, pageNumber))/2, footerOffset, Helvetica, footerSize, pageNumber)
+	}
+	return document.Bytes()
+}
+
+type chartLine struct {
+	font Font
+	text string
+}
+
+// Groups the chart into blocks that must not be split across pages: a
+// heading, a chord line with its lyric, or a single line. Long lines are
+// wrapped at the same column on both the chord and the lyric line.
+func chartBlocks(body string, maxChars int) [][]chartLine {
+	body = strings.Trim(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	lines := strings.Split(body, "\n")
+	var blocks [][]chartLine
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+
+		if heading := chordpro.Heading(trimmed); heading != "" {
+			blocks = append(blocks, []chartLine{{font: HelveticaBold, text: heading}})
+			continue
+		}
+
+		if chords.IsChordLine(line) {
+			lyric := ""
+			if i+1 < len(lines) && isLyric(lines[i+1]) {
+				lyric = strings.TrimRight(lines[i+1], " \t")
+				i++
+			}
+			for _, pair := range wrapPair(line, lyric, maxChars) {
+				block := []chartLine{{font: CourierBold, text: pair[0]}}
+				if lyric != "" {
+					block = append(block, chartLine{font: Courier, text: pair[1]})
+				}
+				blocks = append(blocks, block)
+			}
+			continue
+		}
+
+		for _, part := range wrapPair("", line, maxChars) {
+			blocks = append(blocks, []chartLine{{font: Courier, text: part[1]}})
+		}
+	}
+	return blocks
+}
+
+func isLyric(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed != "" && !chords.IsChordLine(line) && chordpro.Heading(trimmed) == ""
+}
+
+// Wraps a chord line and its lyric at the same columns, preferring a space
+// of the lyric where no chord is placed
+func wrapPair(chordLine string, lyric string, maxChars int) [][2]string {
+	top, bottom := []rune(chordLine), []rune(lyric)
+	var out [][2]string
+	for len(top) > maxChars || len(bottom) > maxChars {
+		cut := maxChars
+		for i := maxChars; i > maxChars/2; i-- {
+			if i < len(bottom) && bottom[i] == ' ' && (i >= len(top) || top[i] == ' ') {
+				cut = i
+				break
+			}
+		}
+		out = append(out, [2]string{runesUntil(top, cut), runesUntil(bottom, cut)})
+		top, bottom = runesFrom(top, cut), runesFrom(bottom, cut)
+
+		// Drop the spaces both lines start with, keeping them aligned
+		for len(bottom) > 0 && bottom[0] == ' ' && (len(top) == 0 || top[0] == ' ') {
+			bottom = bottom[1:]
+			if len(top) > 0 {
+				top = top[1:]
+			}
+		}
+	}
+	return append(out, [2]string{string(top), string(bottom)})
+}
+
+func runesUntil(runes []rune, n int) string {
+	if n > len(runes) {
+		n = len(runes)
+	}
+	return strings.TrimRight(string(runes[:n]), " ")
+}
+
+func runesFrom(runes []rune, n int) []rune {
+	if n > len(runes) {
+		return nil
+	}
+	return runes[n:]
+}
+
+func leaderLine(title string, page int, maxChars int) string {
+	pageText := strconv.Itoa(page)
+	room := maxChars - len(pageText) - 2
+	runes := []rune(title)
+	if len(runes) > room {
+		runes = append(runes[:room-1], '…')
+	}
+	dots := maxChars - len(runes) - len(pageText) - 2
+	if dots < 1 {
+		dots = 1
+	}
+	return fmt.Sprintf("%s %s %s", string(runes), strings.Repeat(".", dots), pageText)
+}
+
+// Accent and case insensitive key, so "Águas" sorts next to "Aguas"
+func sortKey(title string) string {
+	return textnorm.Fold(title)
+}
+
+func firstLetter(title string) string {
+	key := sortKey(title)
+	for _, r := range key {
+		if r >= 'a' && r <= 'z' {
+			return strings.ToUpper(string(r))
+		}
+		return "#"
+	}
+	return "#"
+}
@@ -0,0 +1,128 @@
+package pdf
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestChartBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		maxChars int
+		want     [][]chartLine
+	}{
+		{
+			name:     "heading, chord line with its lyric and plain lines",
+			body:     "[Verse]\nG     C\nAmazing grace\n\nplain\n  D\n",
+			maxChars: 80,
+			want: [][]chartLine{
+				{{font: HelveticaBold, text: "Verse"}},
+				{{font: CourierBold, text: "G     C"}, {font: Courier, text: "Amazing grace"}},
+				{{font: Courier, text: ""}},
+				{{font: Courier, text: "plain"}},
+				{{font: CourierBold, text: "  D"}},
+			},
+		},
+		{
+			name:     "chord line followed by a heading stands alone",
+			body:     "G  D\n[Chorus]",
+			maxChars: 80,
+			want: [][]chartLine{
+				{{font: CourierBold, text: "G  D"}},
+				{{font: HelveticaBold, text: "Chorus"}},
+			},
+		},
+		{
+			name:     "long lines are wrapped at the same columns",
+			body:     "G         C\nAmazing grace how sweet",
+			maxChars: 10,
+			want: [][]chartLine{
+				{{font: CourierBold, text: "G"}, {font: Courier, text: "Amazing"}},
+				{{font: CourierBold, text: "  C"}, {font: Courier, text: "grace how"}},
+				{{font: CourierBold, text: ""}, {font: Courier, text: "sweet"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chartBlocks(tt.body, tt.maxChars); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chartBlocks(%q) = %q, want %q", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLeaderLine(t *testing.T) {
+	tests := []struct {
+		title string
+		page  int
+		want  string
+	}{
+		{title: "Amazing Grace", page: 12, want: "Amazing Grace ............. 12"},
+		{title: "A very long title that does not fit", page: 3, want: "A very long title that doe… . 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := leaderLine(tt.title, tt.page, 30); got != tt.want {
+				t.Errorf("leaderLine(%q, %d) = %q, want %q", tt.title, tt.page, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstLetter(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "amazing", want: "A"},
+		{title: "Águas", want: "A"},
+		{title: "10.000 Reasons", want: "#"},
+		{title: "", want: "#"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := firstLetter(tt.title); got != tt.want {
+				t.Errorf("firstLetter(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSongbook(t *testing.T) {
+	tests := []struct {
+		name   string
+		charts []*Chart
+		pages  string
+	}{
+		{name: "contents, a page per song and the index", charts: []*Chart{{Title: "Zebra", Body: "la"}, {Title: "Águas", Body: "G\nla"}}, pages: "4"},
+		{name: "no songs", pages: "2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := Songbook("Book", tt.charts, Options{})
+			count := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(out)
+			if count == nil || string(count[1]) != tt.pages {
+				t.Errorf("Songbook page count = %s, want %s", count, tt.pages)
+			}
+			assertXref(t, out)
+		})
+	}
+}
+
+func TestSongSheet(t *testing.T) {
+	out := SongSheet(&Chart{Title: "Grace", Body: "{soc}\n[G]Amazing [C]grace\n{eoc}"}, Options{FontSize: 12, Size: Letter})
+	if count := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(out); count == nil || string(count[1]) != "1" {
+		t.Errorf("SongSheet page count = %s, want 1", count)
+	}
+	if !regexp.MustCompile(`/MediaBox \[0 0 612 792\]`).Match(out) {
+		t.Errorf("SongSheet = %q, want letter sized pages", out)
+	}
+	assertXref(t, out)
+}
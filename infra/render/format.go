@@ -0,0 +1,137 @@
+package render
+
+import (
+	"html"
+	"strings"
+
+	"github.com/mazurco066/playliter-api-go/infra/chordpro"
+)
+
+// Chord grid cells written per row of the text output
+const gridColumns = 4
+
+// Text writes the document as plain text, sections are separated by a blank
+// line and start with their "[Label]" heading
+func Text(document *Document) string {
+	var sections []string
+	for _, section := range document.Sections {
+		var lines []string
+		if section.Label != "" {
+			if document.Mode == ModeChordPro {
+				lines = append(lines, "{comment: "+section.Label+"}")
+			} else {
+				lines = append(lines, "["+section.Label+"]")
+			}
+		}
+
+		switch document.Mode {
+		case ModeChords:
+			lines = append(lines, gridRows(section)...)
+		case ModeLyrics:
+			for _, line := range section.Lines {
+				lines = append(lines, plainLyrics(line))
+			}
+		case ModeChordPro:
+			for _, line := range section.Lines {
+				lines = append(lines, inline(line))
+			}
+		default:
+			for _, line := range section.Lines {
+				lines = append(lines, chordpro.FromInline(inline(line)))
+			}
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// HTML writes the document as an HTML fragment, styling is left to the
+// client through the "chart-*" classes
+func HTML(document *Document) string {
+	var b strings.Builder
+	b.WriteString(`<div class="chart chart-` + html.EscapeString(document.Mode) + `">`)
+	for _, section := range document.Sections {
+		b.WriteString(`<section class="chart-section">`)
+		if section.Label != "" {
+			b.WriteString(`<h3 class="chart-label">` + html.EscapeString(section.Label) + `</h3>`)
+		}
+
+		switch document.Mode {
+		case ModeChords:
+			b.WriteString(`<div class="chart-grid">`)
+			for _, line := range section.Lines {
+				b.WriteString(`<span class="chart-cell">` + html.EscapeString(chordList(line)) + `</span>`)
+			}
+			b.WriteString(`</div>`)
+		case ModeLyrics:
+			for _, line := range section.Lines {
+				b.WriteString(`<p class="chart-line chart-` + line.Kind + `">` + html.EscapeString(plainLyrics(line)) + `</p>`)
+			}
+		case ModeChordPro:
+			var lines []string
+			for _, line := range section.Lines {
+				lines = append(lines, inline(line))
+			}
+			b.WriteString(`<pre class="chart-chordpro">` + html.EscapeString(strings.Join(lines, "\n")) + `</pre>`)
+		default:
+			for _, line := range section.Lines {
+				b.WriteString(`<div class="chart-line chart-` + line.Kind + `">`)
+				for _, segment := range line.Segments {
+					b.WriteString(`<span class="chart-segment"><span class="chart-chord">` + html.EscapeString(segment.Chord) + `</span>`)
+					b.WriteString(`<span class="chart-lyrics">` + html.EscapeString(segment.Lyrics) + `</span></span>`)
+				}
+				b.WriteString(`</div>`)
+			}
+		}
+		b.WriteString(`</section>`)
+	}
+	b.WriteString(`</div>`)
+	return b.String()
+}
+
+// Writes a line back with inline chords, e.g. "[G]Amazing [C]grace"
+func inline(line Line) string {
+	var b strings.Builder
+	for i, segment := range line.Segments {
+		if segment.Chord != "" {
+			if line.Kind == LineChords && i > 0 {
+				b.WriteString(" ")
+			}
+			b.WriteString("[" + segment.Chord + "]")
+		}
+		b.WriteString(segment.Lyrics)
+	}
+	return b.String()
+}
+
+func plainLyrics(line Line) string {
+	var b strings.Builder
+	for _, segment := range line.Segments {
+		b.WriteString(segment.Lyrics)
+	}
+	return b.String()
+}
+
+func chordList(line Line) string {
+	var symbols []string
+	for _, segment := range line.Segments {
+		if segment.Chord != "" {
+			symbols = append(symbols, segment.Chord)
+		}
+	}
+	return strings.Join(symbols, " ")
+}
+
+// Lays the lines of a section out as "| G C | Am F |" rows
+func gridRows(section Section) []string {
+	var rows []string
+	for start := 0; start < len(section.Lines); start += gridColumns {
+		end := min(start+gridColumns, len(section.Lines))
+		var cells []string
+		for _, line := range section.Lines[start:end] {
+			cells = append(cells, chordList(line))
+		}
+		rows = append(rows, "| "+strings.Join(cells, " | ")+" |")
+	}
+	return rows
+}
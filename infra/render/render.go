@@ -0,0 +1,198 @@
+package render
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mazurco066/playliter-api-go/infra/chordpro"
+	"github.com/mazurco066/playliter-api-go/infra/chords"
+)
+
+const (
+	ModeFull     = "full"     // Chords over lyrics
+	ModeLyrics   = "lyrics"   // Sung text only
+	ModeChords   = "chords"   // Compact chord grid per section
+	ModeChordPro = "chordpro" // Inline ChordPro chords
+
+	FormatText = "text"
+	FormatHTML = "html"
+	FormatJSON = "json"
+)
+
+const (
+	LineLyrics = "lyrics" // Lyrics, with or without chords above them
+	LineChords = "chords" // Chords played without lyrics, e.g. an intro
+	LineEmpty  = "empty"
+)
+
+var inlineRegex = regexp.MustCompile(`\[([^\]]+)\]`)
+
+// Document is a chart broken into sections, lines and segments, so clients
+// can lay it out without parsing chords themselves
+type Document struct {
+	Mode     string
+	Sections []Section
+}
+
+// Section is a block of the chart under a heading, the label is empty for
+// lines written before the first heading
+type Section struct {
+	Label string
+	Lines []Line
+}
+
+type Line struct {
+	Kind     string
+	Segments []Segment
+}
+
+// Segment is a chord and the lyrics sung from it until the next chord,
+// either of them may be empty
+type Segment struct {
+	Chord  string
+	Lyrics string
+}
+
+// Parse breaks a chart body, written with chords over lyrics or with inline
+// chords, into a full mode document
+func Parse(body string) *Document {
+	document := &Document{Mode: ModeFull}
+	current := Section{}
+	for _, line := range strings.Split(chordpro.ToInline(body), "\n") {
+		if chords.IsDirective(line) {
+			if label := chordpro.SectionHeading(line); label != "" {
+				document.Sections = appendSection(document.Sections, current)
+				current = Section{Label: label}
+			}
+			continue
+		}
+		current.Lines = append(current.Lines, parseLine(line))
+	}
+	document.Sections = appendSection(document.Sections, current)
+	return document
+}
+
+// Apply returns the document as seen in the given mode, the ChordPro mode
+// shares the tokens of the full one
+func Apply(document *Document, mode string) *Document {
+	switch mode {
+	case ModeLyrics:
+		return mapLines(document, mode, lyricsOnly)
+	case ModeChords:
+		return mapLines(document, mode, chordsOnly)
+	}
+	return &Document{Mode: mode, Sections: document.Sections}
+}
+
+func parseLine(line string) Line {
+	if strings.TrimSpace(line) == "" {
+		return Line{Kind: LineEmpty}
+	}
+
+	var segments []Segment
+	cursor := 0
+	chord := ""
+	for _, match := range inlineRegex.FindAllStringSubmatchIndex(line, -1) {
+		symbol := strings.TrimSpace(line[match[2]:match[3]])
+		if !chords.IsChord(symbol) {
+			continue
+		}
+		if text := line[cursor:match[0]]; text != "" || chord != "" {
+			segments = append(segments, Segment{Chord: chord, Lyrics: text})
+		}
+		chord = symbol
+		cursor = match[1]
+	}
+	if text := strings.TrimRight(line[cursor:], " \t"); text != "" || chord != "" {
+		segments = append(segments, Segment{Chord: chord, Lyrics: text})
+	}
+
+	kind := LineChords
+	for _, segment := range segments {
+		if strings.TrimSpace(segment.Lyrics) != "" {
+			kind = LineLyrics
+			break
+		}
+	}
+	if kind == LineChords {
+		for i := range segments {
+			segments[i].Lyrics = ""
+		}
+	}
+	return Line{Kind: kind, Segments: segments}
+}
+
+// Keeps the section when it has any content, without its surrounding blank lines
+func appendSection(sections []Section, section Section) []Section {
+	start, end := 0, len(section.Lines)
+	for start < end && section.Lines[start].Kind == LineEmpty {
+		start++
+	}
+	for end > start && section.Lines[end-1].Kind == LineEmpty {
+		end--
+	}
+	if start == end && section.Label == "" {
+		return sections
+	}
+	section.Lines = section.Lines[start:end]
+	return append(sections, section)
+}
+
+// Rebuilds every section with the lines returned by the mapper, collapsing
+// the blank lines left behind by dropped lines
+func mapLines(document *Document, mode string, mapper func(Line) (Line, bool)) *Document {
+	mapped := &Document{Mode: mode}
+	for _, section := range document.Sections {
+		var lines []Line
+		for _, line := range section.Lines {
+			result, keep := mapper(line)
+			if !keep {
+				continue
+			}
+			if result.Kind == LineEmpty && (len(lines) == 0 || lines[len(lines)-1].Kind == LineEmpty) {
+				continue
+			}
+			lines = append(lines, result)
+		}
+		// Sections left without content, like an instrumental intro in the
+		// lyrics mode, are dropped while bare repeat headings are kept
+		if len(section.Lines) > 0 && len(lines) == 0 {
+			continue
+		}
+		mapped.Sections = appendSection(mapped.Sections, Section{Label: section.Label, Lines: lines})
+	}
+	return mapped
+}
+
+func lyricsOnly(line Line) (Line, bool) {
+	switch line.Kind {
+	case LineChords:
+		return line, false
+	case LineEmpty:
+		return line, true
+	}
+	var text strings.Builder
+	for _, segment := range line.Segments {
+		text.WriteString(segment.Lyrics)
+	}
+	return Line{Kind: LineLyrics, Segments: []Segment{{Lyrics: strings.TrimSpace(text.String())}}}, true
+}
+
+// Every line played becomes a grid row with its chords, a chord held over
+// the next lyrics is not repeated
+func chordsOnly(line Line) (Line, bool) {
+	var segments []Segment
+	for _, segment := range line.Segments {
+		if segment.Chord == "" {
+			continue
+		}
+		if len(segments) > 0 && segments[len(segments)-1].Chord == segment.Chord {
+			continue
+		}
+		segments = append(segments, Segment{Chord: segment.Chord})
+	}
+	if len(segments) == 0 {
+		return line, false
+	}
+	return Line{Kind: LineChords, Segments: segments}, true
+}
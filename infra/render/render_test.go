@@ -0,0 +1,172 @@
+package render
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const chart = "{soc}\nG       C\nAmazing grace\n\n{eoc}\n{start_of_verse: Verse 1}\n[Am]How [F]sweet\n[G] [G]\n{end_of_verse}\n{comment: Chorus}\n"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []Section
+	}{
+		{
+			name: "empty",
+			body: "",
+			want: nil,
+		},
+		{
+			name: "lyrics before any heading",
+			body: "\nplain words\n",
+			want: []Section{
+				{Lines: []Line{{Kind: LineLyrics, Segments: []Segment{{Lyrics: "plain words"}}}}},
+			},
+		},
+		{
+			name: "chords over lyrics",
+			body: "G       C\nAmazing grace",
+			want: []Section{
+				{Lines: []Line{{Kind: LineLyrics, Segments: []Segment{{Chord: "G", Lyrics: "Amazing "}, {Chord: "C", Lyrics: "grace"}}}}},
+			},
+		},
+		{
+			name: "chords without lyrics",
+			body: "[G] [D]",
+			want: []Section{
+				{Lines: []Line{{Kind: LineChords, Segments: []Segment{{Chord: "G"}, {Chord: "D"}}}}},
+			},
+		},
+		{
+			name: "bracketed words are kept as lyrics",
+			body: "[x2] [G]la",
+			want: []Section{
+				{Lines: []Line{{Kind: LineLyrics, Segments: []Segment{{Lyrics: "[x2] "}, {Chord: "G", Lyrics: "la"}}}}},
+			},
+		},
+		{
+			name: "sections and a bare repeat heading",
+			body: chart,
+			want: []Section{
+				{Label: "Chorus", Lines: []Line{
+					{Kind: LineLyrics, Segments: []Segment{{Chord: "G", Lyrics: "Amazing "}, {Chord: "C", Lyrics: "grace"}}},
+				}},
+				{Label: "Verse 1", Lines: []Line{
+					{Kind: LineLyrics, Segments: []Segment{{Chord: "Am", Lyrics: "How "}, {Chord: "F", Lyrics: "sweet"}}},
+					{Kind: LineChords, Segments: []Segment{{Chord: "G"}, {Chord: "G"}}},
+				}},
+				{Label: "Chorus", Lines: []Line{}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.body)
+			if got.Mode != ModeFull {
+				t.Errorf("Parse(%q).Mode = %q, want %q", tt.body, got.Mode, ModeFull)
+			}
+			if !reflect.DeepEqual(got.Sections, tt.want) {
+				t.Errorf("Parse(%q).Sections = %+v, want %+v", tt.body, got.Sections, tt.want)
+			}
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		mode string
+		want string
+	}{
+		{
+			mode: ModeFull,
+			want: "[Chorus]\nG       C\nAmazing grace\n\n[Verse 1]\nAm  F\nHow sweet\nG G\n\n[Chorus]",
+		},
+		{
+			mode: ModeLyrics,
+			want: "[Chorus]\nAmazing grace\n\n[Verse 1]\nHow sweet\n\n[Chorus]",
+		},
+		{
+			mode: ModeChords,
+			want: "[Chorus]\n| G C |\n\n[Verse 1]\n| Am F | G |\n\n[Chorus]",
+		},
+		{
+			mode: ModeChordPro,
+			want: "{comment: Chorus}\n[G]Amazing [C]grace\n\n{comment: Verse 1}\n[Am]How [F]sweet\n[G] [G]\n\n{comment: Chorus}",
+		},
+	}
+
+	document := Parse(chart)
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			if got := Text(Apply(document, tt.mode)); got != tt.want {
+				t.Errorf("Text(%s) = %q, want %q", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyDropsSectionsWithoutContent(t *testing.T) {
+	document := Parse("{start_of_verse: Intro}\n[G] [D]\n{end_of_verse}\n{start_of_verse: Verse}\nla [G]la\n{end_of_verse}")
+	got := Apply(document, ModeLyrics)
+	if len(got.Sections) != 1 || got.Sections[0].Label != "Verse" {
+		t.Errorf("Apply(lyrics).Sections = %+v, want only the verse", got.Sections)
+	}
+}
+
+func TestGridRows(t *testing.T) {
+	var lines []Line
+	for _, chord := range []string{"C", "D", "E", "F", "G"} {
+		lines = append(lines, Line{Kind: LineChords, Segments: []Segment{{Chord: chord}}})
+	}
+	want := []string{"| C | D | E | F |", "| G |"}
+	if got := gridRows(Section{Lines: lines}); !reflect.DeepEqual(got, want) {
+		t.Errorf("gridRows = %q, want %q", got, want)
+	}
+}
+
+func TestHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		document *Document
+		contains []string
+	}{
+		{
+			name:     "full mode segments",
+			document: Apply(Parse("[G]Amazing"), ModeFull),
+			contains: []string{
+				`<div class="chart chart-full">`,
+				`<span class="chart-chord">G</span><span class="chart-lyrics">Amazing</span>`,
+			},
+		},
+		{
+			name: "escaped labels and lyrics",
+			document: &Document{Mode: ModeLyrics, Sections: []Section{
+				{Label: "<Bridge>", Lines: []Line{{Kind: LineLyrics, Segments: []Segment{{Lyrics: "rock & roll"}}}}},
+			}},
+			contains: []string{
+				`<h3 class="chart-label">&lt;Bridge&gt;</h3>`,
+				`<p class="chart-line chart-lyrics">rock &amp; roll</p>`,
+			},
+		},
+		{
+			name:     "chord grid cells",
+			document: Apply(Parse("[Am]How [F]sweet"), ModeChords),
+			contains: []string{`<div class="chart-grid"><span class="chart-cell">Am F</span></div>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HTML(tt.document)
+			for _, fragment := range tt.contains {
+				if !strings.Contains(got, fragment) {
+					t.Errorf("HTML = %s, want it to contain %s", got, fragment)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package textdiff
+
+import "strings"
+
+// Lines of a replaced block need at least this similarity to be matched
+const matchThreshold = 0.6
+
+// MatchLines maps every line number of the old text to its number in the
+// new text. Unchanged lines are matched first and, within each block of
+// replaced lines, an edited line is matched to the most similar line that
+// replaced it, in order. Lines left without a similar one were deleted and
+// are absent from the mapping.
+func MatchLines(oldText string, newText string) map[int]int {
+	mapping := map[int]int{}
+	var deleted, inserted []Line
+	pair := func() {
+		next := 0
+		for _, d := range deleted {
+			best, bestScore := -1, matchThreshold
+			for j := next; j < len(inserted); j++ {
+				if score := similarity(d.Text, inserted[j].Text); score >= bestScore {
+					best, bestScore = j, score
+				}
+			}
+			if best >= 0 {
+				mapping[d.OldLine] = inserted[best].NewLine
+				next = best + 1
+			}
+		}
+		deleted, inserted = nil, nil
+	}
+
+	for _, line := range Lines(oldText, newText) {
+		switch line.Op {
+		case OpEqual:
+			pair()
+			mapping[line.OldLine] = line.NewLine
+		case OpDelete:
+			deleted = append(deleted, line)
+		case OpInsert:
+			inserted = append(inserted, line)
+		}
+	}
+	pair()
+	return mapping
+}
+
+// Similarity of two lines from 0 to 1, the share of their characters in
+// common ignoring case and surrounding spaces. Blank lines match nothing.
+func similarity(a string, b string) float64 {
+	x := []rune(strings.ToLower(strings.TrimSpace(a)))
+	y := []rune(strings.ToLower(strings.TrimSpace(b)))
+	if len(x) == 0 || len(y) == 0 {
+		return 0
+	}
+
+	// Longest common subsequence keeping a single row of the table
+	row := make([]int, len(y)+1)
+	for i := range x {
+		diagonal := 0
+		for j := range y {
+			above := row[j+1]
+			if x[i] == y[j] {
+				row[j+1] = diagonal + 1
+			} else if row[j] > row[j+1] {
+				row[j+1] = row[j]
+			}
+			diagonal = above
+		}
+	}
+	return 2 * float64(row[len(y)]) / float64(len(x)+len(y))
+}
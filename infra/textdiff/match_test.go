@@ -0,0 +1,73 @@
+package textdiff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchLines(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want map[int]int
+	}{
+		{
+			name: "unchanged lines shift",
+			old:  "a\nb",
+			new:  "intro\na\nb",
+			want: map[int]int{1: 2, 2: 3},
+		},
+		{
+			name: "edited line is followed",
+			old:  "Amazing grace how sweet\nthe sound",
+			new:  "Amazing grace, how sweet\nthe sound",
+			want: map[int]int{1: 1, 2: 2},
+		},
+		{
+			name: "rewritten line is deleted",
+			old:  "first\nAmazing grace how sweet\nlast",
+			new:  "first\nSomething else entirely\nlast",
+			want: map[int]int{1: 1, 3: 3},
+		},
+		{
+			name: "deleted line",
+			old:  "a\nb\nc",
+			new:  "a\nc",
+			want: map[int]int{1: 1, 3: 2},
+		},
+		{
+			name: "edits keep their order",
+			old:  "x\nthe first verse line\nthe second verse line\ny",
+			new:  "x\nnew line\nthe first verse line!\nthe second verse line!\ny",
+			want: map[int]int{1: 1, 2: 3, 3: 4, 4: 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchLines(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MatchLines() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want float64
+	}{
+		{"abc", "abc", 1},
+		{"ABC ", "abc", 1},
+		{"abcd", "wxyz", 0},
+		{"ab", "abcd", 2 * 2.0 / 6},
+		{"", "", 0},
+		{"abc", "", 0},
+	}
+	for _, tt := range tests {
+		if got := similarity(tt.a, tt.b); got != tt.want {
+			t.Errorf("similarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package textdiff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want []Line
+	}{
+		{
+			name: "unchanged",
+			old:  "a\nb",
+			new:  "a\nb",
+			want: []Line{
+				{Op: OpEqual, OldLine: 1, NewLine: 1, Text: "a"},
+				{Op: OpEqual, OldLine: 2, NewLine: 2, Text: "b"},
+			},
+		},
+		{
+			name: "replaced line",
+			old:  "a\nb\nc",
+			new:  "a\nx\nc",
+			want: []Line{
+				{Op: OpEqual, OldLine: 1, NewLine: 1, Text: "a"},
+				{Op: OpDelete, OldLine: 2, Text: "b"},
+				{Op: OpInsert, NewLine: 2, Text: "x"},
+				{Op: OpEqual, OldLine: 3, NewLine: 3, Text: "c"},
+			},
+		},
+		{
+			name: "appended",
+			old:  "a",
+			new:  "a\nb",
+			want: []Line{
+				{Op: OpEqual, OldLine: 1, NewLine: 1, Text: "a"},
+				{Op: OpInsert, NewLine: 2, Text: "b"},
+			},
+		},
+		{
+			name: "from empty",
+			old:  "",
+			new:  "a",
+			want: []Line{{Op: OpInsert, NewLine: 1, Text: "a"}},
+		},
+		{
+			name: "to empty",
+			old:  "a",
+			new:  "",
+			want: []Line{{Op: OpDelete, OldLine: 1, Text: "a"}},
+		},
+		{
+			name: "crlf matches lf",
+			old:  "a\r\nb",
+			new:  "a\nb",
+			want: []Line{
+				{Op: OpEqual, OldLine: 1, NewLine: 1, Text: "a"},
+				{Op: OpEqual, OldLine: 2, NewLine: 2, Text: "b"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Lines(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lines() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package textnorm
+
+import "strings"
+
+// Text is a text simplified once so it can be compared against many others
+// without simplifying and splitting it again on every comparison
+type Text struct {
+	runes    []rune
+	words    []string
+	n        int
+	shingles map[string]bool // Sequences of n words
+	unigrams map[string]bool // Single words, for texts shorter than n words
+}
+
+// NewText simplifies the text and collects its n word shingles
+func NewText(text string, n int) *Text {
+	simplified := Simplify(text)
+	t := &Text{runes: []rune(simplified), words: strings.Fields(simplified), n: n}
+	t.unigrams = shingles(t.words, 1)
+	t.shingles = t.unigrams
+	if n != 1 {
+		t.shingles = shingles(t.words, n)
+	}
+	return t
+}
+
+// Empty tells whether nothing is left of the text once simplified
+func (t *Text) Empty() bool {
+	return len(t.runes) == 0
+}
+
+// Ratio compares two texts by edit distance, from 0 for nothing in common to
+// 1 for equal texts once simplified
+func (t *Text) Ratio(other *Text) float64 {
+	if len(t.runes) == 0 && len(other.runes) == 0 {
+		return 1
+	}
+	longest := max(len(t.runes), len(other.runes))
+	return 1 - float64(levenshtein(t.runes, other.runes))/float64(longest)
+}
+
+// Overlap compares two texts by the word sequences they share, using the
+// Dice coefficient of their shingles. Texts shorter than n words are
+// compared word by word. Both texts must be built with the same n.
+func (t *Text) Overlap(other *Text) float64 {
+	if len(t.words) == 0 || len(other.words) == 0 {
+		return 0
+	}
+	sa, sb := t.shingles, other.shingles
+	if len(t.words) < t.n || len(other.words) < other.n {
+		sa, sb = t.unigrams, other.unigrams
+	}
+	common := 0
+	for shingle := range sa {
+		if sb[shingle] {
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(sa)+len(sb))
+}
+
+// Ratio compares two texts by edit distance, see Text.Ratio
+func Ratio(a string, b string) float64 {
+	return NewText(a, 1).Ratio(NewText(b, 1))
+}
+
+// Overlap compares two texts by their n word shingles, see Text.Overlap
+func Overlap(a string, b string, n int) float64 {
+	return NewText(a, n).Overlap(NewText(b, n))
+}
+
+func shingles(words []string, n int) map[string]bool {
+	set := map[string]bool{}
+	for i := 0; i+n <= len(words); i++ {
+		set[strings.Join(words[i:i+n], " ")] = true
+	}
+	return set
+}
+
+func levenshtein(a []rune, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,86 @@
+package textnorm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRatio(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want float64
+	}{
+		{a: "", b: "", want: 1},
+		{a: "Oceans", b: "oceans!", want: 1},
+		{a: "abc", b: "", want: 0},
+		{a: "Graça", b: "graca", want: 1},
+		{a: "kitten", b: "sitting", want: 1 - 3.0/7},
+		{a: "abcd", b: "wxyz", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"|"+tt.b, func(t *testing.T) {
+			if got := Ratio(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Ratio(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverlap(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		n    int
+		want float64
+	}{
+		{name: "empty", a: "", b: "one two three", n: 3, want: 0},
+		{name: "equal once simplified", a: "Amazing grace, how sweet!", b: "amazing grace how sweet", n: 3, want: 1},
+		{name: "shared shingles", a: "a b c d", b: "a b c e", n: 3, want: 0.5},
+		{name: "nothing shared", a: "a b c", b: "d e f", n: 3, want: 0},
+		{name: "short texts compare words", a: "hello world", b: "hello there", n: 3, want: 0.5},
+		{name: "repeated shingles count once", a: "la la la la", b: "la la la", n: 3, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Overlap(tt.a, tt.b, tt.n); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Overlap(%q, %q, %d) = %v, want %v", tt.a, tt.b, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       string
+		b       string
+		n       int
+		ratio   float64
+		overlap float64
+	}{
+		{name: "equal once simplified", a: "Graça, Infinita!", b: "graca infinita", n: 1, ratio: 1, overlap: 1},
+		{name: "shared shingles", a: "a b c d", b: "a b c e", n: 3, ratio: 6.0 / 7, overlap: 0.5},
+		{name: "shorter than n on one side", a: "a b", b: "a b c", n: 3, ratio: 3.0 / 5, overlap: 0.8},
+		{name: "empty", a: "!!", b: "word", n: 3, ratio: 0, overlap: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := NewText(tt.a, tt.n), NewText(tt.b, tt.n)
+			if got := a.Ratio(b); math.Abs(got-tt.ratio) > 1e-9 {
+				t.Errorf("Ratio = %v, want %v", got, tt.ratio)
+			}
+			if got := a.Overlap(b); math.Abs(got-tt.overlap) > 1e-9 {
+				t.Errorf("Overlap = %v, want %v", got, tt.overlap)
+			}
+		})
+	}
+
+	if !NewText(" ?! ", 1).Empty() || NewText("a", 1).Empty() {
+		t.Error("Empty should only hold for texts without letters or digits")
+	}
+}
@@ -0,0 +1,43 @@
+package textnorm
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Base letter of the accented latin characters used in portuguese, spanish,
+// french and german texts
+var accents = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ç': 'c', 'ñ': 'n', 'ý': 'y', 'ÿ': 'y',
+}
+
+// Fold lowercases the text, removes accents and collapses whitespace, so
+// "  Águas   Purificadoras" and "aguas purificadoras" are equal
+func Fold(s string) string {
+	folded := strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		if base, ok := accents[r]; ok {
+			return base
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(folded), " ")
+}
+
+// Simplify folds the text and drops punctuation, keeping only letters,
+// digits and single spaces. "Oceans (Where Feet May Fail)" becomes
+// "oceans where feet may fail".
+func Simplify(s string) string {
+	stripped := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, Fold(s))
+	return strings.Join(strings.Fields(stripped), " ")
+}
@@ -0,0 +1,43 @@
+package textnorm
+
+import "testing"
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "", want: ""},
+		{value: "  Águas   Purificadoras ", want: "aguas purificadoras"},
+		{value: "Coração\tÇÃO", want: "coracao cao"},
+		{value: "Señor, ¿dónde?", want: "senor, ¿donde?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := Fold(tt.value); got != tt.want {
+				t.Errorf("Fold(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "", want: ""},
+		{value: "Oceans (Where Feet May Fail)", want: "oceans where feet may fail"},
+		{value: "Rock'n'Roll -- Pt. 2!", want: "rock n roll pt 2"},
+		{value: "Ó  Deus, és bom...", want: "o deus es bom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := Simplify(tt.value); got != tt.want {
+				t.Errorf("Simplify(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
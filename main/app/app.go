@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/mazurco066/playliter-api-go/data/migrations"
 	accountrepo "github.com/mazurco066/playliter-api-go/data/repositories/account"
 	bandrepo "github.com/mazurco066/playliter-api-go/data/repositories/band"
 	concertrepo "github.com/mazurco066/playliter-api-go/data/repositories/concert"
@@ -64,15 +65,32 @@ func Run() {
 	db.AutoMigrate(
 		&account.Account{},
 		&account.EmailVerification{},
+		&account.Unavailability{},
 		&auth.Auth{},
 		&band.Band{},
 		&band.BandRequest{},
 		&band.Member{},
 		&concert.Concert{},
 		&concert.ConcertSong{},
+		&concert.SetlistSection{},
+		&concert.CalendarFeed{},
+		&concert.RSVP{},
 		&song.Song{},
+		&song.Section{},
+		&song.ArrangementItem{},
+		&song.Revision{},
+		&song.Tag{},
+		&song.MediaLink{},
+		&song.Preference{},
+		&song.Annotation{},
+		&song.Translation{},
 	)
 
+	/* ========= Database migrations (extensions, triggers, indexes) ========= */
+	if migrationErr := migrations.Run(db); migrationErr != nil {
+		panic(migrationErr)
+	}
+
 	/* ========= Setup common ========= */
 	hm := hmachash.NewHMAC(configs.HMACKey)
 
@@ -80,26 +98,52 @@ func Run() {
 
 	/* ========= Setup repositories ========= */
 	accountRepo := accountrepo.NewAccountRepo(db)
+	unavailabilityRepo := accountrepo.NewUnavailabilityRepo(db)
 	bandRepo := bandrepo.NewBandRepo(db)
 	bandRequestRepo := bandrepo.NewBandRequestRepo(db)
 	memberRepo := bandrepo.NewMemberRepo(db)
 	concertRepo := concertrepo.NewConcertRepo(db)
-	songrepo := songrepo.NewSongRepo(db)
+	concertSongRepo := concertrepo.NewConcertSongRepo(db)
+	calendarFeedRepo := concertrepo.NewCalendarFeedRepo(db)
+	rsvpRepo := concertrepo.NewRSVPRepo(db)
+	songRepo := songrepo.NewSongRepo(db)
+	sectionRepo := songrepo.NewSectionRepo(db)
+	arrangementRepo := songrepo.NewArrangementRepo(db)
+	revisionRepo := songrepo.NewRevisionRepo(db)
+	tagRepo := songrepo.NewTagRepo(db)
+	mediaLinkRepo := songrepo.NewMediaLinkRepo(db)
+	preferenceRepo := songrepo.NewPreferenceRepo(db)
+	annotationRepo := songrepo.NewAnnotationRepo(db)
+	translationRepo := songrepo.NewTranslationRepo(db)
+	performanceRepo := songrepo.NewPerformanceRepo(db)
 
 	/* ========= Setup usecases ========= */
 	accountService := accountusecase.NewAccountUseCase(accountRepo, hm)
+	unavailabilityService := accountusecase.NewUnavailabilityUseCase(unavailabilityRepo)
 	authService := authusecase.NewAuthUseCase(configs.JWTSecret)
 	bandService := bandusecase.NewBandUseCase(bandRepo)
 	bandRequestService := bandusecase.NewBandRequestUseCase(bandRequestRepo)
 	memberService := bandusecase.NewMemberUseCase(memberRepo)
 	concertService := concertusecase.NewConcertUseCase(concertRepo)
-	songService := songusecase.NewSongUseCase(songrepo)
+	calendarService := concertusecase.NewCalendarUseCase(calendarFeedRepo, concertRepo, hm)
+	rsvpService := concertusecase.NewRSVPUseCase(rsvpRepo)
+	concertSongService := concertusecase.NewConcertSongUseCase(concertSongRepo, arrangementRepo, sectionRepo)
+	songService := songusecase.NewSongUseCase(songRepo, annotationRepo)
+	sectionService := songusecase.NewSectionUseCase(sectionRepo)
+	arrangementService := songusecase.NewArrangementUseCase(arrangementRepo, sectionRepo)
+	revisionService := songusecase.NewRevisionUseCase(revisionRepo)
+	tagService := songusecase.NewTagUseCase(tagRepo)
+	mediaLinkService := songusecase.NewMediaLinkUseCase(mediaLinkRepo)
+	preferenceService := songusecase.NewPreferenceUseCase(preferenceRepo)
+	annotationService := songusecase.NewAnnotationUseCase(annotationRepo, sectionRepo)
+	translationService := songusecase.NewTranslationUseCase(translationRepo)
+	performanceService := songusecase.NewPerformanceUseCase(performanceRepo)
 
 	/* ========= Setup controllers ========= */
-	accountController := accountcontroller.NewAccaccountController(accountService, authService)
+	accountController := accountcontroller.NewAccaccountController(accountService, authService, unavailabilityService)
 	bandController := bandcontroller.NewBandController(accountService, bandService, bandRequestService, memberService)
-	concertController := concertcontroller.NewConcertController(concertService)
-	songController := songcontroller.NewSongController(songService)
+	concertController := concertcontroller.NewConcertController(accountService, bandService, calendarService, concertService, concertSongService, rsvpService, songService, unavailabilityService, configs.BaseURL())
+	songController := songcontroller.NewSongController(accountService, annotationService, arrangementService, bandService, mediaLinkService, performanceService, preferenceService, revisionService, sectionService, songService, tagService, translationService)
 
 	/* ========= Setup middlewares ========= */
 	router.Use(gin.Logger())
@@ -118,6 +162,11 @@ func Run() {
 		accounts.GET("/me", accountController.CurrentAccount)
 		accounts.PATCH("/me", accountController.Update)
 		accounts.GET("/active_users", accountController.ListActiveAccounts)
+		accounts.POST("/me/calendar", concertController.CreateCalendar)
+		accounts.DELETE("/me/calendar", concertController.RemoveCalendar)
+		accounts.GET("/me/unavailability", accountController.ListUnavailability)
+		accounts.POST("/me/unavailability", accountController.CreateUnavailability)
+		accounts.DELETE("/me/unavailability/:unavailability_id", accountController.RemoveUnavailability)
 	}
 
 	/* ========= App band routes ========= */
@@ -134,6 +183,22 @@ func Run() {
 		bands.PATCH("/:id/invite/:invite_id", bandController.RespondInvite)
 		bands.PATCH("/:id/member/:member_id", bandController.UpdateMember)
 		bands.DELETE(":id/member/:member_id", bandController.ExpelMember)
+		bands.POST("/:id/calendar", concertController.CreateCalendar)
+		bands.DELETE("/:id/calendar", concertController.RemoveCalendar)
+		bands.GET("/:id/concerts", concertController.List)
+		bands.GET("/:id/songs", songController.List)
+		bands.POST("/:id/songs/chordpro", songController.ImportChordPro)
+		bands.POST("/:id/songs/chordpro/batch", songController.ImportChordProBatch)
+		bands.GET("/:id/songs/duplicates", songController.ListDuplicates)
+		bands.POST("/:id/songs/import", songController.ImportSongs)
+		bands.POST("/:id/songs/openlyrics", songController.ImportOpenLyrics)
+		bands.GET("/:id/songs/pdf", songController.ExportSongbook)
+		bands.GET("/:id/songs/stats", songController.PerformanceReport)
+		bands.POST("/:id/songs/pdf", songController.ExportSelection)
+		bands.GET("/:id/tags", songController.ListTags)
+		bands.POST("/:id/tags", songController.CreateTag)
+		bands.PATCH("/:id/tags/:tag_id", songController.UpdateTag)
+		bands.DELETE("/:id/tags/:tag_id", songController.RemoveTag)
 	}
 	invites := api.Group("/invites")
 	invites.Use(middlewares.RequiredLoggedIn(configs.JWTSecret))
@@ -142,10 +207,24 @@ func Run() {
 	}
 
 	/* ========= App concert routes ========= */
+	// Calendar apps can't log in, feeds are authorized by their secret token
+	api.GET("/calendar/:token", concertController.Calendar)
 	concerts := api.Group("/concerts")
 	concerts.Use(middlewares.RequiredLoggedIn(configs.JWTSecret))
 	{
 		concerts.POST("/", concertController.Create)
+		concerts.GET("/:id", concertController.Get)
+		concerts.PATCH("/:id", concertController.Update)
+		concerts.DELETE("/:id", concertController.Remove)
+		concerts.GET("/:id/chords", concertController.ChordDiagrams)
+		concerts.PUT("/:id/rsvp", concertController.SaveRSVP)
+		concerts.GET("/:id/rsvps", concertController.RSVPSummary)
+		concerts.POST("/:id/songs", concertController.AddSong)
+		concerts.PUT("/:id/songs", concertController.ReorderSongs)
+		concerts.PATCH("/:id/songs/:entry_id", concertController.MoveSong)
+		concerts.DELETE("/:id/songs/:entry_id", concertController.RemoveSong)
+		concerts.GET("/:id/songs/:entry_id/chart", concertController.Chart)
+		concerts.PUT("/:id/songs/:entry_id/overrides", concertController.SaveOverrides)
 	}
 
 	/* ========= App song routes ========= */
@@ -153,6 +232,59 @@ func Run() {
 	songs.Use(middlewares.RequiredLoggedIn(configs.JWTSecret))
 	{
 		songs.POST("/", songController.Create)
+		songs.GET("/search", songController.Search)
+		songs.POST("/nashville", songController.ConvertNashville)
+		songs.GET("/:id", songController.Get)
+		songs.PATCH("/:id", songController.Update)
+		songs.DELETE("/:id", songController.Remove)
+		songs.POST("/:id/transpose", songController.Transpose)
+		songs.POST("/:id/merge", songController.MergeSongs)
+		songs.GET("/:id/chordpro", songController.ExportChordPro)
+		songs.GET("/:id/chords", songController.ChordDiagrams)
+		songs.GET("/:id/openlyrics", songController.ExportOpenLyrics)
+		songs.GET("/:id/stats", songController.GetStats)
+		songs.GET("/:id/pdf", songController.ExportPdf)
+		songs.GET("/:id/media", songController.ListMediaLinks)
+		songs.POST("/:id/media", songController.CreateMediaLink)
+		songs.DELETE("/:id/media/:media_id", songController.RemoveMediaLink)
+		songs.GET("/:id/preference", songController.GetPreference)
+		songs.PUT("/:id/preference", songController.SavePreference)
+		songs.DELETE("/:id/preference", songController.RemovePreference)
+		songs.GET("/:id/translations", songController.ListTranslations)
+		songs.PUT("/:id/translations/:language", songController.SaveTranslation)
+		songs.DELETE("/:id/translations/:language", songController.RemoveTranslation)
+		songs.GET("/:id/sections", songController.ListSections)
+		songs.POST("/:id/sections", songController.CreateSection)
+		songs.POST("/:id/sections/parse", songController.ParseSections)
+		songs.PATCH("/:id/sections/:section_id", songController.UpdateSection)
+		songs.DELETE("/:id/sections/:section_id", songController.RemoveSection)
+		songs.GET("/:id/annotations", songController.ListAnnotations)
+		songs.POST("/:id/annotations", songController.CreateAnnotation)
+		songs.PATCH("/:id/annotations/:annotation_id", songController.UpdateAnnotation)
+		songs.DELETE("/:id/annotations/:annotation_id", songController.RemoveAnnotation)
+		songs.POST("/:id/annotations/:annotation_id/resolve", songController.ResolveAnnotation)
+		songs.DELETE("/:id/annotations/:annotation_id/resolve", songController.ReopenAnnotation)
+		songs.POST("/:id/annotations/:annotation_id/hide", songController.HideAnnotation)
+		songs.DELETE("/:id/annotations/:annotation_id/hide", songController.UnhideAnnotation)
+		songs.GET("/:id/arrangement", songController.GetArrangement)
+		songs.PUT("/:id/arrangement", songController.UpdateArrangement)
+		songs.GET("/:id/render", songController.Render)
+		songs.GET("/:id/revisions", songController.ListRevisions)
+		songs.GET("/:id/revisions/diff", songController.DiffRevisions)
+		songs.GET("/:id/revisions/:number", songController.GetRevision)
+		songs.POST("/:id/revisions/:number/restore", songController.RestoreRevision)
+		songs.POST("/:id/publish", songController.Publish)
+		songs.DELETE("/:id/publish", songController.Unpublish)
+		songs.POST("/:id/sync", songController.SyncUpstream)
+	}
+
+	/* ========= App catalog routes ========= */
+	catalog := api.Group("/catalog")
+	catalog.Use(middlewares.RequiredLoggedIn(configs.JWTSecret))
+	{
+		catalog.GET("/", songController.ListCatalog)
+		catalog.GET("/:id", songController.GetCatalogSong)
+		catalog.POST("/:id/fork", songController.ForkCatalogSong)
 	}
 
 	/* ========= Server start ========= */
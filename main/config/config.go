@@ -1,6 +1,9 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strings"
+)
 
 const (
 	prod = "production"
@@ -12,12 +15,21 @@ type Config struct {
 	Port      string `env:"APP_PORT"`
 	JWTSecret string `env:"JWT_SIGN_KEY"`
 	HMACKey   string `env:"HMAC_KEY"`
+	PublicURL string `env:"APP_PUBLIC_URL"` // Address clients reach the API at, e.g. behind a proxy
 }
 
 func (c Config) IsProd() bool {
 	return c.Env == prod
 }
 
+// Base of links handed out to clients, the app address when not configured
+func (c Config) BaseURL() string {
+	if c.PublicURL == "" {
+		return "http://" + c.Host + ":" + c.Port
+	}
+	return strings.TrimSuffix(c.PublicURL, "/")
+}
+
 func GetConfig() Config {
 	return Config{
 		Env:       os.Getenv("ENV"),
@@ -25,5 +37,6 @@ func GetConfig() Config {
 		Port:      os.Getenv("APP_PORT"),
 		JWTSecret: os.Getenv("JWT_SIGN_KEY"),
 		HMACKey:   os.Getenv("HMAC_KEY"),
+		PublicURL: os.Getenv("APP_PUBLIC_URL"),
 	}
 }
@@ -15,25 +15,31 @@ import (
 )
 
 type AccountController interface {
+	CreateUnavailability(*gin.Context)
 	CurrentAccount(*gin.Context)
 	ListActiveAccounts(*gin.Context)
+	ListUnavailability(*gin.Context)
 	Login(*gin.Context)
 	Register(*gin.Context)
+	RemoveUnavailability(*gin.Context)
 	Update(*gin.Context)
 }
 
 type accountController struct {
-	AccountUC accountusecase.AccountUseCase
-	AuthUc    authusecase.AuthUseCase
+	AccountUC        accountusecase.AccountUseCase
+	AuthUc           authusecase.AuthUseCase
+	UnavailabilityUC accountusecase.UnavailabilityUseCase
 }
 
 func NewAccaccountController(
 	accountUC accountusecase.AccountUseCase,
 	authUc authusecase.AuthUseCase,
+	unavailabilityUC accountusecase.UnavailabilityUseCase,
 ) AccountController {
 	return &accountController{
-		AccountUC: accountUC,
-		AuthUc:    authUc,
+		AccountUC:        accountUC,
+		AuthUc:           authUc,
+		UnavailabilityUC: unavailabilityUC,
 	}
 }
 
@@ -0,0 +1,160 @@
+package accountcontroller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	accountinputs "github.com/mazurco066/playliter-api-go/domain/inputs/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	accountoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/account"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary Mark a period the current account can't play
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/accounts/me/unavailability [post]
+func (ctl *accountController) CreateUnavailability(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	var unavailabilityInput accountinputs.UnavailabilityInput
+	if err := c.BindJSON(&unavailabilityInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(unavailabilityInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	// Both dates were checked by the validator
+	startsOn, _ := time.Parse(time.DateOnly, unavailabilityInput.From)
+	endsOn, _ := time.Parse(time.DateOnly, unavailabilityInput.To)
+	unavailabilityObj := account.Unavailability{
+		AccountID: user.ID,
+		StartsOn:  startsOn,
+		EndsOn:    endsOn,
+		Note:      strings.TrimSpace(unavailabilityInput.Note),
+	}
+
+	if persistErr := ctl.UnavailabilityUC.Create(&unavailabilityObj); persistErr != nil {
+		es := persistErr.Error()
+		if strings.Contains(es, "invalid period") {
+			helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", es)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting unavailability!", es)
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Unavailability successfully created!", ctl.mapToUnavailabilityOutput(&unavailabilityObj))
+}
+
+// @Summary List current and upcoming periods the current account can't play
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/accounts/me/unavailability [get]
+func (ctl *accountController) ListUnavailability(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	results, err := ctl.UnavailabilityUC.FindByAccount(user)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	var resultOutput []*accountoutputs.UnavailabilityOutput
+	for _, u := range results {
+		resultOutput = append(resultOutput, ctl.mapToUnavailabilityOutput(u))
+	}
+
+	// Empty array if no results
+	if resultOutput == nil {
+		helpers.HTTPRes(c, http.StatusOK, "Unavailability retrieved!", []string{})
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Unavailability retrieved!", resultOutput)
+}
+
+// @Summary Delete a period the current account can't play
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/accounts/me/unavailability/:unavailability_id [delete]
+func (ctl *accountController) RemoveUnavailability(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	id, err := ctl.stringToUint(c.Param(("unavailability_id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	unavailabilityResult, err := ctl.UnavailabilityUC.FindById(id)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Unavailability not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	// Accounts only manage their own periods
+	if unavailabilityResult.AccountID != user.ID {
+		helpers.HTTPRes(c, http.StatusNotFound, "Unavailability not found", nil)
+		return
+	}
+
+	if persistErr := ctl.UnavailabilityUC.Remove(unavailabilityResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error deleting unavailability!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusNoContent, "Unavailability successfully deleted!", nil)
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *accountController) stringToUint(IDParam string) (uint, error) {
+	id, err := strconv.Atoi(IDParam)
+	if err != nil {
+		return 0, errors.New("id should be a number")
+	}
+	return uint(id), nil
+}
+
+func (ctl *accountController) mapToUnavailabilityOutput(u *account.Unavailability) *accountoutputs.UnavailabilityOutput {
+	return &accountoutputs.UnavailabilityOutput{
+		ID:   u.ID,
+		From: u.StartsOn.Format(time.DateOnly),
+		To:   u.EndsOn.Format(time.DateOnly),
+		Note: u.Note,
+	}
+}
@@ -591,7 +591,7 @@ func (ctl *bandController) Update(c *gin.Context) {
 	helpers.HTTPRes(c, http.StatusOK, "Band successfully updated", bandOutput)
 }
 
-// @Summary Promote or Demote band member into admin
+// @Summary Promote or Demote band member into admin, or mark it as required on concerts
 // @Produce json
 // @Success 200 {object} Response
 // @Failure 400 {object} Response
@@ -656,19 +656,28 @@ func (ctl *bandController) UpdateMember(c *gin.Context) {
 		return
 	}
 
-	if updateInput.Role != "admin" && updateInput.Role != "member" {
+	role := memberResult.Role
+	if updateInput.Role != "" {
+		role = updateInput.Role
+	}
+	if role != "admin" && role != "member" {
 		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
 		return
 	}
+	required := memberResult.Required
+	if updateInput.Required != nil {
+		required = *updateInput.Required
+	}
 
 	memberOutput := ctl.mapToMemberOutput(memberResult)
-	if memberResult.Role == updateInput.Role {
+	if memberResult.Role == role && memberResult.Required == required {
 		helpers.HTTPRes(c, http.StatusOK, "No need to update this member!", memberOutput)
 		return
 	}
 
 	// Updating member reference
-	memberResult.Role = updateInput.Role
+	memberResult.Role = role
+	memberResult.Required = required
 	if persistErr := ctl.MemberUC.Update(memberResult); persistErr != nil {
 		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting band member!", persistErr.Error())
 		return
@@ -886,6 +895,7 @@ func (ctl *bandController) mapToMemberOutput(b *band.Member) *bandoutputs.Member
 			IsActive:     b.Account.IsActive,
 		},
 		Role:     b.Role,
+		Required: b.Required,
 		JoinedAt: b.JoinedAt,
 	}
 }
@@ -0,0 +1,130 @@
+package concertcontroller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	concertoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/concert"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary iCalendar feed of concerts, authorized by the secret token of its URL
+// @Produce text/calendar
+// @Success 200 {string} string
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/calendar/:token [get]
+func (ctl *concertController) Calendar(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	feedResult, err := ctl.CalendarUC.Find(token)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Calendar not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	content, err := ctl.CalendarUC.Render(feedResult)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Calendar not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="concerts.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", content)
+}
+
+// @Summary Create the calendar feed of the account or of one of its bands, revoking the previous one
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/accounts/me/calendar [post]
+// @Router /api/bands/:id/calendar [post]
+func (ctl *concertController) CreateCalendar(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	bandResult, ok := ctl.findFeedBand(c, user)
+	if !ok {
+		return
+	}
+
+	token, err := ctl.CalendarUC.Create(user, bandResult)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting calendar!", err.Error())
+		return
+	}
+
+	feedOutput := &concertoutputs.CalendarFeedOutput{
+		Token: token,
+		URL:   ctl.calendarURL(token),
+	}
+	helpers.HTTPRes(c, http.StatusOK, "Calendar successfully created!", feedOutput)
+}
+
+// @Summary Revoke the calendar feed of the account or of one of its bands
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/accounts/me/calendar [delete]
+// @Router /api/bands/:id/calendar [delete]
+func (ctl *concertController) RemoveCalendar(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	bandResult, ok := ctl.findFeedBand(c, user)
+	if !ok {
+		return
+	}
+
+	if persistErr := ctl.CalendarUC.Remove(user, bandResult); persistErr != nil {
+		es := persistErr.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Calendar not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error deleting calendar!", es)
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusNoContent, "Calendar successfully revoked!", nil)
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+// Band of a band feed route, nil on the account feed routes which have no ":id"
+func (ctl *concertController) findFeedBand(c *gin.Context, user *account.Account) (*band.Band, bool) {
+	if c.Param("id") == "" {
+		return nil, true
+	}
+
+	id, err := ctl.stringToUint(c.Param("id"))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return nil, false
+	}
+	return ctl.findBand(c, id, user, false)
+}
+
+// Subscription URL of the feed, on the configured public address
+func (ctl *concertController) calendarURL(token string) string {
+	return ctl.BaseURL + "/api/calendar/" + token + ".ics"
+}
@@ -1,26 +1,455 @@
 package concertcontroller
 
 import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	accountusecase "github.com/mazurco066/playliter-api-go/data/usecases/account"
+	bandusecase "github.com/mazurco066/playliter-api-go/data/usecases/band"
 	concertusecase "github.com/mazurco066/playliter-api-go/data/usecases/concert"
+	songusecase "github.com/mazurco066/playliter-api-go/data/usecases/song"
+	commoninputs "github.com/mazurco066/playliter-api-go/domain/inputs/common"
+	concertinputs "github.com/mazurco066/playliter-api-go/domain/inputs/concert"
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/concert"
+	concertoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/concert"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
 )
 
 type ConcertController interface {
+	AddSong(*gin.Context)
+	Calendar(*gin.Context)
+	Chart(*gin.Context)
+	ChordDiagrams(*gin.Context)
 	Create(*gin.Context)
+	CreateCalendar(*gin.Context)
+	Get(*gin.Context)
+	List(*gin.Context)
+	MoveSong(*gin.Context)
+	Remove(*gin.Context)
+	RemoveCalendar(*gin.Context)
+	RemoveSong(*gin.Context)
+	ReorderSongs(*gin.Context)
+	RSVPSummary(*gin.Context)
+	SaveOverrides(*gin.Context)
+	SaveRSVP(*gin.Context)
+	Update(*gin.Context)
 }
 
 type concertController struct {
-	ConcertUC concertusecase.ConcertUseCase
+	AccountUc        accountusecase.AccountUseCase
+	BandUC           bandusecase.BandUseCase
+	CalendarUC       concertusecase.CalendarUseCase
+	ConcertUC        concertusecase.ConcertUseCase
+	ConcertSongUC    concertusecase.ConcertSongUseCase
+	RSVPUC           concertusecase.RSVPUseCase
+	SongUC           songusecase.SongUseCase
+	UnavailabilityUC accountusecase.UnavailabilityUseCase
+	BaseURL          string
 }
 
 func NewConcertController(
+	accountUc accountusecase.AccountUseCase,
+	bandUc bandusecase.BandUseCase,
+	calendarUc concertusecase.CalendarUseCase,
 	concertUc concertusecase.ConcertUseCase,
+	concertSongUc concertusecase.ConcertSongUseCase,
+	rsvpUc concertusecase.RSVPUseCase,
+	songUc songusecase.SongUseCase,
+	unavailabilityUc accountusecase.UnavailabilityUseCase,
+	baseURL string,
 ) ConcertController {
 	return &concertController{
-		ConcertUC: concertUc,
+		AccountUc:        accountUc,
+		BandUC:           bandUc,
+		CalendarUC:       calendarUc,
+		ConcertUC:        concertUc,
+		ConcertSongUC:    concertSongUc,
+		RSVPUC:           rsvpUc,
+		SongUC:           songUc,
+		UnavailabilityUC: unavailabilityUc,
+		BaseURL:          baseURL,
 	}
 }
 
+// @Summary Register a new concert into a band
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/concerts [post]
 func (ctl *concertController) Create(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	var newConcert concertinputs.CreateInput
+	if err := c.BindJSON(&newConcert); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(newConcert); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	bandResult, ok := ctl.findBand(c, newConcert.BandID, user, true)
+	if !ok {
+		return
+	}
+
+	concertObj := concert.Concert{
+		Title:       newConcert.Title,
+		Description: newConcert.Description,
+		Date:        newConcert.Date,
+		Venue:       strings.TrimSpace(newConcert.Venue),
+		TimeZone:    newConcert.TimeZone,
+		BandID:      bandResult.ID,
+		Band:        *bandResult,
+	}
+
+	// The concert is booked anyway, admins are warned about the missing members
+	warnings, err := ctl.availabilityWarnings(&concertObj, bandResult.Members)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	if persistErr := ctl.ConcertUC.Create(&concertObj); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting concert!", persistErr.Error())
+		return
+	}
+
+	concertOutput := ctl.mapToConcertOutput(&concertObj)
+	concertOutput.Warnings = warnings
+	helpers.HTTPRes(c, http.StatusOK, "Concert successfully created!", concertOutput)
+}
+
+// @Summary Get concert with its setlist
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/concerts/:id [get]
+func (ctl *concertController) Get(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	concertResult, ok := ctl.findConcert(c, user, false)
+	if !ok {
+		return
+	}
+
+	concertOutput := ctl.mapToConcertOutput(concertResult)
+	helpers.HTTPRes(c, http.StatusOK, "Concert retrieved!", concertOutput)
+}
+
+// @Summary List band concerts, optionally upcoming or past ones within a date range
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/bands/:id/concerts [get]
+func (ctl *concertController) List(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	id, err := ctl.stringToUint(c.Param(("id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	bandResult, ok := ctl.findBand(c, id, user, false)
+	if !ok {
+		return
+	}
+
+	var listParams concertinputs.ListParams
+	if err := c.BindQuery(&listParams); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(listParams); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	var paging commoninputs.PagingParams
+	if err := c.BindQuery(&paging); err != nil {
+		paging.Limit = 100
+		paging.Offset = 0
+	}
+
+	results, err := ctl.ConcertUC.FindByBand(bandResult, &listParams, &paging)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	var resultOutput []*concertoutputs.ConcertOutput
+	for _, r := range results {
+		resultOutput = append(resultOutput, ctl.mapToConcertOutput(r))
+	}
+
+	// Empty array if no results
+	if resultOutput == nil {
+		helpers.HTTPRes(c, http.StatusOK, "Concerts successfully listed!", []string{})
+		return
+	}
+
+	// Formatted array
+	helpers.HTTPRes(c, http.StatusOK, "Concerts successfully listed!", resultOutput)
+}
+
+// @Summary Update concert data
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/concerts/:id [patch]
+func (ctl *concertController) Update(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	concertResult, ok := ctl.findConcert(c, user, true)
+	if !ok {
+		return
+	}
+
+	var updateInput concertinputs.UpdateInput
+	if err := c.BindJSON(&updateInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(updateInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	if updateInput.Title != "" {
+		concertResult.Title = updateInput.Title
+	}
+	if updateInput.Description != nil {
+		concertResult.Description = *updateInput.Description
+	}
+	if updateInput.Date != nil {
+		concertResult.Date = *updateInput.Date
+	}
+	if updateInput.Venue != nil {
+		concertResult.Venue = strings.TrimSpace(*updateInput.Venue)
+	}
+	if updateInput.TimeZone != nil {
+		concertResult.TimeZone = *updateInput.TimeZone
+	}
+
+	// Moving the concert to another day warns like booking it
+	var warnings []string
+	if updateInput.Date != nil || updateInput.TimeZone != nil {
+		result, err := ctl.availabilityWarnings(concertResult, concertResult.Band.Members)
+		if err != nil {
+			helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+			return
+		}
+		warnings = result
+	}
+
+	if persistErr := ctl.ConcertUC.Update(concertResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting concert!", persistErr.Error())
+		return
+	}
 
+	concertOutput := ctl.mapToConcertOutput(concertResult)
+	concertOutput.Warnings = warnings
+	helpers.HTTPRes(c, http.StatusOK, "Concert successfully updated!", concertOutput)
+}
+
+// @Summary Delete concert and its setlist
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/concerts/:id [delete]
+func (ctl *concertController) Remove(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	concertResult, ok := ctl.findConcert(c, user, true)
+	if !ok {
+		return
+	}
+
+	if persistErr := ctl.ConcertUC.Remove(concertResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error deleting concert!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusNoContent, "Concert successfully deleted!", nil)
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *concertController) validateTokenData(c *gin.Context) *account.Account {
+	id, exists := c.Get("user_email")
+	if exists == false {
+		return nil
+	}
+
+	user, err := ctl.AccountUc.GetAccountByEmail(id.(string))
+	if err != nil {
+		return nil
+	}
+
+	return user
+}
+
+// Loads the concert from the ":id" param and checks if the user is allowed
+// to access it, responding with the proper error when it is not. Members
+// can read concerts while changes are up to the band admins.
+func (ctl *concertController) findConcert(c *gin.Context, user *account.Account, adminOnly bool) (*concert.Concert, bool) {
+	id, err := ctl.stringToUint(c.Param(("id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return nil, false
+	}
+
+	concertResult, err := ctl.ConcertUC.FindById(id)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Concert not found", nil)
+			return nil, false
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return nil, false
+	}
+
+	if !ctl.isAllowed(&concertResult.Band, user, adminOnly) {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return nil, false
+	}
+
+	return concertResult, true
+}
+
+func (ctl *concertController) findBand(c *gin.Context, id uint, user *account.Account, adminOnly bool) (*band.Band, bool) {
+	bandResult, err := ctl.BandUC.FindById(id)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Band not found", nil)
+			return nil, false
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return nil, false
+	}
+
+	if !ctl.isAllowed(bandResult, user, adminOnly) {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return nil, false
+	}
+
+	return bandResult, true
+}
+
+func (ctl *concertController) isAllowed(b *band.Band, user *account.Account, adminOnly bool) bool {
+	if b.OwnerID == user.ID {
+		return true
+	}
+	if adminOnly {
+		return ctl.isBandAdmin(b.Members, user.ID)
+	}
+	return ctl.isBandMember(b.Members, user.ID)
+}
+
+func (ctl *concertController) isBandMember(members []band.Member, accountID uint) bool {
+	for _, member := range members {
+		if member.AccountID == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+func (ctl *concertController) isBandAdmin(members []band.Member, accountID uint) bool {
+	for _, member := range members {
+		if member.AccountID == accountID && member.Role == "admin" {
+			return true
+		}
+	}
+	return false
+}
+
+func (ctl *concertController) stringToUint(IDParam string) (uint, error) {
+	concertID, err := strconv.Atoi(IDParam)
+	if err != nil {
+		return 0, errors.New("id should be a number")
+	}
+	return uint(concertID), nil
+}
+
+func (ctl *concertController) mapToConcertOutput(c *concert.Concert) *concertoutputs.ConcertOutput {
+	output := &concertoutputs.ConcertOutput{
+		ID:          c.ID,
+		Title:       c.Title,
+		Description: c.Description,
+		Date:        c.Date,
+		Venue:       c.Venue,
+		TimeZone:    c.TimeZone,
+		BandID:      c.BandID,
+		Songs:       []*concertoutputs.ConcertSongOutput{},
+	}
+	for _, entry := range c.Songs {
+		entryOutput := &concertoutputs.ConcertSongOutput{
+			ID:             entry.ID,
+			SongID:         entry.SongID,
+			Position:       entry.Position,
+			Title:          entry.Song.Title,
+			Writter:        entry.Song.Writter,
+			Tone:           entry.Song.Tone,
+			Key:            entry.Key,
+			Capo:           entry.Capo,
+			Arrangement:    []*concertoutputs.SetlistSectionOutput{},
+			LeadVocalistID: entry.LeadVocalistID,
+			Segue:          entry.Segue,
+			Transition:     entry.Transition,
+		}
+		if entry.LeadVocalist != nil {
+			entryOutput.LeadVocalist = entry.LeadVocalist.Name
+		}
+		for _, item := range entry.Arrangement {
+			entryOutput.Arrangement = append(entryOutput.Arrangement, &concertoutputs.SetlistSectionOutput{
+				SectionID: item.SectionID,
+				Label:     item.Section.Label,
+				Repeat:    item.Repeat,
+			})
+		}
+		output.Songs = append(output.Songs, entryOutput)
+	}
+	return output
 }
@@ -0,0 +1,84 @@
+package concertcontroller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary Chord diagrams of every chord used along a concert setlist
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/concerts/:id/chords [get]
+func (ctl *concertController) ChordDiagrams(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	concertResult, ok := ctl.findConcert(c, user, false)
+	if !ok {
+		return
+	}
+
+	var diagramParams songinputs.DiagramParams
+	if err := c.BindQuery(&diagramParams); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(diagramParams); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	// Diagrams follow the key and capo each entry is played with
+	songs := []*song.Song{}
+	for i := range concertResult.Songs {
+		chartResult, err := ctl.performanceChart(&concertResult.Songs[i])
+		if err != nil {
+			helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		songs = append(songs, chartResult)
+	}
+
+	result, err := ctl.SongUC.ChordDiagrams(songs, diagramParams.Instrument, diagramParams.Tuning)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", err.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Chord diagrams successfully generated!", ctl.mapToChordDiagramSetOutput(result))
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *concertController) mapToChordDiagramSetOutput(set *song.ChordDiagramSet) *songoutputs.ChordDiagramSetOutput {
+	diagrams := []*songoutputs.ChordDiagramOutput{}
+	for _, d := range set.Diagrams {
+		diagrams = append(diagrams, &songoutputs.ChordDiagramOutput{
+			Chord:    d.Chord,
+			Frets:    d.Frets,
+			BaseFret: d.BaseFret,
+			Keys:     d.Keys,
+			Notes:    d.Notes,
+			Svg:      d.Svg,
+		})
+	}
+	return &songoutputs.ChordDiagramSetOutput{
+		Instrument: set.Instrument,
+		Tuning:     set.Tuning,
+		Diagrams:   diagrams,
+		Unvoiced:   set.Unvoiced,
+	}
+}
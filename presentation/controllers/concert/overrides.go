@@ -0,0 +1,213 @@
+package concertcontroller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	concertinputs "github.com/mazurco066/playliter-api-go/domain/inputs/concert"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/concert"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary Replace the overrides a setlist entry is played with
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/concerts/:id/songs/:entry_id/overrides [put]
+func (ctl *concertController) SaveOverrides(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	concertResult, ok := ctl.findConcert(c, user, true)
+	if !ok {
+		return
+	}
+
+	entry, ok := ctl.findEntry(c, concertResult)
+	if !ok {
+		return
+	}
+
+	var overridesInput concertinputs.OverridesInput
+	if err := c.BindJSON(&overridesInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(overridesInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	// The lead vocalist must be someone playing on the band
+	if id := overridesInput.LeadVocalistID; id != nil &&
+		concertResult.Band.OwnerID != *id && !ctl.isBandMember(concertResult.Band.Members, *id) {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Lead vocalist is not a member of the band", nil)
+		return
+	}
+
+	entry.Key = overridesInput.Key
+	entry.Capo = overridesInput.Capo
+	entry.LeadVocalistID = overridesInput.LeadVocalistID
+	entry.Segue = overridesInput.Segue
+	entry.Transition = overridesInput.Transition
+
+	items := []*concert.SetlistSection{}
+	for _, itemInput := range overridesInput.Arrangement {
+		items = append(items, &concert.SetlistSection{
+			SectionID: itemInput.SectionID,
+			Repeat:    itemInput.Repeat,
+		})
+	}
+
+	if persistErr := ctl.ConcertSongUC.SaveOverrides(entry, items); persistErr != nil {
+		es := persistErr.Error()
+		if strings.Contains(es, "invalid key") || strings.Contains(es, "does not belong") {
+			helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", es)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting concert song!", es)
+		return
+	}
+
+	ctl.respondSetlist(c, concertResult, "Overrides successfully saved!")
+}
+
+// @Summary Chart of a setlist entry as played on the concert
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/concerts/:id/songs/:entry_id/chart [get]
+func (ctl *concertController) Chart(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	concertResult, ok := ctl.findConcert(c, user, false)
+	if !ok {
+		return
+	}
+
+	entry, ok := ctl.findEntry(c, concertResult)
+	if !ok {
+		return
+	}
+
+	var renderParams songinputs.RenderParams
+	if err := c.BindQuery(&renderParams); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(renderParams); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	chartResult, err := ctl.performanceChart(entry)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	switch c.Query("notation") {
+	case "", "letters":
+	case "nashville":
+		if notationErr := ctl.SongUC.ToNashville(chartResult); notationErr != nil {
+			helpers.HTTPRes(c, http.StatusBadRequest, notationErr.Error(), nil)
+			return
+		}
+	default:
+		helpers.HTTPRes(c, http.StatusBadRequest, "notation should be letters or nashville", nil)
+		return
+	}
+
+	// Same modes and formats of the song chart, without any the body is
+	// sent as written
+	if renderParams.Mode == "" && renderParams.Format == "" {
+		helpers.HTTPRes(c, http.StatusOK, "Song chart rendered!", ctl.mapToChartOutput(chartResult, chartResult.Body))
+		return
+	}
+
+	document := ctl.SongUC.RenderChart(chartResult, renderParams.Mode, renderParams.Format)
+	switch renderParams.Format {
+	case "text":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(document.Text))
+	case "html":
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(document.Text))
+	case "json":
+		helpers.HTTPRes(c, http.StatusOK, "Song chart rendered!", ctl.mapToChartDocumentOutput(chartResult, document))
+	default:
+		helpers.HTTPRes(c, http.StatusOK, "Song chart rendered!", ctl.mapToChartOutput(chartResult, document.Text))
+	}
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+// Charts the entry song with its overrides applied on a copy of it
+func (ctl *concertController) performanceChart(entry *concert.ConcertSong) (*song.Song, error) {
+	chartResult, err := ctl.ConcertSongUC.Chart(entry)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Key != nil || entry.Capo != nil {
+		if err := ctl.SongUC.Retune(chartResult, entry.Key, entry.Capo); err != nil {
+			return nil, err
+		}
+	}
+	return chartResult, nil
+}
+
+func (ctl *concertController) mapToChartOutput(s *song.Song, body string) *songoutputs.ChartOutput {
+	return &songoutputs.ChartOutput{
+		ID:    s.ID,
+		Title: s.Title,
+		Tone:  s.Tone,
+		Body:  body,
+	}
+}
+
+func (ctl *concertController) mapToChartDocumentOutput(s *song.Song, d *song.ChartDocument) *songoutputs.ChartDocumentOutput {
+	output := &songoutputs.ChartDocumentOutput{
+		ID:       s.ID,
+		Title:    s.Title,
+		Tone:     s.Tone,
+		Mode:     d.Mode,
+		Sections: []*songoutputs.ChartSectionOutput{},
+	}
+	for _, section := range d.Sections {
+		sectionOutput := &songoutputs.ChartSectionOutput{
+			Label: section.Label,
+			Lines: []*songoutputs.ChartLineOutput{},
+		}
+		for _, line := range section.Lines {
+			lineOutput := &songoutputs.ChartLineOutput{
+				Kind:     line.Kind,
+				Segments: []*songoutputs.ChartSegmentOutput{},
+			}
+			for _, segment := range line.Segments {
+				lineOutput.Segments = append(lineOutput.Segments, &songoutputs.ChartSegmentOutput{
+					Chord:  segment.Chord,
+					Lyrics: segment.Lyrics,
+				})
+			}
+			sectionOutput.Lines = append(sectionOutput.Lines, lineOutput)
+		}
+		output.Sections = append(output.Sections, sectionOutput)
+	}
+	return output
+}
@@ -0,0 +1,181 @@
+package concertcontroller
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	concertinputs "github.com/mazurco066/playliter-api-go/domain/inputs/concert"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/concert"
+	accountoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/account"
+	concertoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/concert"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary Answer whether the current account can play a concert
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/concerts/:id/rsvp [put]
+func (ctl *concertController) SaveRSVP(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	concertResult, ok := ctl.findConcert(c, user, false)
+	if !ok {
+		return
+	}
+
+	var rsvpInput concertinputs.RSVPInput
+	if err := c.BindJSON(&rsvpInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(rsvpInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	rsvpObj, err := ctl.RSVPUC.Find(concertResult, user)
+	if err != nil {
+		if !strings.Contains(err.Error(), "not found") {
+			helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+			return
+		}
+		rsvpObj = &concert.RSVP{
+			ConcertID: concertResult.ID,
+			AccountID: user.ID,
+		}
+	}
+	rsvpObj.Response = rsvpInput.Response
+	rsvpObj.Note = strings.TrimSpace(rsvpInput.Note)
+
+	if persistErr := ctl.RSVPUC.Save(rsvpObj); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting RSVP!", persistErr.Error())
+		return
+	}
+
+	rsvpOutput := &concertoutputs.RSVPOutput{
+		ConcertID: rsvpObj.ConcertID,
+		AccountID: rsvpObj.AccountID,
+		Response:  rsvpObj.Response,
+		Note:      rsvpObj.Note,
+	}
+	helpers.HTTPRes(c, http.StatusOK, "RSVP successfully saved!", rsvpOutput)
+}
+
+// @Summary Answers of the whole band for a concert, with who is unavailable on its day
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/concerts/:id/rsvps [get]
+func (ctl *concertController) RSVPSummary(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	concertResult, ok := ctl.findConcert(c, user, true)
+	if !ok {
+		return
+	}
+
+	accountIDs := []uint{concertResult.Band.OwnerID}
+	for _, member := range concertResult.Band.Members {
+		accountIDs = append(accountIDs, member.AccountID)
+	}
+	unavailable, err := ctl.UnavailabilityUC.FindConflicts(accountIDs, ctl.concertDay(concertResult))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	summary, err := ctl.RSVPUC.Summary(concertResult, unavailable)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "RSVP summary retrieved!", ctl.mapToRSVPSummaryOutput(summary))
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+// Warns about every required member marked as unavailable on the concert day
+func (ctl *concertController) availabilityWarnings(concertObj *concert.Concert, members []band.Member) ([]string, error) {
+	var accountIDs []uint
+	for _, member := range members {
+		if member.Required {
+			accountIDs = append(accountIDs, member.AccountID)
+		}
+	}
+
+	day := ctl.concertDay(concertObj)
+	unavailable, err := ctl.UnavailabilityUC.FindConflicts(accountIDs, day)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	for _, period := range unavailable {
+		warning := fmt.Sprintf("%s is unavailable from %s to %s",
+			period.Account.Name, period.StartsOn.Format(time.DateOnly), period.EndsOn.Format(time.DateOnly))
+		if period.Note != "" {
+			warning += " (" + period.Note + ")"
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings, nil
+}
+
+// Calendar day the concert is played on, in its own time zone
+func (ctl *concertController) concertDay(concertObj *concert.Concert) time.Time {
+	loc, err := time.LoadLocation(concertObj.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	year, month, day := concertObj.Date.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (ctl *concertController) mapToRSVPSummaryOutput(summary *concert.RSVPSummary) *concertoutputs.RSVPSummaryOutput {
+	output := &concertoutputs.RSVPSummaryOutput{
+		Yes:     summary.Yes,
+		No:      summary.No,
+		Maybe:   summary.Maybe,
+		Pending: summary.Pending,
+		Members: []*concertoutputs.RSVPStatusOutput{},
+	}
+	for _, status := range summary.Members {
+		statusOutput := &concertoutputs.RSVPStatusOutput{
+			Account: &accountoutputs.AccountPublicOutput{
+				ID:   status.Account.ID,
+				Name: status.Account.Name,
+			},
+			Required: status.Required,
+			Response: status.Response,
+			Note:     status.Note,
+		}
+		if status.Account.Avatar != nil {
+			statusOutput.Account.Avatar = *status.Account.Avatar
+		}
+		if status.Unavailable != nil {
+			statusOutput.Unavailable = true
+			statusOutput.UnavailableNote = status.Unavailable.Note
+		}
+		output.Members = append(output.Members, statusOutput)
+	}
+	return output
+}
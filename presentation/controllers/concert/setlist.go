@@ -0,0 +1,216 @@
+package concertcontroller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	concertinputs "github.com/mazurco066/playliter-api-go/domain/inputs/concert"
+	"github.com/mazurco066/playliter-api-go/domain/models/concert"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary Add a band song to the concert setlist, at a position or at its end
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/concerts/:id/songs [post]
+func (ctl *concertController) AddSong(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	concertResult, ok := ctl.findConcert(c, user, true)
+	if !ok {
+		return
+	}
+
+	var addInput concertinputs.AddSongInput
+	if err := c.BindJSON(&addInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(addInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	songResult, err := ctl.SongUC.FindById(addInput.SongID)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Song not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	// Only songs of the band can be played on its concerts
+	if songResult.BandID != concertResult.BandID {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Song does not belong to the concert band", nil)
+		return
+	}
+
+	entryObj := concert.ConcertSong{
+		ConcertID: concertResult.ID,
+		SongID:    songResult.ID,
+	}
+	if persistErr := ctl.ConcertSongUC.Insert(&entryObj, addInput.Position); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting concert song!", persistErr.Error())
+		return
+	}
+
+	ctl.respondSetlist(c, concertResult, "Song successfully added to the concert!")
+}
+
+// @Summary Move a setlist entry to another position
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/concerts/:id/songs/:entry_id [patch]
+func (ctl *concertController) MoveSong(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	concertResult, ok := ctl.findConcert(c, user, true)
+	if !ok {
+		return
+	}
+
+	entry, ok := ctl.findEntry(c, concertResult)
+	if !ok {
+		return
+	}
+
+	var moveInput concertinputs.MoveSongInput
+	if err := c.BindJSON(&moveInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(moveInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	if persistErr := ctl.ConcertSongUC.Move(entry, *moveInput.Position); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting concert song!", persistErr.Error())
+		return
+	}
+
+	ctl.respondSetlist(c, concertResult, "Song successfully moved!")
+}
+
+// @Summary Remove an entry from the concert setlist
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/concerts/:id/songs/:entry_id [delete]
+func (ctl *concertController) RemoveSong(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	concertResult, ok := ctl.findConcert(c, user, true)
+	if !ok {
+		return
+	}
+
+	entry, ok := ctl.findEntry(c, concertResult)
+	if !ok {
+		return
+	}
+
+	if persistErr := ctl.ConcertSongUC.Remove(entry); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error deleting concert song!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusNoContent, "Song successfully removed from the concert!", nil)
+}
+
+// @Summary Replace the whole setlist order in one call
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/concerts/:id/songs [put]
+func (ctl *concertController) ReorderSongs(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	concertResult, ok := ctl.findConcert(c, user, true)
+	if !ok {
+		return
+	}
+
+	var reorderInput concertinputs.ReorderInput
+	if err := c.BindJSON(&reorderInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(reorderInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	if persistErr := ctl.ConcertSongUC.Reorder(concertResult, reorderInput.Entries); persistErr != nil {
+		es := persistErr.Error()
+		if strings.Contains(es, "setlist order") {
+			helpers.HTTPRes(c, http.StatusBadRequest, es, nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting setlist!", es)
+		return
+	}
+
+	ctl.respondSetlist(c, concertResult, "Setlist successfully reordered!")
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *concertController) findEntry(c *gin.Context, concertObj *concert.Concert) (*concert.ConcertSong, bool) {
+	entryID, err := ctl.stringToUint(c.Param(("entry_id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return nil, false
+	}
+
+	for i := range concertObj.Songs {
+		if concertObj.Songs[i].ID == entryID {
+			return &concertObj.Songs[i], true
+		}
+	}
+	helpers.HTTPRes(c, http.StatusNotFound, "Setlist entry not found", nil)
+	return nil, false
+}
+
+// Responds with the concert reloaded, so the setlist comes in its new order
+func (ctl *concertController) respondSetlist(c *gin.Context, concertObj *concert.Concert, message string) {
+	concertResult, err := ctl.ConcertUC.FindById(concertObj.ID)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+	helpers.HTTPRes(c, http.StatusOK, message, ctl.mapToConcertOutput(concertResult))
+}
@@ -0,0 +1,345 @@
+package songcontroller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	accountoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/account"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary List song annotation threads visible to the current account
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/annotations [get]
+func (ctl *songController) ListAnnotations(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	results, err := ctl.AnnotationUC.FindThreads(songResult, user, ctl.isModerator(songResult, user))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	resultOutput := []*songoutputs.AnnotationOutput{}
+	for _, a := range results {
+		resultOutput = append(resultOutput, ctl.mapToAnnotationOutput(a))
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Annotations successfully listed!", resultOutput)
+}
+
+// @Summary Annotate a line or section of a song, or reply to a thread
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/annotations [post]
+func (ctl *songController) CreateAnnotation(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	var newAnnotation songinputs.AnnotationInput
+	if err := c.BindJSON(&newAnnotation); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(newAnnotation); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	annotationObj := song.Annotation{
+		AuthorID:  user.ID,
+		Author:    *user,
+		ParentID:  newAnnotation.ParentID,
+		Kind:      newAnnotation.Kind,
+		Private:   newAnnotation.Private,
+		Line:      newAnnotation.Line,
+		SectionID: newAnnotation.SectionID,
+		Body:      strings.TrimSpace(newAnnotation.Body),
+	}
+
+	if persistErr := ctl.AnnotationUC.Create(&annotationObj, songResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Annotation successfully created!", ctl.mapToAnnotationOutput(&annotationObj))
+}
+
+// @Summary Edit the text of an annotation, only its author can
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/annotations/:annotation_id [patch]
+func (ctl *songController) UpdateAnnotation(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	annotationResult, ok := ctl.findAnnotation(c, songResult, user)
+	if !ok {
+		return
+	}
+	if annotationResult.AuthorID != user.ID {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	var updateInput songinputs.UpdateAnnotationInput
+	if err := c.BindJSON(&updateInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(updateInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	annotationResult.Body = strings.TrimSpace(updateInput.Body)
+	if persistErr := ctl.AnnotationUC.Update(annotationResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting annotation!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Annotation successfully updated!", ctl.mapToAnnotationOutput(annotationResult))
+}
+
+// @Summary Delete an annotation and its replies, by its author or a band admin
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/annotations/:annotation_id [delete]
+func (ctl *songController) RemoveAnnotation(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	annotationResult, ok := ctl.findAnnotation(c, songResult, user)
+	if !ok {
+		return
+	}
+	if annotationResult.AuthorID != user.ID && !ctl.isModerator(songResult, user) {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	if persistErr := ctl.AnnotationUC.Remove(annotationResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error deleting annotation!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusNoContent, "Annotation successfully deleted!", nil)
+}
+
+// @Summary Mark an annotation thread as resolved, by its author or a band admin
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/annotations/:annotation_id/resolve [post]
+func (ctl *songController) ResolveAnnotation(c *gin.Context) {
+	ctl.setResolved(c, true)
+}
+
+// @Summary Reopen a resolved annotation thread
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/annotations/:annotation_id/resolve [delete]
+func (ctl *songController) ReopenAnnotation(c *gin.Context) {
+	ctl.setResolved(c, false)
+}
+
+// @Summary Hide an annotation from the band members, band admins only
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/annotations/:annotation_id/hide [post]
+func (ctl *songController) HideAnnotation(c *gin.Context) {
+	ctl.setHidden(c, true)
+}
+
+// @Summary Show again a hidden annotation, band admins only
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/annotations/:annotation_id/hide [delete]
+func (ctl *songController) UnhideAnnotation(c *gin.Context) {
+	ctl.setHidden(c, false)
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *songController) setResolved(c *gin.Context, resolved bool) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	annotationResult, ok := ctl.findAnnotation(c, songResult, user)
+	if !ok {
+		return
+	}
+	if annotationResult.AuthorID != user.ID && !ctl.isModerator(songResult, user) {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	if persistErr := ctl.AnnotationUC.Resolve(annotationResult, user, resolved); persistErr != nil {
+		if strings.Contains(persistErr.Error(), "only a thread") {
+			helpers.HTTPRes(c, http.StatusBadRequest, persistErr.Error(), nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting annotation!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Annotation successfully updated!", ctl.mapToAnnotationOutput(annotationResult))
+}
+
+func (ctl *songController) setHidden(c *gin.Context, hidden bool) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, true)
+	if !ok {
+		return
+	}
+
+	annotationResult, ok := ctl.findAnnotation(c, songResult, user)
+	if !ok {
+		return
+	}
+
+	if persistErr := ctl.AnnotationUC.Hide(annotationResult, hidden); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting annotation!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Annotation successfully updated!", ctl.mapToAnnotationOutput(annotationResult))
+}
+
+// Loads the annotation from the ":annotation_id" param, annotations the user
+// can not see are reported as not found
+func (ctl *songController) findAnnotation(c *gin.Context, s *song.Song, user *account.Account) (*song.Annotation, bool) {
+	annotationID, err := ctl.stringToUint(c.Param(("annotation_id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return nil, false
+	}
+
+	annotationResult, err := ctl.AnnotationUC.FindById(annotationID)
+	if err == nil && annotationResult.AuthorID != user.ID {
+		if annotationResult.Private || annotationResult.Hidden && !ctl.isModerator(s, user) {
+			annotationResult = nil
+		}
+	}
+	if err != nil || annotationResult == nil || annotationResult.SongID != s.ID {
+		if err == nil || strings.Contains(err.Error(), "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Annotation not found", nil)
+			return nil, false
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return nil, false
+	}
+
+	return annotationResult, true
+}
+
+// Band owners and admins moderate the annotations of the band songs
+func (ctl *songController) isModerator(s *song.Song, user *account.Account) bool {
+	return s.Band.OwnerID == user.ID || ctl.isBandAdmin(s.Band.Members, user.ID)
+}
+
+func (ctl *songController) mapToAnnotationOutput(a *song.Annotation) *songoutputs.AnnotationOutput {
+	output := &songoutputs.AnnotationOutput{
+		ID:         a.ID,
+		Kind:       a.Kind,
+		Private:    a.Private,
+		LineText:   a.LineText,
+		Orphaned:   a.Orphaned,
+		Body:       a.Body,
+		Resolved:   a.Resolved,
+		ResolvedAt: a.ResolvedAt,
+		Hidden:     a.Hidden,
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+		Author: &accountoutputs.AccountPublicOutput{
+			ID:   a.Author.ID,
+			Name: a.Author.Name,
+		},
+		Replies: []*songoutputs.AnnotationOutput{},
+	}
+	if a.Author.Avatar != nil {
+		output.Author.Avatar = *a.Author.Avatar
+	}
+	if a.Line != nil {
+		output.Line = *a.Line
+	}
+	if a.SectionID != nil {
+		output.SectionID = *a.SectionID
+	}
+	for i := range a.Replies {
+		output.Replies = append(output.Replies, ctl.mapToAnnotationOutput(&a.Replies[i]))
+	}
+	return output
+}
@@ -0,0 +1,117 @@
+package songcontroller
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/infra/bulkimport"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+const (
+	maxArchiveSize = 10 << 20 // 10 MB
+	maxImportRows  = 500
+)
+
+var bulkImportExtensions = []string{".csv", ".zip"}
+
+// @Summary Import songs in bulk from a CSV file or a ZIP of .txt and .cho files
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/bands/:id/songs/import [post]
+func (ctl *songController) ImportSongs(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	bandResult, ok := ctl.findBand(c, user, false)
+	if !ok {
+		return
+	}
+
+	var importParams songinputs.ImportParams
+	if err := c.BindQuery(&importParams); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	content, fileName, err := ctl.readUpload(c, bulkImportExtensions, maxArchiveSize)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	var records []*bulkimport.Record
+	if strings.ToLower(filepath.Ext(fileName)) == ".zip" {
+		records, err = bulkimport.ParseZip([]byte(content), maxImportRows)
+	} else {
+		records, err = bulkimport.ParseCSV([]byte(content))
+	}
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	if len(records) > maxImportRows {
+		helpers.HTTPRes(c, http.StatusBadRequest, fmt.Sprintf("File has more than %d songs", maxImportRows), nil)
+		return
+	}
+
+	tags, err := ctl.TagUC.FindByBand(bandResult)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	report, err := ctl.SongUC.Import(records, bandResult, tags, user, importParams.DryRun)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting songs!", err.Error())
+		return
+	}
+
+	reportOutput := ctl.mapToImportReportOutput(report)
+	if report.DryRun {
+		helpers.HTTPRes(c, http.StatusOK, "Import preview successfully generated!", reportOutput)
+		return
+	}
+	if report.Failed > 0 {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Some rows failed, no song was imported", reportOutput)
+		return
+	}
+	helpers.HTTPRes(c, http.StatusOK, "Songs successfully imported!", reportOutput)
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *songController) mapToImportReportOutput(r *song.ImportReport) *songoutputs.ImportReportOutput {
+	rows := []*songoutputs.ImportRowOutput{}
+	for _, row := range r.Rows {
+		rowOutput := &songoutputs.ImportRowOutput{
+			Row:    row.Row,
+			Source: row.Source,
+			Title:  row.Title,
+			Status: row.Status,
+			Reason: row.Reason,
+		}
+		if row.Song != nil {
+			rowOutput.SongID = row.Song.ID
+		}
+		rows = append(rows, rowOutput)
+	}
+	return &songoutputs.ImportReportOutput{
+		DryRun:  r.DryRun,
+		Created: r.Created,
+		Skipped: r.Skipped,
+		Failed:  r.Failed,
+		Rows:    rows,
+	}
+}
@@ -0,0 +1,260 @@
+package songcontroller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	commoninputs "github.com/mazurco066/playliter-api-go/domain/inputs/common"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary List or search songs of the public catalog
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/catalog [get]
+func (ctl *songController) ListCatalog(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	var catalogParams songinputs.CatalogParams
+	if err := c.BindQuery(&catalogParams); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(catalogParams); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	var paging commoninputs.PagingParams
+	if err := c.BindQuery(&paging); err != nil {
+		paging.Limit = 100
+		paging.Offset = 0
+	}
+
+	results, err := ctl.SongUC.FindPublic(catalogParams.Query, &paging)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	var resultOutput []*songoutputs.SongOutput
+	for _, s := range results {
+		resultOutput = append(resultOutput, ctl.mapToSongOutput(s))
+	}
+
+	// Empty array if no results
+	if resultOutput == nil {
+		helpers.HTTPRes(c, http.StatusOK, "Catalog successfully listed!", []string{})
+		return
+	}
+
+	// Formatted array
+	helpers.HTTPRes(c, http.StatusOK, "Catalog successfully listed!", resultOutput)
+}
+
+// @Summary Get a song of the public catalog
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/catalog/:id [get]
+func (ctl *songController) GetCatalogSong(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findCatalogSong(c)
+	if !ok {
+		return
+	}
+
+	// Read time transposition and notation (?key=G, ?notation=nashville), never persisted
+	if chartErr := ctl.chartFromQuery(c, songResult); chartErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, chartErr.Error(), nil)
+		return
+	}
+
+	songOutput := ctl.mapToSongOutput(songResult)
+	helpers.HTTPRes(c, http.StatusOK, "Song retrieved!", songOutput)
+}
+
+// @Summary Fork a catalog song into a band
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/catalog/:id/fork [post]
+func (ctl *songController) ForkCatalogSong(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findCatalogSong(c)
+	if !ok {
+		return
+	}
+
+	var forkInput songinputs.ForkInput
+	if err := c.BindJSON(&forkInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(forkInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	bandResult, err := ctl.BandUC.FindById(forkInput.BandID)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Band not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	// Verify if user is a band admin
+	if bandResult.OwnerID != user.ID && !ctl.isBandAdmin(bandResult.Members, user.ID) {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	fork, err := ctl.SongUC.Fork(songResult, bandResult, user)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting song!", err.Error())
+		return
+	}
+
+	songOutput := ctl.mapToSongOutput(fork)
+	helpers.HTTPRes(c, http.StatusOK, "Song successfully forked!", songOutput)
+}
+
+// @Summary Publish a song to the public catalog
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/publish [post]
+func (ctl *songController) Publish(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, true)
+	if !ok {
+		return
+	}
+
+	if persistErr := ctl.SongUC.Publish(songResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting song!", persistErr.Error())
+		return
+	}
+
+	songOutput := ctl.mapToSongOutput(songResult)
+	helpers.HTTPRes(c, http.StatusOK, "Song successfully published", songOutput)
+}
+
+// @Summary Remove a song from the public catalog
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/publish [delete]
+func (ctl *songController) Unpublish(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, true)
+	if !ok {
+		return
+	}
+
+	if persistErr := ctl.SongUC.Unpublish(songResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting song!", persistErr.Error())
+		return
+	}
+
+	songOutput := ctl.mapToSongOutput(songResult)
+	helpers.HTTPRes(c, http.StatusOK, "Song successfully unpublished", songOutput)
+}
+
+// @Summary Pull the upstream catalog content into a forked song
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/sync [post]
+func (ctl *songController) SyncUpstream(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, true)
+	if !ok {
+		return
+	}
+
+	if syncErr := ctl.SongUC.SyncUpstream(songResult, user); syncErr != nil {
+		es := syncErr.Error()
+		if strings.Contains(es, "not a fork") || strings.Contains(es, "no longer available") {
+			helpers.HTTPRes(c, http.StatusBadRequest, es, nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting song!", es)
+		return
+	}
+
+	songOutput := ctl.mapToSongOutput(songResult)
+	helpers.HTTPRes(c, http.StatusOK, "Song successfully synced with upstream", songOutput)
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *songController) findCatalogSong(c *gin.Context) (*song.Song, bool) {
+	id, err := ctl.stringToUint(c.Param(("id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return nil, false
+	}
+
+	songResult, err := ctl.SongUC.FindPublicById(id)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Song not found", nil)
+			return nil, false
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return nil, false
+	}
+
+	return songResult, true
+}
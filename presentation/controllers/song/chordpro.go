@@ -0,0 +1,226 @@
+package songcontroller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+const maxUploadSize = 1 << 20 // 1 MB
+
+var chordProExtensions = []string{".cho", ".chordpro", ".chopro", ".crd"}
+
+// @Summary Import a single song from a ChordPro file
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/bands/:id/songs/chordpro [post]
+func (ctl *songController) ImportChordPro(c *gin.Context) {
+	songs, bandResult, user, ok := ctl.parseChordProUpload(c)
+	if !ok {
+		return
+	}
+
+	if len(songs) > 1 {
+		helpers.HTTPRes(c, http.StatusBadRequest, "File contains more than one song, use the batch import instead", nil)
+		return
+	}
+
+	songObj := songs[0]
+	songObj.Band = *bandResult
+	if persistErr := ctl.SongUC.Create(songObj, user); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting song!", persistErr.Error())
+		return
+	}
+
+	songOutput := ctl.mapToSongOutput(songObj)
+	helpers.HTTPRes(c, http.StatusOK, "Song successfully imported!", songOutput)
+}
+
+// @Summary Import every song of a multi-song ChordPro file
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/bands/:id/songs/chordpro/batch [post]
+func (ctl *songController) ImportChordProBatch(c *gin.Context) {
+	songs, bandResult, user, ok := ctl.parseChordProUpload(c)
+	if !ok {
+		return
+	}
+
+	for _, songObj := range songs {
+		songObj.Band = *bandResult
+	}
+
+	// Either the whole file is imported or none of its songs
+	if persistErr := ctl.SongUC.CreateBatch(songs, user); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting song!", persistErr.Error())
+		return
+	}
+
+	var resultOutput []*songoutputs.SongOutput
+	for _, songObj := range songs {
+		resultOutput = append(resultOutput, ctl.mapToSongOutput(songObj))
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Songs successfully imported!", resultOutput)
+}
+
+// @Summary Download a song as a ChordPro file
+// @Produce plain
+// @Success 200 {string} string
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/chordpro [get]
+func (ctl *songController) ExportChordPro(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	id, err := ctl.stringToUint(c.Param(("id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	songResult, err := ctl.SongUC.FindById(id)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Song not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	// Validate if user is a current band member
+	if songResult.Band.OwnerID != user.ID && !ctl.isBandMember(songResult.Band.Members, user.ID) {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	content := ctl.SongUC.ExportChordPro(songResult)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", ctl.fileName(songResult.Title, ".cho")))
+	c.Data(http.StatusOK, "application/x-chordpro; charset=utf-8", []byte(content))
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *songController) parseChordProUpload(c *gin.Context) ([]*song.Song, *band.Band, *account.Account, bool) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return nil, nil, nil, false
+	}
+
+	id, err := ctl.stringToUint(c.Param(("id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return nil, nil, nil, false
+	}
+
+	bandResult, err := ctl.BandUC.FindById(id)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Band not found", nil)
+			return nil, nil, nil, false
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return nil, nil, nil, false
+	}
+
+	// Validate if user is a current band member
+	if bandResult.OwnerID != user.ID && !ctl.isBandMember(bandResult.Members, user.ID) {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return nil, nil, nil, false
+	}
+
+	content, fileName, err := ctl.readUpload(c, chordProExtensions, maxUploadSize)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return nil, nil, nil, false
+	}
+
+	songs, err := ctl.SongUC.ImportChordPro(content, bandResult)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return nil, nil, nil, false
+	}
+
+	// Songs without a title directive are named after the uploaded file
+	baseName := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	for i, s := range songs {
+		if s.Title != "" {
+			continue
+		}
+		s.Title = baseName
+		if len(songs) > 1 {
+			s.Title = fmt.Sprintf("%s (%d)", baseName, i+1)
+		}
+	}
+
+	return songs, bandResult, user, true
+}
+
+func (ctl *songController) readUpload(c *gin.Context, extensions []string, maxSize int64) (string, string, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return "", "", errors.New("a file must be uploaded in the \"file\" field")
+	}
+
+	extension := strings.ToLower(filepath.Ext(fileHeader.Filename))
+	allowed := false
+	for _, e := range extensions {
+		if e == extension {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return "", "", fmt.Errorf("unsupported file type, expected one of %s", strings.Join(extensions, ", "))
+	}
+
+	if fileHeader.Size > maxSize {
+		return "", "", errors.New("uploaded file is too large")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", "", err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxSize))
+	if err != nil {
+		return "", "", err
+	}
+	return string(content), fileHeader.Filename, nil
+}
+
+func (ctl *songController) fileName(title string, extension string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(title))
+	if name == "" {
+		name = "song"
+	}
+	return name + extension
+}
@@ -0,0 +1,79 @@
+package songcontroller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary Chord diagrams of every chord used in a song
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/chords [get]
+func (ctl *songController) ChordDiagrams(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	var diagramParams songinputs.DiagramParams
+	if err := c.BindQuery(&diagramParams); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(diagramParams); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	// Diagrams follow the read time transposition (?transpose=+2 or ?key=G)
+	if transposeErr := ctl.transposeFromQuery(c, songResult); transposeErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, transposeErr.Error(), nil)
+		return
+	}
+
+	result, err := ctl.SongUC.ChordDiagrams([]*song.Song{songResult}, diagramParams.Instrument, diagramParams.Tuning)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", err.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Chord diagrams successfully generated!", ctl.mapToChordDiagramSetOutput(result))
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *songController) mapToChordDiagramSetOutput(set *song.ChordDiagramSet) *songoutputs.ChordDiagramSetOutput {
+	diagrams := []*songoutputs.ChordDiagramOutput{}
+	for _, d := range set.Diagrams {
+		diagrams = append(diagrams, &songoutputs.ChordDiagramOutput{
+			Chord:    d.Chord,
+			Frets:    d.Frets,
+			BaseFret: d.BaseFret,
+			Keys:     d.Keys,
+			Notes:    d.Notes,
+			Svg:      d.Svg,
+		})
+	}
+	return &songoutputs.ChordDiagramSetOutput{
+		Instrument: set.Instrument,
+		Tuning:     set.Tuning,
+		Diagrams:   diagrams,
+		Unvoiced:   set.Unvoiced,
+	}
+}
@@ -0,0 +1,121 @@
+package songcontroller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary List band songs that look like duplicates, best candidates first
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/bands/:id/songs/duplicates [get]
+func (ctl *songController) ListDuplicates(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	bandResult, ok := ctl.findBand(c, user, false)
+	if !ok {
+		return
+	}
+
+	var duplicateParams songinputs.DuplicateParams
+	if err := c.BindQuery(&duplicateParams); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(duplicateParams); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	results, err := ctl.SongUC.FindDuplicates(bandResult, duplicateParams.MinScore)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	resultOutput := []*songoutputs.DuplicatePairOutput{}
+	for _, pair := range results {
+		resultOutput = append(resultOutput, &songoutputs.DuplicatePairOutput{
+			Song:      ctl.mapToSongOutput(pair.Song),
+			Duplicate: ctl.mapToSongOutput(pair.Duplicate),
+			Score:     pair.Score,
+			Title:     pair.Title,
+			Writer:    pair.Writer,
+			Lyrics:    pair.Lyrics,
+		})
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Duplicates successfully listed!", resultOutput)
+}
+
+// @Summary Merge a duplicate into this song, moving its concerts and removing it
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/merge [post]
+func (ctl *songController) MergeSongs(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, true)
+	if !ok {
+		return
+	}
+
+	var mergeInput songinputs.MergeInput
+	if err := c.BindJSON(&mergeInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(mergeInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	duplicateResult, err := ctl.SongUC.FindById(mergeInput.DuplicateID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Duplicate song not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	if duplicateResult.BandID != songResult.BandID {
+		helpers.HTTPRes(c, http.StatusNotFound, "Duplicate song not found", nil)
+		return
+	}
+
+	if mergeErr := ctl.SongUC.Merge(songResult, duplicateResult); mergeErr != nil {
+		if strings.Contains(mergeErr.Error(), "merged") {
+			helpers.HTTPRes(c, http.StatusBadRequest, mergeErr.Error(), nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error merging songs!", mergeErr.Error())
+		return
+	}
+
+	songOutput := ctl.mapToSongOutput(songResult)
+	helpers.HTTPRes(c, http.StatusOK, "Songs successfully merged!", songOutput)
+}
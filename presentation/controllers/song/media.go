@@ -0,0 +1,173 @@
+package songcontroller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/infra/medialinks"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary List song media links
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/media [get]
+func (ctl *songController) ListMediaLinks(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	results, err := ctl.MediaLinkUC.FindBySong(songResult)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	var resultOutput []*songoutputs.MediaLinkOutput
+	for _, l := range results {
+		resultOutput = append(resultOutput, ctl.mapToMediaLinkOutput(l))
+	}
+
+	// Empty array if no results
+	if resultOutput == nil {
+		helpers.HTTPRes(c, http.StatusOK, "Media links successfully listed!", []string{})
+		return
+	}
+
+	// Formatted array
+	helpers.HTTPRes(c, http.StatusOK, "Media links successfully listed!", resultOutput)
+}
+
+// @Summary Attach a recording or video reference to a song
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/media [post]
+func (ctl *songController) CreateMediaLink(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	var newLink songinputs.MediaLinkInput
+	if err := c.BindJSON(&newLink); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(newLink); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	linkObj := song.MediaLink{
+		SongID: songResult.ID,
+		Song:   *songResult,
+		Label:  newLink.Label,
+		Url:    newLink.Url,
+	}
+
+	if persistErr := ctl.MediaLinkUC.Create(&linkObj); persistErr != nil {
+		es := persistErr.Error()
+		if strings.Contains(es, "media link") {
+			helpers.HTTPRes(c, http.StatusBadRequest, es, nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting media link!", es)
+		return
+	}
+
+	linkOutput := ctl.mapToMediaLinkOutput(&linkObj)
+	helpers.HTTPRes(c, http.StatusOK, "Media link successfully created!", linkOutput)
+}
+
+// @Summary Delete song media link
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/media/:media_id [delete]
+func (ctl *songController) RemoveMediaLink(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	linkID, err := ctl.stringToUint(c.Param(("media_id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	linkResult, err := ctl.MediaLinkUC.FindById(linkID)
+	if err != nil || linkResult.SongID != songResult.ID {
+		if err == nil || strings.Contains(err.Error(), "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Media link not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	if persistErr := ctl.MediaLinkUC.Remove(linkResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error deleting media link!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusNoContent, "Media link successfully deleted!", nil)
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+// Embed descriptor of a stored link, nil for legacy links of unknown providers
+func (ctl *songController) mapToEmbedOutput(address string) *songoutputs.EmbedOutput {
+	link, err := medialinks.Parse(address)
+	if err != nil {
+		return nil
+	}
+	return &songoutputs.EmbedOutput{
+		Provider: link.Provider,
+		ID:       link.ID,
+		Kind:     link.Kind,
+		Url:      link.Url,
+		EmbedUrl: link.EmbedUrl,
+		Start:    link.Start,
+	}
+}
+
+func (ctl *songController) mapToMediaLinkOutput(l *song.MediaLink) *songoutputs.MediaLinkOutput {
+	return &songoutputs.MediaLinkOutput{
+		ID:       l.ID,
+		Label:    l.Label,
+		Position: l.Position,
+		Embed:    ctl.mapToEmbedOutput(l.Url),
+	}
+}
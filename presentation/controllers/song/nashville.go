@@ -0,0 +1,49 @@
+package songcontroller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary Render a Nashville number chart as letter chords in any key
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/nashville [post]
+func (ctl *songController) ConvertNashville(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	var nashvilleInput songinputs.NashvilleInput
+	if err := c.BindJSON(&nashvilleInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(nashvilleInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	body, tone, err := ctl.SongUC.FromNashville(nashvilleInput.Body, nashvilleInput.Key)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", err.Error())
+		return
+	}
+
+	chartOutput := &songoutputs.ChartOutput{
+		Tone: tone,
+		Body: body,
+	}
+	helpers.HTTPRes(c, http.StatusOK, "Chart successfully converted!", chartOutput)
+}
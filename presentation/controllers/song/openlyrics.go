@@ -0,0 +1,114 @@
+package songcontroller
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+var openLyricsExtensions = []string{".xml"}
+
+// @Summary Import a song and its translations from an OpenLyrics file
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/bands/:id/songs/openlyrics [post]
+func (ctl *songController) ImportOpenLyrics(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	bandResult, ok := ctl.findBand(c, user, false)
+	if !ok {
+		return
+	}
+
+	content, fileName, err := ctl.readUpload(c, openLyricsExtensions, maxUploadSize)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	result, err := ctl.SongUC.ImportOpenLyrics([]byte(content), bandResult)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	// Songs without a title are named after the uploaded file
+	songObj := result.Song
+	if songObj.Title == "" {
+		songObj.Title = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	}
+
+	if persistErr := ctl.SongUC.Create(songObj, user); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting song!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Song successfully imported!", ctl.mapToOpenLyricsImportOutput(result))
+}
+
+// @Summary Download a song and its translations as an OpenLyrics file
+// @Produce xml
+// @Success 200 {string} string
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/openlyrics [get]
+func (ctl *songController) ExportOpenLyrics(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	translations, err := ctl.TranslationUC.FindBySong(songResult)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	content, dropped, err := ctl.SongUC.ExportOpenLyrics(songResult, translations)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	// Song fields the format has no place for, so clients can warn about
+	// them. Section labels may hold any character while header values are
+	// ASCII, so the list is percent encoded.
+	if len(dropped) > 0 {
+		c.Header("X-OpenLyrics-Dropped", url.PathEscape(strings.Join(dropped, ", ")))
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", ctl.fileName(songResult.Title, ".xml")))
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", content)
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *songController) mapToOpenLyricsImportOutput(r *song.OpenLyricsImport) *songoutputs.OpenLyricsImportOutput {
+	output := &songoutputs.OpenLyricsImportOutput{
+		Song:         ctl.mapToSongOutput(r.Song),
+		Translations: []*songoutputs.TranslationOutput{},
+		Dropped:      []string{},
+	}
+	for i := range r.Song.Translations {
+		output.Translations = append(output.Translations, ctl.mapToTranslationOutput(&r.Song.Translations[i]))
+	}
+	output.Dropped = append(output.Dropped, r.Dropped...)
+	return output
+}
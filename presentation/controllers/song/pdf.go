@@ -0,0 +1,195 @@
+package songcontroller
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	commoninputs "github.com/mazurco066/playliter-api-go/domain/inputs/common"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// Upper bound of songs printed into a single band songbook
+const maxSongbookSongs = 500
+
+// @Summary Download a song chart as PDF
+// @Produce application/pdf
+// @Success 200 {string} string
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/pdf [get]
+func (ctl *songController) ExportPdf(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	pdfParams, ok := ctl.bindPdfParams(c)
+	if !ok {
+		return
+	}
+
+	// Read time transposition and notation (?key=G, ?notation=nashville), never persisted
+	if chartErr := ctl.chartFromQuery(c, songResult); chartErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, chartErr.Error(), nil)
+		return
+	}
+
+	content := ctl.SongUC.ExportPdf(songResult, pdfParams)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", ctl.fileName(songResult.Title, ".pdf")))
+	c.Data(http.StatusOK, "application/pdf", content)
+}
+
+// @Summary Download every song of a band as a PDF songbook
+// @Produce application/pdf
+// @Success 200 {string} string
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/bands/:id/songs/pdf [get]
+func (ctl *songController) ExportSongbook(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	bandResult, ok := ctl.findBand(c, user, false)
+	if !ok {
+		return
+	}
+
+	pdfParams, ok := ctl.bindPdfParams(c)
+	if !ok {
+		return
+	}
+
+	// Same filters of the song listing, so a songbook can be printed per tag
+	var listParams songinputs.ListParams
+	if err := c.BindQuery(&listParams); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(listParams); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	paging := commoninputs.PagingParams{Limit: maxSongbookSongs}
+	results, err := ctl.SongUC.FindByBand(bandResult, &listParams, &paging)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid key") {
+			helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", err.Error())
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	if len(results) == 0 {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Band has no songs to export", nil)
+		return
+	}
+
+	ctl.sendSongbook(c, bandResult.Title, results, pdfParams)
+}
+
+// @Summary Download a selected list of band songs as a PDF songbook
+// @Produce application/pdf
+// @Success 200 {string} string
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/bands/:id/songs/pdf [post]
+func (ctl *songController) ExportSelection(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	bandResult, ok := ctl.findBand(c, user, false)
+	if !ok {
+		return
+	}
+
+	pdfParams, ok := ctl.bindPdfParams(c)
+	if !ok {
+		return
+	}
+
+	var selection songinputs.PdfSelectionInput
+	if err := c.BindJSON(&selection); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(selection); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	// Songs are printed in the requested order
+	var songs []*song.Song
+	for _, id := range selection.SongIDs {
+		songResult, err := ctl.SongUC.FindById(id)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				helpers.HTTPRes(c, http.StatusNotFound, fmt.Sprintf("Song %d not found", id), nil)
+				return
+			}
+			helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+			return
+		}
+		if songResult.BandID != bandResult.ID {
+			helpers.HTTPRes(c, http.StatusNotFound, fmt.Sprintf("Song %d not found", id), nil)
+			return
+		}
+		songs = append(songs, songResult)
+	}
+
+	ctl.sendSongbook(c, bandResult.Title, songs, pdfParams)
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *songController) bindPdfParams(c *gin.Context) (*songinputs.PdfParams, bool) {
+	var pdfParams songinputs.PdfParams
+	if err := c.BindQuery(&pdfParams); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return nil, false
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(pdfParams); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return nil, false
+	}
+
+	return &pdfParams, true
+}
+
+// Applies the read time options to every song and sends the songbook
+func (ctl *songController) sendSongbook(c *gin.Context, title string, songs []*song.Song, pdfParams *songinputs.PdfParams) {
+	for _, s := range songs {
+		if chartErr := ctl.chartFromQuery(c, s); chartErr != nil {
+			helpers.HTTPRes(c, http.StatusBadRequest, fmt.Sprintf("%s: %s", s.Title, chartErr.Error()), nil)
+			return
+		}
+	}
+
+	content := ctl.SongUC.ExportSongbook(title, songs, pdfParams)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", ctl.fileName(title, ".pdf")))
+	c.Data(http.StatusOK, "application/pdf", content)
+}
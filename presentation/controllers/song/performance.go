@@ -0,0 +1,134 @@
+package songcontroller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary Performance statistics of a song from the concert history
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/stats [get]
+func (ctl *songController) GetStats(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	stats, err := ctl.PerformanceUC.Stats(songResult)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Song statistics successfully generated!", ctl.mapToPerformanceStatsOutput(stats))
+}
+
+// @Summary Most played, least played and never played songs of a band
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/bands/:id/songs/stats [get]
+func (ctl *songController) PerformanceReport(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	bandResult, ok := ctl.findBand(c, user, false)
+	if !ok {
+		return
+	}
+
+	var reportParams songinputs.PerformanceReportParams
+	if err := c.BindQuery(&reportParams); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(reportParams); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	report, err := ctl.PerformanceUC.Report(bandResult, &reportParams)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid period") {
+			helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", err.Error())
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Performance report successfully generated!", ctl.mapToPerformanceReportOutput(report))
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *songController) mapToPerformanceStatsOutput(s *song.PerformanceStats) *songoutputs.PerformanceStatsOutput {
+	output := &songoutputs.PerformanceStatsOutput{
+		SongID:         s.SongID,
+		Performances:   s.Performances,
+		FirstPerformed: s.FirstPerformed,
+		LastPerformed:  s.LastPerformed,
+		AverageGapDays: s.AverageGapDays,
+		Venues:         []*songoutputs.VenueCountOutput{},
+	}
+	for _, v := range s.Venues {
+		output.Venues = append(output.Venues, &songoutputs.VenueCountOutput{
+			Venue:        v.Venue,
+			Performances: v.Performances,
+		})
+	}
+	return output
+}
+
+func (ctl *songController) mapToPerformanceReportOutput(r *song.PerformanceReport) *songoutputs.PerformanceReportOutput {
+	output := &songoutputs.PerformanceReportOutput{
+		From:        r.From,
+		To:          r.To,
+		MostPlayed:  ctl.mapToPerformanceCountOutputs(r.MostPlayed),
+		LeastPlayed: ctl.mapToPerformanceCountOutputs(r.LeastPlayed),
+		NeverPlayed: []*songoutputs.PerformanceCountOutput{},
+	}
+	for _, s := range r.NeverPlayed {
+		output.NeverPlayed = append(output.NeverPlayed, &songoutputs.PerformanceCountOutput{
+			SongID: s.ID,
+			Title:  s.Title,
+		})
+	}
+	return output
+}
+
+func (ctl *songController) mapToPerformanceCountOutputs(counts []*song.PerformanceCount) []*songoutputs.PerformanceCountOutput {
+	output := []*songoutputs.PerformanceCountOutput{}
+	for _, count := range counts {
+		output = append(output, &songoutputs.PerformanceCountOutput{
+			SongID:        count.Song.ID,
+			Title:         count.Song.Title,
+			Performances:  count.Performances,
+			Total:         count.Total,
+			LastPerformed: count.LastPerformed,
+		})
+	}
+	return output
+}
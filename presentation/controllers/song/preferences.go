@@ -0,0 +1,186 @@
+package songcontroller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary Retrieve the current account preference for a song
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/preference [get]
+func (ctl *songController) GetPreference(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	preferenceResult, err := ctl.PreferenceUC.Find(songResult, user)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Preference not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Preference retrieved!", ctl.mapToPreferenceOutput(preferenceResult))
+}
+
+// @Summary Save the key, capo and notation the current account plays a song with
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/preference [put]
+func (ctl *songController) SavePreference(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	var preferenceInput songinputs.PreferenceInput
+	if err := c.BindJSON(&preferenceInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(preferenceInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	preferenceObj, err := ctl.PreferenceUC.Find(songResult, user)
+	if err != nil {
+		if !strings.Contains(err.Error(), "not found") {
+			helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+			return
+		}
+		preferenceObj = &song.Preference{
+			SongID:    songResult.ID,
+			AccountID: user.ID,
+		}
+	}
+	preferenceObj.Key = preferenceInput.Key
+	preferenceObj.Capo = preferenceInput.Capo
+	preferenceObj.Notation = preferenceInput.Notation
+
+	if persistErr := ctl.PreferenceUC.Save(preferenceObj); persistErr != nil {
+		if strings.Contains(persistErr.Error(), "invalid key") {
+			helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", persistErr.Error())
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting preference!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Preference successfully saved!", ctl.mapToPreferenceOutput(preferenceObj))
+}
+
+// @Summary Delete the current account preference for a song
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/preference [delete]
+func (ctl *songController) RemovePreference(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	preferenceResult, err := ctl.PreferenceUC.Find(songResult, user)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Preference not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	if persistErr := ctl.PreferenceUC.Remove(preferenceResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error deleting preference!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusNoContent, "Preference successfully deleted!", nil)
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+// Charts the song after the user preference and the read time query params,
+// which are applied on top of it. Responds with the proper error and returns
+// false when the chart can not be built.
+func (ctl *songController) personalChart(c *gin.Context, user *account.Account, s *song.Song) (bool, bool) {
+	preferenceResult, err := ctl.PreferenceUC.Find(s, user)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			if chartErr := ctl.chartFromQuery(c, s); chartErr != nil {
+				helpers.HTTPRes(c, http.StatusBadRequest, chartErr.Error(), nil)
+				return false, false
+			}
+			return false, true
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return false, false
+	}
+
+	if applyErr := ctl.PreferenceUC.Apply(s, preferenceResult); applyErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, applyErr.Error(), nil)
+		return false, false
+	}
+
+	// Numbers can not be transposed, so the preferred notation is applied
+	// last and only when the query does not ask for another one
+	if chartErr := ctl.chartFromQuery(c, s); chartErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, chartErr.Error(), nil)
+		return false, false
+	}
+	if c.Query("notation") == "" && preferenceResult.Notation == song.NotationNashville {
+		if notationErr := ctl.SongUC.ToNashville(s); notationErr != nil {
+			helpers.HTTPRes(c, http.StatusBadRequest, notationErr.Error(), nil)
+			return false, false
+		}
+	}
+	return true, true
+}
+
+func (ctl *songController) mapToPreferenceOutput(p *song.Preference) *songoutputs.PreferenceOutput {
+	return &songoutputs.PreferenceOutput{
+		SongID:   p.SongID,
+		Key:      p.Key,
+		Capo:     p.Capo,
+		Notation: p.Notation,
+	}
+}
@@ -0,0 +1,245 @@
+package songcontroller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	commoninputs "github.com/mazurco066/playliter-api-go/domain/inputs/common"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	accountoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/account"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary List song revisions, newest first
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/revisions [get]
+func (ctl *songController) ListRevisions(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	var paging commoninputs.PagingParams
+	if err := c.BindQuery(&paging); err != nil {
+		paging.Limit = 100
+		paging.Offset = 0
+	}
+
+	results, err := ctl.RevisionUC.FindBySong(songResult, &paging)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	var resultOutput []*songoutputs.RevisionOutput
+	for _, r := range results {
+		resultOutput = append(resultOutput, ctl.mapToRevisionOutput(r))
+	}
+
+	// Empty array if no results
+	if resultOutput == nil {
+		helpers.HTTPRes(c, http.StatusOK, "Revisions successfully listed!", []string{})
+		return
+	}
+
+	// Formatted array
+	helpers.HTTPRes(c, http.StatusOK, "Revisions successfully listed!", resultOutput)
+}
+
+// @Summary Get song revision
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/revisions/:number [get]
+func (ctl *songController) GetRevision(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	revisionResult, ok := ctl.findRevision(c, songResult, c.Param("number"))
+	if !ok {
+		return
+	}
+
+	revisionOutput := ctl.mapToRevisionOutput(revisionResult)
+	helpers.HTTPRes(c, http.StatusOK, "Revision retrieved!", revisionOutput)
+}
+
+// @Summary Line level diff between two song revisions
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/revisions/diff [get]
+func (ctl *songController) DiffRevisions(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	var diffParams songinputs.RevisionDiffParams
+	if err := c.BindQuery(&diffParams); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(diffParams); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	fromRevision, ok := ctl.findRevision(c, songResult, strconv.Itoa(diffParams.From))
+	if !ok {
+		return
+	}
+	toRevision, ok := ctl.findRevision(c, songResult, strconv.Itoa(diffParams.To))
+	if !ok {
+		return
+	}
+
+	diff := ctl.RevisionUC.Diff(fromRevision, toRevision)
+	diffOutput := &songoutputs.RevisionDiffOutput{
+		From:   ctl.mapToRevisionOutput(diff.From),
+		To:     ctl.mapToRevisionOutput(diff.To),
+		Fields: []*songoutputs.FieldChangeOutput{},
+		Lines:  []*songoutputs.DiffLineOutput{},
+	}
+	for _, f := range diff.Fields {
+		diffOutput.Fields = append(diffOutput.Fields, &songoutputs.FieldChangeOutput{
+			Field: f.Field,
+			From:  f.From,
+			To:    f.To,
+		})
+	}
+	for _, l := range diff.Lines {
+		diffOutput.Lines = append(diffOutput.Lines, &songoutputs.DiffLineOutput{
+			Op:      l.Op,
+			OldLine: l.OldLine,
+			NewLine: l.NewLine,
+			Text:    l.Text,
+		})
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Revisions compared!", diffOutput)
+}
+
+// @Summary Restore an old revision as the current song version
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/revisions/:number/restore [post]
+func (ctl *songController) RestoreRevision(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	revisionResult, ok := ctl.findRevision(c, songResult, c.Param("number"))
+	if !ok {
+		return
+	}
+
+	if persistErr := ctl.SongUC.Restore(songResult, revisionResult, user); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting song!", persistErr.Error())
+		return
+	}
+
+	songOutput := ctl.mapToSongOutput(songResult)
+	helpers.HTTPRes(c, http.StatusOK, "Revision successfully restored!", songOutput)
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *songController) findRevision(c *gin.Context, s *song.Song, numberParam string) (*song.Revision, bool) {
+	number, err := strconv.Atoi(numberParam)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "revision number should be a number", nil)
+		return nil, false
+	}
+
+	revisionResult, err := ctl.RevisionUC.FindByNumber(s, number)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Revision not found", nil)
+			return nil, false
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return nil, false
+	}
+
+	return revisionResult, true
+}
+
+func (ctl *songController) mapToRevisionOutput(r *song.Revision) *songoutputs.RevisionOutput {
+	output := &songoutputs.RevisionOutput{
+		ID:           r.ID,
+		Number:       r.Number,
+		RestoredFrom: r.RestoredFrom,
+		CreatedAt:    r.CreatedAt,
+		Title:        r.Title,
+		Writter:      r.Writter,
+		Tone:         r.Tone,
+		Capo:         r.Capo,
+		Body:         r.Body,
+		Author: &accountoutputs.AccountPublicOutput{
+			ID:   r.Author.ID,
+			Name: r.Author.Name,
+		},
+	}
+	if r.Author.Avatar != nil {
+		output.Author.Avatar = *r.Author.Avatar
+	}
+	if r.OriginalKey != nil {
+		output.OriginalKey = *r.OriginalKey
+	}
+	if r.Bpm != nil {
+		output.Bpm = *r.Bpm
+	}
+	if r.TimeSignature != nil {
+		output.TimeSignature = *r.TimeSignature
+	}
+	if r.DurationSeconds != nil {
+		output.DurationSeconds = *r.DurationSeconds
+	}
+	if r.EmbeddedUrl != nil {
+		output.EmbeddedUrl = *r.EmbeddedUrl
+	}
+	return output
+}
@@ -0,0 +1,455 @@
+package songcontroller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary List song sections
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/sections [get]
+func (ctl *songController) ListSections(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	results, err := ctl.SectionUC.FindBySong(songResult)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	var resultOutput []*songoutputs.SectionOutput
+	for _, s := range results {
+		resultOutput = append(resultOutput, ctl.mapToSectionOutput(s))
+	}
+
+	// Empty array if no results
+	if resultOutput == nil {
+		helpers.HTTPRes(c, http.StatusOK, "Sections successfully listed!", []string{})
+		return
+	}
+
+	// Formatted array
+	helpers.HTTPRes(c, http.StatusOK, "Sections successfully listed!", resultOutput)
+}
+
+// @Summary Register a new section into a song
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/sections [post]
+func (ctl *songController) CreateSection(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	var newSection songinputs.SectionInput
+	if err := c.BindJSON(&newSection); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(newSection); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	sectionObj := song.Section{
+		SongID: songResult.ID,
+		Song:   *songResult,
+		Kind:   newSection.Kind,
+		Label:  newSection.Label,
+		Body:   newSection.Body,
+	}
+
+	if persistErr := ctl.SectionUC.Create(&sectionObj); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting section!", persistErr.Error())
+		return
+	}
+
+	sectionOutput := ctl.mapToSectionOutput(&sectionObj)
+	helpers.HTTPRes(c, http.StatusOK, "Section successfully created!", sectionOutput)
+}
+
+// @Summary Split the song body into sections and arrangement
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/sections/parse [post]
+func (ctl *songController) ParseSections(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	if _, persistErr := ctl.SectionUC.Parse(songResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting sections!", persistErr.Error())
+		return
+	}
+
+	items, err := ctl.ArrangementUC.FindBySong(songResult)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Song successfully split into sections!", ctl.mapToArrangementOutput(items))
+}
+
+// @Summary Update song section
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/sections/:section_id [patch]
+func (ctl *songController) UpdateSection(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	sectionResult, ok := ctl.findSection(c, songResult)
+	if !ok {
+		return
+	}
+
+	var updateInput songinputs.UpdateSectionInput
+	if err := c.BindJSON(&updateInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(updateInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	if updateInput.Kind != "" {
+		sectionResult.Kind = updateInput.Kind
+	}
+	if updateInput.Label != "" {
+		sectionResult.Label = updateInput.Label
+	}
+	if updateInput.Body != "" {
+		sectionResult.Body = updateInput.Body
+	}
+
+	if persistErr := ctl.SectionUC.Update(sectionResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting section!", persistErr.Error())
+		return
+	}
+
+	sectionOutput := ctl.mapToSectionOutput(sectionResult)
+	helpers.HTTPRes(c, http.StatusOK, "Section successfully updated", sectionOutput)
+}
+
+// @Summary Delete song section
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/sections/:section_id [delete]
+func (ctl *songController) RemoveSection(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	sectionResult, ok := ctl.findSection(c, songResult)
+	if !ok {
+		return
+	}
+
+	if persistErr := ctl.SectionUC.Remove(sectionResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error deleting section!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusNoContent, "Section successfully deleted!", nil)
+}
+
+// @Summary Get song arrangement
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/arrangement [get]
+func (ctl *songController) GetArrangement(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	items, err := ctl.ArrangementUC.FindBySong(songResult)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Arrangement retrieved!", ctl.mapToArrangementOutput(items))
+}
+
+// @Summary Replace song arrangement
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/arrangement [put]
+func (ctl *songController) UpdateArrangement(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	var arrangementInput songinputs.ArrangementInput
+	if err := c.BindJSON(&arrangementInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(arrangementInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	var items []*song.ArrangementItem
+	for _, item := range arrangementInput.Items {
+		items = append(items, &song.ArrangementItem{
+			SectionID: item.SectionID,
+			Repeat:    item.Repeat,
+		})
+	}
+
+	if persistErr := ctl.ArrangementUC.Replace(songResult, items); persistErr != nil {
+		if strings.Contains(persistErr.Error(), "does not belong") {
+			helpers.HTTPRes(c, http.StatusBadRequest, persistErr.Error(), nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting arrangement!", persistErr.Error())
+		return
+	}
+
+	items, err := ctl.ArrangementUC.FindBySong(songResult)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Arrangement successfully updated", ctl.mapToArrangementOutput(items))
+}
+
+// @Summary Render the song chart following its arrangement, in a reading mode and format
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/render [get]
+func (ctl *songController) Render(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	var renderParams songinputs.RenderParams
+	if err := c.BindQuery(&renderParams); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(renderParams); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	body, err := ctl.ArrangementUC.Render(songResult)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+	songResult.Body = body
+
+	if chartErr := ctl.chartFromQuery(c, songResult); chartErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, chartErr.Error(), nil)
+		return
+	}
+
+	// Modes (?mode=full|lyrics|chords|chordpro) come as plain text, HTML or JSON
+	// tokens (?format=text|html|json), without any the body is sent as written
+	if renderParams.Mode == "" && renderParams.Format == "" {
+		chartOutput := &songoutputs.ChartOutput{
+			ID:    songResult.ID,
+			Title: songResult.Title,
+			Tone:  songResult.Tone,
+			Body:  songResult.Body,
+		}
+		helpers.HTTPRes(c, http.StatusOK, "Song chart rendered!", chartOutput)
+		return
+	}
+
+	document := ctl.SongUC.RenderChart(songResult, renderParams.Mode, renderParams.Format)
+	switch renderParams.Format {
+	case "text":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(document.Text))
+	case "html":
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(document.Text))
+	case "json":
+		helpers.HTTPRes(c, http.StatusOK, "Song chart rendered!", ctl.mapToChartDocumentOutput(songResult, document))
+	default:
+		chartOutput := &songoutputs.ChartOutput{
+			ID:    songResult.ID,
+			Title: songResult.Title,
+			Tone:  songResult.Tone,
+			Body:  document.Text,
+		}
+		helpers.HTTPRes(c, http.StatusOK, "Song chart rendered!", chartOutput)
+	}
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *songController) findSection(c *gin.Context, s *song.Song) (*song.Section, bool) {
+	sectionId, err := ctl.stringToUint(c.Param(("section_id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return nil, false
+	}
+
+	sectionResult, err := ctl.SectionUC.FindById(sectionId)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Section not found", nil)
+			return nil, false
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return nil, false
+	}
+
+	if sectionResult.SongID != s.ID {
+		helpers.HTTPRes(c, http.StatusNotFound, "Section not found", nil)
+		return nil, false
+	}
+
+	return sectionResult, true
+}
+
+func (ctl *songController) mapToSectionOutput(s *song.Section) *songoutputs.SectionOutput {
+	return &songoutputs.SectionOutput{
+		ID:       s.ID,
+		Kind:     s.Kind,
+		Label:    s.Label,
+		Position: s.Position,
+		Body:     s.Body,
+	}
+}
+
+func (ctl *songController) mapToArrangementOutput(items []*song.ArrangementItem) []*songoutputs.ArrangementItemOutput {
+	resultOutput := []*songoutputs.ArrangementItemOutput{}
+	for _, item := range items {
+		resultOutput = append(resultOutput, &songoutputs.ArrangementItemOutput{
+			ID:       item.ID,
+			Position: item.Position,
+			Repeat:   item.Repeat,
+			Section:  ctl.mapToSectionOutput(&item.Section),
+		})
+	}
+	return resultOutput
+}
+
+func (ctl *songController) mapToChartDocumentOutput(s *song.Song, d *song.ChartDocument) *songoutputs.ChartDocumentOutput {
+	output := &songoutputs.ChartDocumentOutput{
+		ID:       s.ID,
+		Title:    s.Title,
+		Tone:     s.Tone,
+		Mode:     d.Mode,
+		Sections: []*songoutputs.ChartSectionOutput{},
+	}
+	for _, section := range d.Sections {
+		sectionOutput := &songoutputs.ChartSectionOutput{
+			Label: section.Label,
+			Lines: []*songoutputs.ChartLineOutput{},
+		}
+		for _, line := range section.Lines {
+			lineOutput := &songoutputs.ChartLineOutput{
+				Kind:     line.Kind,
+				Segments: []*songoutputs.ChartSegmentOutput{},
+			}
+			for _, segment := range line.Segments {
+				lineOutput.Segments = append(lineOutput.Segments, &songoutputs.ChartSegmentOutput{
+					Chord:  segment.Chord,
+					Lyrics: segment.Lyrics,
+				})
+			}
+			sectionOutput.Lines = append(sectionOutput.Lines, lineOutput)
+		}
+		output.Sections = append(output.Sections, sectionOutput)
+	}
+	return output
+}
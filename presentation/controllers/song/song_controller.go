@@ -1,26 +1,839 @@
 package songcontroller
 
 import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	accountusecase "github.com/mazurco066/playliter-api-go/data/usecases/account"
+	bandusecase "github.com/mazurco066/playliter-api-go/data/usecases/band"
 	songusecase "github.com/mazurco066/playliter-api-go/data/usecases/song"
+	commoninputs "github.com/mazurco066/playliter-api-go/domain/inputs/common"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/account"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	bandoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/band"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
 )
 
 type SongController interface {
+	ChordDiagrams(*gin.Context)
+	ConvertNashville(*gin.Context)
 	Create(*gin.Context)
+	CreateAnnotation(*gin.Context)
+	CreateMediaLink(*gin.Context)
+	CreateSection(*gin.Context)
+	CreateTag(*gin.Context)
+	DiffRevisions(*gin.Context)
+	ExportChordPro(*gin.Context)
+	ExportOpenLyrics(*gin.Context)
+	ExportPdf(*gin.Context)
+	ExportSelection(*gin.Context)
+	ExportSongbook(*gin.Context)
+	ForkCatalogSong(*gin.Context)
+	Get(*gin.Context)
+	GetArrangement(*gin.Context)
+	GetCatalogSong(*gin.Context)
+	GetPreference(*gin.Context)
+	GetRevision(*gin.Context)
+	GetStats(*gin.Context)
+	HideAnnotation(*gin.Context)
+	ImportChordPro(*gin.Context)
+	ImportChordProBatch(*gin.Context)
+	ImportOpenLyrics(*gin.Context)
+	ImportSongs(*gin.Context)
+	List(*gin.Context)
+	ListAnnotations(*gin.Context)
+	ListCatalog(*gin.Context)
+	ListDuplicates(*gin.Context)
+	ListMediaLinks(*gin.Context)
+	ListRevisions(*gin.Context)
+	ListSections(*gin.Context)
+	ListTags(*gin.Context)
+	ListTranslations(*gin.Context)
+	MergeSongs(*gin.Context)
+	ParseSections(*gin.Context)
+	PerformanceReport(*gin.Context)
+	Publish(*gin.Context)
+	Remove(*gin.Context)
+	RemoveAnnotation(*gin.Context)
+	RemoveMediaLink(*gin.Context)
+	RemovePreference(*gin.Context)
+	RemoveSection(*gin.Context)
+	RemoveTag(*gin.Context)
+	RemoveTranslation(*gin.Context)
+	ReopenAnnotation(*gin.Context)
+	Render(*gin.Context)
+	ResolveAnnotation(*gin.Context)
+	RestoreRevision(*gin.Context)
+	SavePreference(*gin.Context)
+	SaveTranslation(*gin.Context)
+	Search(*gin.Context)
+	SyncUpstream(*gin.Context)
+	Transpose(*gin.Context)
+	UnhideAnnotation(*gin.Context)
+	Unpublish(*gin.Context)
+	Update(*gin.Context)
+	UpdateAnnotation(*gin.Context)
+	UpdateArrangement(*gin.Context)
+	UpdateSection(*gin.Context)
+	UpdateTag(*gin.Context)
 }
 
 type songController struct {
-	SongUC songusecase.SongUseCase
+	AccountUc     accountusecase.AccountUseCase
+	AnnotationUC  songusecase.AnnotationUseCase
+	ArrangementUC songusecase.ArrangementUseCase
+	BandUC        bandusecase.BandUseCase
+	MediaLinkUC   songusecase.MediaLinkUseCase
+	PerformanceUC songusecase.PerformanceUseCase
+	PreferenceUC  songusecase.PreferenceUseCase
+	RevisionUC    songusecase.RevisionUseCase
+	SectionUC     songusecase.SectionUseCase
+	SongUC        songusecase.SongUseCase
+	TagUC         songusecase.TagUseCase
+	TranslationUC songusecase.TranslationUseCase
 }
 
 func NewSongController(
+	accountUc accountusecase.AccountUseCase,
+	annotationUc songusecase.AnnotationUseCase,
+	arrangementUc songusecase.ArrangementUseCase,
+	bandUc bandusecase.BandUseCase,
+	mediaLinkUc songusecase.MediaLinkUseCase,
+	performanceUc songusecase.PerformanceUseCase,
+	preferenceUc songusecase.PreferenceUseCase,
+	revisionUc songusecase.RevisionUseCase,
+	sectionUc songusecase.SectionUseCase,
 	songUc songusecase.SongUseCase,
+	tagUc songusecase.TagUseCase,
+	translationUc songusecase.TranslationUseCase,
 ) SongController {
 	return &songController{
-		SongUC: songUc,
+		AccountUc:     accountUc,
+		AnnotationUC:  annotationUc,
+		ArrangementUC: arrangementUc,
+		BandUC:        bandUc,
+		MediaLinkUC:   mediaLinkUc,
+		PerformanceUC: performanceUc,
+		PreferenceUC:  preferenceUc,
+		RevisionUC:    revisionUc,
+		SectionUC:     sectionUc,
+		SongUC:        songUc,
+		TagUC:         tagUc,
+		TranslationUC: translationUc,
 	}
 }
 
+// @Summary Register a new song into a band
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs [post]
 func (ctl *songController) Create(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	var newSong songinputs.CreateInput
+	if err := c.BindJSON(&newSong); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(newSong); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	bandResult, err := ctl.BandUC.FindById(newSong.BandID)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Band not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	// Validate if user is a current band member
+	if bandResult.OwnerID != user.ID && !ctl.isBandMember(bandResult.Members, user.ID) {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	tags, err := ctl.TagUC.FindByIds(bandResult, newSong.TagIDs)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	songObj := song.Song{
+		Title:           newSong.Title,
+		Writter:         newSong.Writter,
+		Tone:            newSong.Tone,
+		OriginalKey:     newSong.OriginalKey,
+		Bpm:             newSong.Bpm,
+		TimeSignature:   newSong.TimeSignature,
+		DurationSeconds: newSong.DurationSeconds,
+		Capo:            newSong.Capo,
+		Body:            newSong.Body,
+		EmbeddedUrl:     newSong.EmbeddedUrl,
+		Tags:            tags,
+		BandID:          bandResult.ID,
+		Band:            *bandResult,
+	}
+
+	// Nashville charts are stored in letter chords of the song tone
+	if newSong.Notation == "nashville" {
+		body, _, nashvilleErr := ctl.SongUC.FromNashville(songObj.Body, songObj.Tone)
+		if nashvilleErr != nil {
+			helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nashvilleErr.Error())
+			return
+		}
+		songObj.Body = body
+	}
+
+	if normalizeErr := ctl.SongUC.Normalize(&songObj); normalizeErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", normalizeErr.Error())
+		return
+	}
+
+	if persistErr := ctl.SongUC.Create(&songObj, user); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting song!", persistErr.Error())
+		return
+	}
+
+	songOutput := ctl.mapToSongOutput(&songObj)
+	helpers.HTTPRes(c, http.StatusOK, "Song successfully created!", songOutput)
+}
+
+// @Summary Get song
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id [get]
+func (ctl *songController) Get(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	id, err := ctl.stringToUint(c.Param(("id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	songResult, err := ctl.SongUC.FindById(id)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Song not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	// Validate if user is a current band member
+	if songResult.Band.OwnerID != user.ID && !ctl.isBandMember(songResult.Band.Members, user.ID) {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	var songParams songinputs.SongParams
+	if err := c.BindQuery(&songParams); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	// Charted after the member preference unless the original is asked for (?original=true),
+	// read time transposition and notation (?key=G, ?notation=nashville) apply on top, never persisted
+	personalized := false
+	if songParams.Original {
+		if chartErr := ctl.chartFromQuery(c, songResult); chartErr != nil {
+			helpers.HTTPRes(c, http.StatusBadRequest, chartErr.Error(), nil)
+			return
+		}
+	} else {
+		applied, ok := ctl.personalChart(c, user, songResult)
+		if !ok {
+			return
+		}
+		personalized = applied
+	}
+
+	songOutput := ctl.mapToSongOutput(songResult)
+	songOutput.Personalized = personalized
+	helpers.HTTPRes(c, http.StatusOK, "Song retrieved!", songOutput)
+}
+
+// @Summary List band songs
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/bands/:id/songs [get]
+func (ctl *songController) List(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	id, err := ctl.stringToUint(c.Param(("id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	bandResult, err := ctl.BandUC.FindById(id)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Band not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	// Validate if user is a current band member
+	if bandResult.OwnerID != user.ID && !ctl.isBandMember(bandResult.Members, user.ID) {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	var listParams songinputs.ListParams
+	if err := c.BindQuery(&listParams); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(listParams); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	var paging commoninputs.PagingParams
+	if err := c.BindQuery(&paging); err != nil {
+		paging.Limit = 100
+		paging.Offset = 0
+	}
+
+	results, err := ctl.SongUC.FindByBand(bandResult, &listParams, &paging)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid key") {
+			helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", err.Error())
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	var resultOutput []*songoutputs.SongOutput
+	for _, s := range results {
+		output := ctl.mapToSongOutput(s)
+		resultOutput = append(resultOutput, output)
+	}
+
+	// Empty array if no results
+	if resultOutput == nil {
+		helpers.HTTPRes(c, http.StatusOK, "Songs successfully listed!", []string{})
+		return
+	}
+
+	// Formatted array
+	helpers.HTTPRes(c, http.StatusOK, "Songs successfully listed!", resultOutput)
+}
+
+// @Summary Delete song endpoint
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id [delete]
+func (ctl *songController) Remove(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	id, err := ctl.stringToUint(c.Param(("id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	songResult, err := ctl.SongUC.FindById(id)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Song not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	// Verify if user is a band admin
+	if songResult.Band.OwnerID != user.ID && !ctl.isBandAdmin(songResult.Band.Members, user.ID) {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	if persistErr := ctl.SongUC.Remove(songResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error deleting song!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusNoContent, "Song successfully deleted!", nil)
+}
+
+// @Summary Full text search over songs of the account bands
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/search [get]
+func (ctl *songController) Search(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	var searchParams songinputs.SearchParams
+	if err := c.BindQuery(&searchParams); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(searchParams); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	var paging commoninputs.PagingParams
+	if err := c.BindQuery(&paging); err != nil {
+		paging.Limit = 100
+		paging.Offset = 0
+	}
+
+	results, err := ctl.SongUC.Search(user, searchParams.Query, searchParams.BandID, &paging)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	var resultOutput []*songoutputs.SongSearchOutput
+	for _, r := range results {
+		resultOutput = append(resultOutput, &songoutputs.SongSearchOutput{
+			Song:    ctl.mapToSongOutput(r.Song),
+			Rank:    r.Rank,
+			Snippet: r.Snippet,
+		})
+	}
+
+	// Empty array if no results
+	if resultOutput == nil {
+		helpers.HTTPRes(c, http.StatusOK, "Songs successfully searched!", []string{})
+		return
+	}
+
+	// Formatted array
+	helpers.HTTPRes(c, http.StatusOK, "Songs successfully searched!", resultOutput)
+}
+
+// @Summary Transpose a song and persist the new key
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/transpose [post]
+func (ctl *songController) Transpose(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	id, err := ctl.stringToUint(c.Param(("id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	songResult, err := ctl.SongUC.FindById(id)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Song not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	// Validate if user is a current band member
+	if songResult.Band.OwnerID != user.ID && !ctl.isBandMember(songResult.Band.Members, user.ID) {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	var transposeInput songinputs.TransposeInput
+	if err := c.BindJSON(&transposeInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
 
+	validate := validator.New()
+	if validationErr := validate.Struct(transposeInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	if transposeInput.Semitones == 0 && transposeInput.Key == "" {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Either semitones or key must be provided", nil)
+		return
+	}
+
+	if transposeErr := ctl.SongUC.Transpose(songResult, transposeInput.Semitones, transposeInput.Key); transposeErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, transposeErr.Error(), nil)
+		return
+	}
+
+	if persistErr := ctl.SongUC.Update(songResult, user); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting song!", persistErr.Error())
+		return
+	}
+
+	songOutput := ctl.mapToSongOutput(songResult)
+	helpers.HTTPRes(c, http.StatusOK, "Song successfully transposed", songOutput)
+}
+
+// @Summary Update song data endpoint
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id [patch]
+func (ctl *songController) Update(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	id, err := ctl.stringToUint(c.Param(("id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	songResult, err := ctl.SongUC.FindById(id)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Song not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	// Validate if user is a current band member
+	if songResult.Band.OwnerID != user.ID && !ctl.isBandMember(songResult.Band.Members, user.ID) {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	var updateInput songinputs.UpdateInput
+	if err := c.BindJSON(&updateInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(updateInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	// Update song and persist
+	if updateInput.Title != "" {
+		songResult.Title = updateInput.Title
+	}
+	if updateInput.Writter != "" {
+		songResult.Writter = updateInput.Writter
+	}
+	if updateInput.Bpm != nil {
+		songResult.Bpm = updateInput.Bpm
+	}
+	if updateInput.DurationSeconds != nil {
+		songResult.DurationSeconds = updateInput.DurationSeconds
+	}
+	if updateInput.Capo != nil {
+		songResult.Capo = *updateInput.Capo
+	}
+	if updateInput.Body != "" {
+		songResult.Body = updateInput.Body
+	}
+	if updateInput.TagIDs != nil {
+		tags, err := ctl.TagUC.FindByIds(&songResult.Band, updateInput.TagIDs)
+		if err != nil {
+			helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		songResult.Tags = tags
+	}
+
+	// Only the metadata and media link sent are validated, songs stored with
+	// values that are no longer accepted can still be edited
+	changes := &song.Song{
+		Tone:          updateInput.Tone,
+		OriginalKey:   updateInput.OriginalKey,
+		TimeSignature: updateInput.TimeSignature,
+		EmbeddedUrl:   updateInput.EmbeddedUrl,
+	}
+	if normalizeErr := ctl.SongUC.Normalize(changes); normalizeErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", normalizeErr.Error())
+		return
+	}
+	if changes.Tone != "" {
+		songResult.Tone = changes.Tone
+	}
+	if changes.OriginalKey != nil {
+		songResult.OriginalKey = changes.OriginalKey
+	}
+	if changes.TimeSignature != nil {
+		songResult.TimeSignature = changes.TimeSignature
+	}
+	if changes.EmbeddedUrl != nil {
+		songResult.EmbeddedUrl = changes.EmbeddedUrl
+	}
+
+	if updateInput.Body != "" && updateInput.Notation == "nashville" {
+		body, _, nashvilleErr := ctl.SongUC.FromNashville(songResult.Body, songResult.Tone)
+		if nashvilleErr != nil {
+			helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nashvilleErr.Error())
+			return
+		}
+		songResult.Body = body
+	}
+
+	if persistErr := ctl.SongUC.Update(songResult, user); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting song!", persistErr.Error())
+		return
+	}
+
+	songOutput := ctl.mapToSongOutput(songResult)
+	helpers.HTTPRes(c, http.StatusOK, "Song successfully updated", songOutput)
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *songController) validateTokenData(c *gin.Context) *account.Account {
+	id, exists := c.Get("user_email")
+	if exists == false {
+		return nil
+	}
+
+	user, err := ctl.AccountUc.GetAccountByEmail(id.(string))
+	if err != nil {
+		return nil
+	}
+
+	return user
+}
+
+// Loads the song from the ":id" param and checks if the user is allowed to
+// access it, responding with the proper error when it is not
+func (ctl *songController) findSong(c *gin.Context, user *account.Account, adminOnly bool) (*song.Song, bool) {
+	id, err := ctl.stringToUint(c.Param(("id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return nil, false
+	}
+
+	songResult, err := ctl.SongUC.FindById(id)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Song not found", nil)
+			return nil, false
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return nil, false
+	}
+
+	allowed := songResult.Band.OwnerID == user.ID
+	if adminOnly {
+		allowed = allowed || ctl.isBandAdmin(songResult.Band.Members, user.ID)
+	} else {
+		allowed = allowed || ctl.isBandMember(songResult.Band.Members, user.ID)
+	}
+	if !allowed {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return nil, false
+	}
+
+	return songResult, true
+}
+
+// Loads the band from the ":id" param and checks if the user is allowed to
+// access it, responding with the proper error when it is not
+func (ctl *songController) findBand(c *gin.Context, user *account.Account, adminOnly bool) (*band.Band, bool) {
+	id, err := ctl.stringToUint(c.Param(("id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return nil, false
+	}
+
+	bandResult, err := ctl.BandUC.FindById(id)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Band not found", nil)
+			return nil, false
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return nil, false
+	}
+
+	allowed := bandResult.OwnerID == user.ID
+	if adminOnly {
+		allowed = allowed || ctl.isBandAdmin(bandResult.Members, user.ID)
+	} else {
+		allowed = allowed || ctl.isBandMember(bandResult.Members, user.ID)
+	}
+	if !allowed {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return nil, false
+	}
+
+	return bandResult, true
+}
+
+func (ctl *songController) isBandMember(members []band.Member, accountID uint) bool {
+	for _, member := range members {
+		if member.AccountID == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+func (ctl *songController) isBandAdmin(members []band.Member, accountID uint) bool {
+	for _, member := range members {
+		if member.AccountID == accountID && member.Role == "admin" {
+			return true
+		}
+	}
+	return false
+}
+
+func (ctl *songController) stringToUint(IDParam string) (uint, error) {
+	songID, err := strconv.Atoi(IDParam)
+	if err != nil {
+		return 0, errors.New("id should be a number")
+	}
+	return uint(songID), nil
+}
+
+// Read time options of a chart: transposition followed by the notation
+// (?notation=nashville), none of them persisted
+func (ctl *songController) chartFromQuery(c *gin.Context, s *song.Song) error {
+	if err := ctl.transposeFromQuery(c, s); err != nil {
+		return err
+	}
+	switch c.Query("notation") {
+	case "", "letters":
+		return nil
+	case "nashville":
+		return ctl.SongUC.ToNashville(s)
+	}
+	return errors.New("notation should be letters or nashville")
+}
+
+func (ctl *songController) transposeFromQuery(c *gin.Context, s *song.Song) error {
+	key := c.Query("key")
+	semitones := 0
+	if raw := strings.TrimSpace(c.Query("transpose")); raw != "" {
+		parsed, err := strconv.Atoi(strings.TrimPrefix(raw, "+"))
+		if err != nil {
+			return errors.New("transpose should be a number of semitones")
+		}
+		semitones = parsed
+	}
+	if semitones == 0 && key == "" {
+		return nil
+	}
+	return ctl.SongUC.Transpose(s, semitones, key)
+}
+
+func (ctl *songController) mapToSongOutput(s *song.Song) *songoutputs.SongOutput {
+	output := &songoutputs.SongOutput{
+		ID:       s.ID,
+		Title:    s.Title,
+		Writter:  s.Writter,
+		Tone:     s.Tone,
+		Capo:     s.Capo,
+		Body:     s.Body,
+		IsPublic: s.IsPublic,
+		Band: &bandoutputs.BandOutput{
+			ID:          s.Band.ID,
+			Title:       s.Band.Title,
+			Description: s.Band.Description,
+		},
+	}
+	if s.OriginalKey != nil {
+		output.OriginalKey = *s.OriginalKey
+	}
+	if s.Bpm != nil {
+		output.Bpm = *s.Bpm
+	}
+	if s.TimeSignature != nil {
+		output.TimeSignature = *s.TimeSignature
+	}
+	if s.DurationSeconds != nil {
+		output.DurationSeconds = *s.DurationSeconds
+	}
+	if s.EmbeddedUrl != nil {
+		output.EmbeddedUrl = *s.EmbeddedUrl
+		output.Embed = ctl.mapToEmbedOutput(*s.EmbeddedUrl)
+	}
+	if s.ForkedFromID != nil {
+		output.ForkedFromID = *s.ForkedFromID
+		output.UpstreamSyncedAt = s.UpstreamSyncedAt
+	}
+	output.PublishedAt = s.PublishedAt
+	if s.Band.Logo != nil {
+		output.Band.Logo = *s.Band.Logo
+	}
+	output.Tags = []*songoutputs.TagOutput{}
+	for i := range s.Tags {
+		output.Tags = append(output.Tags, ctl.mapToTagOutput(&s.Tags[i]))
+	}
+	output.MediaLinks = []*songoutputs.MediaLinkOutput{}
+	for i := range s.MediaLinks {
+		output.MediaLinks = append(output.MediaLinks, ctl.mapToMediaLinkOutput(&s.MediaLinks[i]))
+	}
+	return output
 }
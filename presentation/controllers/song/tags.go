@@ -0,0 +1,229 @@
+package songcontroller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/band"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary List band tags
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/bands/:id/tags [get]
+func (ctl *songController) ListTags(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	bandResult, ok := ctl.findBand(c, user, false)
+	if !ok {
+		return
+	}
+
+	results, err := ctl.TagUC.FindByBand(bandResult)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	var resultOutput []*songoutputs.TagOutput
+	for _, t := range results {
+		resultOutput = append(resultOutput, ctl.mapToTagOutput(t))
+	}
+
+	// Empty array if no results
+	if resultOutput == nil {
+		helpers.HTTPRes(c, http.StatusOK, "Tags successfully listed!", []string{})
+		return
+	}
+
+	// Formatted array
+	helpers.HTTPRes(c, http.StatusOK, "Tags successfully listed!", resultOutput)
+}
+
+// @Summary Register a new tag into a band
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/bands/:id/tags [post]
+func (ctl *songController) CreateTag(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	bandResult, ok := ctl.findBand(c, user, true)
+	if !ok {
+		return
+	}
+
+	var newTag songinputs.TagInput
+	if err := c.BindJSON(&newTag); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(newTag); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	tagObj := song.Tag{
+		Title:  newTag.Title,
+		Color:  newTag.Color,
+		BandID: bandResult.ID,
+		Band:   *bandResult,
+	}
+
+	if persistErr := ctl.TagUC.Create(&tagObj); persistErr != nil {
+		if strings.Contains(persistErr.Error(), "already exists") {
+			helpers.HTTPRes(c, http.StatusBadRequest, persistErr.Error(), nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting tag!", persistErr.Error())
+		return
+	}
+
+	tagOutput := ctl.mapToTagOutput(&tagObj)
+	helpers.HTTPRes(c, http.StatusOK, "Tag successfully created!", tagOutput)
+}
+
+// @Summary Update band tag
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/bands/:id/tags/:tag_id [patch]
+func (ctl *songController) UpdateTag(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	bandResult, ok := ctl.findBand(c, user, true)
+	if !ok {
+		return
+	}
+
+	tagResult, ok := ctl.findTag(c, bandResult)
+	if !ok {
+		return
+	}
+
+	var updateInput songinputs.UpdateTagInput
+	if err := c.BindJSON(&updateInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(updateInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	if updateInput.Title != "" {
+		tagResult.Title = updateInput.Title
+	}
+	if updateInput.Color != nil {
+		tagResult.Color = updateInput.Color
+	}
+
+	if persistErr := ctl.TagUC.Update(tagResult); persistErr != nil {
+		if strings.Contains(persistErr.Error(), "already exists") {
+			helpers.HTTPRes(c, http.StatusBadRequest, persistErr.Error(), nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting tag!", persistErr.Error())
+		return
+	}
+
+	tagOutput := ctl.mapToTagOutput(tagResult)
+	helpers.HTTPRes(c, http.StatusOK, "Tag successfully updated", tagOutput)
+}
+
+// @Summary Delete band tag, detaching it from its songs
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/bands/:id/tags/:tag_id [delete]
+func (ctl *songController) RemoveTag(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	bandResult, ok := ctl.findBand(c, user, true)
+	if !ok {
+		return
+	}
+
+	tagResult, ok := ctl.findTag(c, bandResult)
+	if !ok {
+		return
+	}
+
+	if persistErr := ctl.TagUC.Remove(tagResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error deleting tag!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusNoContent, "Tag successfully deleted!", nil)
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *songController) findTag(c *gin.Context, b *band.Band) (*song.Tag, bool) {
+	tagID, err := ctl.stringToUint(c.Param(("tag_id")))
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, err.Error(), nil)
+		return nil, false
+	}
+
+	tagResult, err := ctl.TagUC.FindById(tagID)
+	if err != nil {
+		es := err.Error()
+		if strings.Contains(es, "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Tag not found", nil)
+			return nil, false
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return nil, false
+	}
+
+	// Tags from other bands are reported as missing
+	if tagResult.BandID != b.ID {
+		helpers.HTTPRes(c, http.StatusNotFound, "Tag not found", nil)
+		return nil, false
+	}
+
+	return tagResult, true
+}
+
+func (ctl *songController) mapToTagOutput(t *song.Tag) *songoutputs.TagOutput {
+	output := &songoutputs.TagOutput{
+		ID:    t.ID,
+		Title: t.Title,
+	}
+	if t.Color != nil {
+		output.Color = *t.Color
+	}
+	return output
+}
@@ -0,0 +1,157 @@
+package songcontroller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	songinputs "github.com/mazurco066/playliter-api-go/domain/inputs/song"
+	"github.com/mazurco066/playliter-api-go/domain/models/song"
+	songoutputs "github.com/mazurco066/playliter-api-go/domain/outputs/song"
+	"github.com/mazurco066/playliter-api-go/presentation/helpers"
+)
+
+// @Summary List the translations of a song
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/translations [get]
+func (ctl *songController) ListTranslations(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	results, err := ctl.TranslationUC.FindBySong(songResult)
+	if err != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	var resultOutput []*songoutputs.TranslationOutput
+	for _, t := range results {
+		resultOutput = append(resultOutput, ctl.mapToTranslationOutput(t))
+	}
+
+	// Empty array if no results
+	if resultOutput == nil {
+		helpers.HTTPRes(c, http.StatusOK, "Translations successfully listed!", []string{})
+		return
+	}
+
+	// Formatted array
+	helpers.HTTPRes(c, http.StatusOK, "Translations successfully listed!", resultOutput)
+}
+
+// @Summary Create or replace the translation of a song into a language
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/translations/:language [put]
+func (ctl *songController) SaveTranslation(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	var translationInput songinputs.TranslationInput
+	if err := c.BindJSON(&translationInput); err != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", nil)
+		return
+	}
+
+	validate := validator.New()
+	if validationErr := validate.Struct(translationInput); validationErr != nil {
+		helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", validationErr.Error())
+		return
+	}
+
+	language := c.Param("language")
+	translationObj, err := ctl.TranslationUC.Find(songResult, language)
+	if err != nil {
+		if !strings.Contains(err.Error(), "not found") {
+			helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+			return
+		}
+		translationObj = &song.Translation{
+			SongID:   songResult.ID,
+			Language: language,
+		}
+	}
+	translationObj.Title = translationInput.Title
+	translationObj.Body = translationInput.Body
+
+	if persistErr := ctl.TranslationUC.Save(translationObj); persistErr != nil {
+		if strings.Contains(persistErr.Error(), "invalid language") {
+			helpers.HTTPRes(c, http.StatusBadRequest, "Invalid Payload", persistErr.Error())
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error persisting translation!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusOK, "Translation successfully saved!", ctl.mapToTranslationOutput(translationObj))
+}
+
+// @Summary Delete the translation of a song into a language
+// @Produce json
+// @Success 200 {object} Response
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /api/songs/:id/translations/:language [delete]
+func (ctl *songController) RemoveTranslation(c *gin.Context) {
+	user := ctl.validateTokenData(c)
+	if user == nil {
+		helpers.HTTPRes(c, http.StatusForbidden, "Forbidden", nil)
+		return
+	}
+
+	songResult, ok := ctl.findSong(c, user, false)
+	if !ok {
+		return
+	}
+
+	translationResult, err := ctl.TranslationUC.Find(songResult, c.Param("language"))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			helpers.HTTPRes(c, http.StatusNotFound, "Translation not found", nil)
+			return
+		}
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Internal server error", nil)
+		return
+	}
+
+	if persistErr := ctl.TranslationUC.Remove(translationResult); persistErr != nil {
+		helpers.HTTPRes(c, http.StatusInternalServerError, "Error deleting translation!", persistErr.Error())
+		return
+	}
+
+	helpers.HTTPRes(c, http.StatusNoContent, "Translation successfully deleted!", nil)
+}
+
+/* =========== PRIVATE METHODS =========== */
+
+func (ctl *songController) mapToTranslationOutput(t *song.Translation) *songoutputs.TranslationOutput {
+	return &songoutputs.TranslationOutput{
+		ID:       t.ID,
+		SongID:   t.SongID,
+		Language: t.Language,
+		Title:    t.Title,
+		Body:     t.Body,
+	}
+}